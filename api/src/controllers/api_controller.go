@@ -1,33 +1,194 @@
 package controllers
 
 import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 )
 
-func StartAPI() {
+// Time given to in-flight requests to finish when the API is stopped
+const shutdownTimeout = 10 * time.Second
+
+// Maximum duration of a request when API_REQUEST_TIMEOUT is not defined
+const defaultRequestTimeout = 30 * time.Second
+
+func newRouter() *gin.Engine {
 	router := gin.Default()
 
 	// Configure CORS
 	config := cors.DefaultConfig()
 	config.AllowOrigins = []string{"http://localhost:4200", "http://localhost:3000"}
-	config.AllowMethods = []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"}
-	config.AllowHeaders = []string{"Content-Type", "Authorization"}
+	config.AllowMethods = []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"}
+	config.AllowHeaders = []string{"Content-Type", "Authorization", "If-Match"}
+	config.ExposeHeaders = []string{"ETag"}
 	config.AllowCredentials = true
 	router.Use(cors.New(config))
+	router.Use(requestTimeout(getRequestTimeout()))
+
+	// Authentication endpoints
+	router.POST("api/auth/register", register)
+	router.POST("api/auth/login", login)
+	router.POST("api/auth/refresh", refresh)
+
+	// Statuses of the tasks, so clients can render the board columns
+	router.GET("api/workflow", getWorkflow)
+
+	// Tasks endpoints require an authenticated user
+	tasks := router.Group("api/tasks", requireAuth)
 
 	// General endpoints
-	router.POST("api/tasks", createTask)
-	router.GET("api/tasks", getTasksList)
+	tasks.POST("", createTask)
+	tasks.GET("", getTasksList)
+	tasks.GET("/execution-order", getExecutionOrder)
+	tasks.GET("/schedule", getSchedule)
+	tasks.POST("/batch", batchTasks)
+	tasks.POST("/mass-update", massUpdateTasks)
 
 	// Task based endpoints
-	router.GET("api/tasks/:taskId", getTask)
-	router.PUT("api/tasks/:taskId", updateTask)
-	router.DELETE("api/tasks/:taskId", deleteTask)
+	tasks.GET("/:taskId", getTask)
+	tasks.PUT("/:taskId", updateTask)
+	tasks.PATCH("/:taskId", patchTask)
+	tasks.DELETE("/:taskId", deleteTask)
+	tasks.GET("/:taskId/history", getTaskHistory)
+	tasks.POST("/:taskId/restore", restoreTask)
+	tasks.GET("/:taskId/subtasks", getSubtasks)
+	tasks.GET("/:taskId/occurrences", getOccurrences)
+
+	// Dependency endpoints
+	tasks.GET("/:taskId/dependencies", getDependencies)
+	tasks.POST("/:taskId/dependencies/:dependsOnId", addDependency)
+	tasks.DELETE("/:taskId/dependencies/:dependsOnId", deleteDependency)
+
+	// Checklist endpoints
+	tasks.GET("/:taskId/checklist", getChecklist)
+	tasks.POST("/:taskId/checklist", addChecklistItem)
+	tasks.PUT("/:taskId/checklist/order", reorderChecklist)
+	tasks.PATCH("/:taskId/checklist/:itemId", updateChecklistItem)
+	tasks.DELETE("/:taskId/checklist/:itemId", deleteChecklistItem)
+
+	// Task labels endpoints
+	tasks.POST("/:taskId/tags/:tagId", addTaskTag)
+	tasks.DELETE("/:taskId/tags/:tagId", removeTaskTag)
+
+	// Comments endpoints
+	tasks.GET("/:taskId/comments", getComments)
+	tasks.POST("/:taskId/comments", addComment)
+	tasks.PUT("/:taskId/comments/:commentId", updateComment)
+	tasks.DELETE("/:taskId/comments/:commentId", deleteComment)
+
+	// Attachments endpoints
+	tasks.GET("/:taskId/attachments", getAttachments)
+	tasks.POST("/:taskId/attachments", addAttachment)
+	tasks.GET("/:taskId/attachments/:attachmentId", downloadAttachment)
+	tasks.DELETE("/:taskId/attachments/:attachmentId", deleteAttachment)
+
+	// Reminders endpoints
+	tasks.GET("/:taskId/reminders", getReminders)
+	tasks.POST("/:taskId/reminders", addReminder)
+	tasks.DELETE("/:taskId/reminders/:reminderId", deleteReminder)
+
+	// Deleted tasks, kept until restored or purged
+	router.GET("api/trash", requireAuth, getTrash)
+
+	// Projects endpoints
+	projects := router.Group("api/projects", requireAuth)
+	projects.POST("", createProject)
+	projects.GET("", getProjectsList)
+	projects.GET("/:projectId", getProject)
+	projects.PUT("/:projectId", updateProject)
+	projects.DELETE("/:projectId", deleteProject)
+
+	// Project sharing endpoints
+	projects.GET("/:projectId/members", getProjectMembers)
+	projects.POST("/:projectId/members", setProjectMember)
+	projects.DELETE("/:projectId/members/:userId", deleteProjectMember)
+
+	// Tags endpoints
+	tags := router.Group("api/tags", requireAuth)
+	tags.POST("", createTag)
+	tags.GET("", getTagsList)
+	tags.PATCH("/:tagId", updateTag)
+	tags.DELETE("/:tagId", deleteTag)
 
 	router.GET("/api/documentation/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
-	router.Run() // listen and serve on 0.0.0.0:8080 (for windows "localhost:8080")
+	return router
+}
+
+// Bounds the duration of each request. The deadline is carried by the request
+// context down to the database queries, which are canceled when it expires.
+func requestTimeout(timeout time.Duration) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if timeout <= 0 {
+			c.Next()
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(c.Request.Context(), timeout)
+		defer cancel()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+	}
+}
+
+// Reads the request timeout from API_REQUEST_TIMEOUT env variable (0 disables it)
+func getRequestTimeout() time.Duration {
+	value, exist := os.LookupEnv("API_REQUEST_TIMEOUT")
+	if !exist || value == "" {
+		return defaultRequestTimeout
+	}
+
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout < 0 {
+		log.Fatalf("Invalid API_REQUEST_TIMEOUT value '%s', must be a duration >= 0", value)
+	}
+
+	return timeout
+}
+
+// StartAPI serves the API until an interrupt or terminate signal is received,
+// then waits for the in-flight requests before returning.
+func StartAPI() {
+	// listen and serve on 0.0.0.0:8080 (or the port defined by PORT env variable)
+	address := ":8080"
+	if port := os.Getenv("PORT"); port != "" {
+		address = ":" + port
+	}
+
+	server := &http.Server{Addr: address, Handler: newRouter()}
+
+	stopCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-serverErr:
+		if !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("API server failed: %v\n", err)
+		}
+		return
+	case <-stopCtx.Done():
+		log.Println("Shutting down API...")
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("API shutdown failed: %v\n", err)
+	}
 }
@@ -0,0 +1,57 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestTimeout(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var deadline time.Time
+	var hasDeadline bool
+
+	router := gin.New()
+	router.Use(requestTimeout(2 * time.Second))
+	router.GET("/test", func(c *gin.Context) {
+		deadline, hasDeadline = c.Request.Context().Deadline()
+		c.Status(http.StatusOK)
+	})
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.True(t, hasDeadline, "Request context should have a deadline")
+	assert.WithinDuration(t, time.Now().Add(2*time.Second), deadline, time.Second, "Wrong request deadline")
+}
+
+func TestRequestTimeoutDisabled(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	hasDeadline := true
+
+	router := gin.New()
+	router.Use(requestTimeout(0))
+	router.GET("/test", func(c *gin.Context) {
+		_, hasDeadline = c.Request.Context().Deadline()
+		c.Status(http.StatusOK)
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/test", nil))
+
+	assert.False(t, hasDeadline, "Request context should not have a deadline")
+}
+
+func TestGetRequestTimeout(t *testing.T) {
+	assert.Equal(t, defaultRequestTimeout, getRequestTimeout(), "Should return default timeout when env is not set")
+
+	t.Setenv("API_REQUEST_TIMEOUT", "45s")
+	assert.Equal(t, 45*time.Second, getRequestTimeout(), "Should return timeout from env")
+}
@@ -0,0 +1,179 @@
+package controllers
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Room for the multipart headers and boundaries on top of the largest attachment
+const multipartOverhead = 64 << 10
+
+// GetAttachments Lists the attachments of a task
+//
+//	@Summary		Get the attachments of a task
+//	@Description	Lists the files attached to the task oldest first, with their type, size and uploader
+//	@Tags			Attachments
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId	path		int						true	"Task ID"
+//	@Success		200		{object}	map[string]interface{}	"Attachments retrieved successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		404		{object}	map[string]interface{}	"Task not found"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/attachments [get]
+func getAttachments(c *gin.Context) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attachments, err := service.GetAttachments(c.Request.Context(), taskId)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Attachments retrieved successfully", "data": attachments})
+}
+
+// AddAttachment Uploads a file to a task
+//
+//	@Summary		Upload an attachment
+//	@Description	Attaches the multipart "file" to the task, like a screenshot or a log. The type is detected from the content and must be an image, plain text, PDF, zip or gzip, within the maximum size (10 MB by default)
+//	@Tags			Attachments
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			taskId	path		int						true	"Task ID"
+//	@Param			file	formData	file					true	"File to attach"
+//	@Success		201		{object}	map[string]interface{}	"Attachment uploaded successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		403		{object}	map[string]interface{}	"Not allowed for the user role"
+//	@Failure		404		{object}	map[string]interface{}	"Task not found"
+//	@Failure		413		{object}	map[string]interface{}	"File too large"
+//	@Failure		415		{object}	map[string]interface{}	"File type not allowed"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/attachments [post]
+func addAttachment(c *gin.Context) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, service.MaxAttachmentSize()+multipartOverhead)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			respondServiceError(c, service.ErrAttachmentTooLarge)
+		} else {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "missing required multipart field: 'file'"})
+		}
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer file.Close()
+
+	attachmentId, err := service.AddAttachment(c.Request.Context(), taskId, fileHeader.Filename, file)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Attachment uploaded successfully", "attachmentId": attachmentId})
+}
+
+// DownloadAttachment Downloads an attachment
+//
+//	@Summary		Download an attachment
+//	@Description	Sends the contents of the attachment with its content type, as a download named after the uploaded file
+//	@Tags			Attachments
+//	@Produce		octet-stream
+//	@Param			taskId			path		int						true	"Task ID"
+//	@Param			attachmentId	path		int						true	"Attachment ID"
+//	@Success		200				{file}		file					"Attachment contents"
+//	@Failure		400				{object}	map[string]interface{}	"Bad request"
+//	@Failure		404				{object}	map[string]interface{}	"Task or attachment not found"
+//	@Failure		500				{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503				{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504				{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/attachments/{attachmentId} [get]
+func downloadAttachment(c *gin.Context) {
+	taskId, attachmentId, err := getAttachmentParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	attachment, contents, err := service.OpenAttachment(c.Request.Context(), taskId, attachmentId)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+	defer contents.Close()
+
+	disposition := mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename})
+	if disposition == "" {
+		disposition = "attachment"
+	}
+
+	// Browsers must not guess another type than the detected one, nor show the file inline
+	c.Header("X-Content-Type-Options", "nosniff")
+	c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, contents, map[string]string{"Content-Disposition": disposition})
+}
+
+// DeleteAttachment Removes an attachment
+//
+//	@Summary		Delete an attachment
+//	@Description	Removes the attachment from the task together with its contents
+//	@Tags			Attachments
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId			path		int						true	"Task ID"
+//	@Param			attachmentId	path		int						true	"Attachment ID"
+//	@Success		200				{object}	map[string]interface{}	"Attachment deleted successfully"
+//	@Failure		400				{object}	map[string]interface{}	"Bad request"
+//	@Failure		403				{object}	map[string]interface{}	"Not allowed for the user role"
+//	@Failure		404				{object}	map[string]interface{}	"Task or attachment not found"
+//	@Failure		500				{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503				{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504				{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/attachments/{attachmentId} [delete]
+func deleteAttachment(c *gin.Context) {
+	taskId, attachmentId, err := getAttachmentParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.DeleteAttachment(c.Request.Context(), taskId, attachmentId); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Attachment deleted successfully"})
+}
+
+func getAttachmentParams(c *gin.Context) (uint, uint, error) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	attachmentId, err := service.ValidateAttachmentIdInput(c.Param("attachmentId"))
+	return taskId, attachmentId, err
+}
@@ -0,0 +1,89 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// Uploads content as the multipart "file" of an attachments request
+func uploadAttachment(router *gin.Engine, path string, filename string, content string, tokens service.AuthTokens) *httptest.ResponseRecorder {
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	part, _ := writer.CreateFormFile("file", filename)
+	part.Write([]byte(content))
+	writer.Close()
+
+	request := httptest.NewRequest(http.MethodPost, path, body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	request.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestAttachmentEndpoints(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "crash on start"}`, tokens)
+
+	recorder := uploadAttachment(router, "/api/tasks/1/attachments", "crash.log", "panic: nil map\n", tokens)
+	assert.Equal(t, http.StatusCreated, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, `{"message": "Attachment uploaded successfully", "attachmentId": 1}`, recorder.Body.String(), "Invalid response")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1/attachments", "", tokens)
+	var response struct {
+		Data []service.AttachmentInfo `json:"data"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.Len(t, response.Data, 1, "Invalid amount of attachments")
+	assert.Equal(t, "crash.log", response.Data[0].Filename)
+	assert.Equal(t, int64(15), response.Data[0].Size)
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1/attachments/1", "", tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.Equal(t, "panic: nil map\n", recorder.Body.String())
+	assert.Equal(t, "text/plain; charset=utf-8", recorder.Header().Get("Content-Type"))
+	assert.Equal(t, `attachment; filename=crash.log`, recorder.Header().Get("Content-Disposition"))
+	assert.Equal(t, "nosniff", recorder.Header().Get("X-Content-Type-Options"))
+
+	recorder = serveAuthenticated(router, http.MethodDelete, "/api/tasks/1/attachments/1", "", tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1/attachments/1", "", tokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Deleted attachment should not be found")
+}
+
+func TestAttachmentEndpointsLimits(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "crash on start"}`, tokens)
+
+	recorder := uploadAttachment(router, "/api/tasks/1/attachments", "page.html", "<html><body>hi</body></html>", tokens)
+	assert.Equal(t, http.StatusUnsupportedMediaType, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	service.SetMaxAttachmentSize(10)
+	defer service.SetMaxAttachmentSize(10 << 20)
+	recorder = uploadAttachment(router, "/api/tasks/1/attachments", "crash.log", "panic: nil map\n", tokens)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	recorder = uploadAttachment(router, "/api/tasks/1/attachments", "crash.log", strings.Repeat("a", 128<<10), tokens)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, recorder.Code, "Request body over the limit should be rejected")
+
+	recorder = serveAuthenticated(router, http.MethodPost, "/api/tasks/1/attachments", `{"file": "crash.log"}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, `{"error": "missing required multipart field: 'file'"}`, recorder.Body.String(), "Invalid response")
+}
@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"net/http"
+	"strings"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RefreshRequestBody struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// Register Creates a new user
+//
+//	@Summary		Register a user
+//	@Description	Creates a new user able to login on the API
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			user	body		service.UserRequestBody	true	"User credentials"
+//	@Success		201		{object}	map[string]interface{}	"User created successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		409		{object}	map[string]interface{}	"Username already taken"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/auth/register [post]
+func register(c *gin.Context) {
+	var requestBody service.UserRequestBody
+	var err error
+	if err = c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.ValidateUserInput(requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var userId uint
+	if userId, err = service.RegisterUser(c.Request.Context(), requestBody); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "User created successfully", "userId": userId})
+}
+
+// Login Authenticates a user
+//
+//	@Summary		Login
+//	@Description	Exchanges the user credentials for an access token and a refresh token
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			user	body		service.UserRequestBody	true	"User credentials"
+//	@Success		200		{object}	map[string]interface{}	"Login successful"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		401		{object}	map[string]interface{}	"Invalid credentials"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/auth/login [post]
+func login(c *gin.Context) {
+	var requestBody service.UserRequestBody
+	var err error
+	if err = c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.ValidateLoginInput(requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tokens, err := service.Login(c.Request.Context(), requestBody)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Login successful", "tokens": tokens})
+}
+
+// Refresh Renews the tokens of a user
+//
+//	@Summary		Refresh tokens
+//	@Description	Exchanges a valid refresh token for a new access token and refresh token
+//	@Tags			Auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			token	body		RefreshRequestBody		true	"Refresh token"
+//	@Success		200		{object}	map[string]interface{}	"Tokens refreshed successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		401		{object}	map[string]interface{}	"Invalid or expired token"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/auth/refresh [post]
+func refresh(c *gin.Context) {
+	var requestBody RefreshRequestBody
+	if err := c.ShouldBindJSON(&requestBody); err != nil || requestBody.RefreshToken == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing required field: 'refresh_token'"})
+		return
+	}
+
+	tokens, err := service.RefreshTokens(c.Request.Context(), requestBody.RefreshToken)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tokens refreshed successfully", "tokens": tokens})
+}
+
+// Rejects the requests without a valid access token ("Authorization: Bearer <token>").
+// The authenticated user is carried by the request context to the services.
+func requireAuth(c *gin.Context) {
+	token, found := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+	if !found || token == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "missing bearer token"})
+		return
+	}
+
+	userId, err := service.AuthenticateToken(token)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Request = c.Request.WithContext(service.ContextWithUser(c.Request.Context(), userId))
+	c.Next()
+}
@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"to-do-api/models"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setMockUserRepository() {
+	service.SetUserRepository(models.NewMemoryUserRepository())
+	service.SetAuthConfig(service.AuthConfig{Secret: []byte("test secret"), AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour})
+}
+
+func getAuthTestContext(body string) (*gin.Context, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+
+	recorder := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(recorder)
+	context.Request = httptest.NewRequest(http.MethodPost, "/api/auth", bytes.NewBufferString(body))
+
+	return context, recorder
+}
+
+// Registers a user and returns its login tokens
+func registerTestUser(t *testing.T) service.AuthTokens {
+	return registerNamedTestUser(t, "alice")
+}
+
+func registerNamedTestUser(t *testing.T, username string) service.AuthTokens {
+	credentials := fmt.Sprintf(`{"username": "%s", "password": "password123"}`, username)
+	context, recorder := getAuthTestContext(credentials)
+	register(context)
+	assert.Equal(t, http.StatusCreated, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	context, recorder = getAuthTestContext(credentials)
+	login(context)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	var response struct {
+		Tokens service.AuthTokens `json:"tokens"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&response)
+	return response.Tokens
+}
+
+func TestRegisterAndLogin(t *testing.T) {
+	setMockUserRepository()
+
+	tokens := registerTestUser(t)
+
+	assert.NotEmpty(t, tokens.AccessToken, "Login should return an access token")
+	assert.NotEmpty(t, tokens.RefreshToken, "Login should return a refresh token")
+}
+
+func TestRegisterTakenUsername(t *testing.T) {
+	setMockUserRepository()
+	registerTestUser(t)
+
+	context, recorder := getAuthTestContext(`{"username": "alice", "password": "password123"}`)
+	register(context)
+
+	responseBody, _ := io.ReadAll(recorder.Body)
+	assert.Equal(t, http.StatusConflict, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, "{\"error\":\"username already taken\"}", string(responseBody), "Invalid response JSON")
+}
+
+func TestLoginWrongPassword(t *testing.T) {
+	setMockUserRepository()
+	registerTestUser(t)
+
+	context, recorder := getAuthTestContext(`{"username": "alice", "password": "wrong password"}`)
+	login(context)
+
+	responseBody, _ := io.ReadAll(recorder.Body)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, "{\"error\":\"invalid username or password\"}", string(responseBody), "Invalid response JSON")
+}
+
+func TestRefresh(t *testing.T) {
+	setMockUserRepository()
+	tokens := registerTestUser(t)
+
+	context, recorder := getAuthTestContext(fmt.Sprintf(`{"refresh_token": "%s"}`, tokens.RefreshToken))
+	refresh(context)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	context, recorder = getAuthTestContext(fmt.Sprintf(`{"refresh_token": "%s"}`, tokens.AccessToken))
+	refresh(context)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+}
+
+func TestTasksRequireAuth(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/tasks", nil))
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code, "Request without token should be rejected")
+
+	request := httptest.NewRequest(http.MethodGet, "/api/tasks", nil)
+	request.Header.Set("Authorization", "Bearer "+tokens.RefreshToken)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code, "Request with refresh token should be rejected")
+
+	request = httptest.NewRequest(http.MethodGet, "/api/tasks", nil)
+	request.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Request with access token should be accepted")
+}
+
+// Sends an authenticated request through the API router
+func serveAuthenticated(router *gin.Engine, method string, path string, body string, tokens service.AuthTokens) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(method, path, bytes.NewBufferString(body))
+	request.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestTasksOfOtherUsersNotFound(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	aliceTokens := registerNamedTestUser(t, "alice")
+	bobTokens := registerNamedTestUser(t, "bob")
+	router := newRouter()
+
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "alice task"}`, aliceTokens)
+	assert.Equal(t, http.StatusCreated, recorder.Code, "Task should be created")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1", "", aliceTokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Owner should read the task")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1", "", bobTokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Other users should not read the task")
+	recorder = serveAuthenticated(router, http.MethodPatch, "/api/tasks/1", `{"title": "bob title"}`, bobTokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Other users should not update the task")
+	recorder = serveAuthenticated(router, http.MethodDelete, "/api/tasks/1", "", bobTokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Other users should not delete the task")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks", "", bobTokens)
+	var response struct {
+		Data       []service.TaskInfo `json:"data"`
+		Pagination map[string]uint    `json:"pagination"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Empty(t, response.Data, "Other users should not list the task")
+	assert.Equal(t, uint(0), response.Pagination["total_tasks"], "Other users should not count the task")
+}
@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type batchItemResponse struct {
+	Index  int    `json:"index"`
+	Op     string `json:"op"`
+	TaskId uint   `json:"task_id"`
+	Status int    `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// BatchTasks Applies many task operations in one transaction
+//
+//	@Summary		Create, update and delete many tasks
+//	@Description	Applies up to 100 operations (create, update, patch, delete) in order inside one transaction. In 'atomic' mode (default) the first failed operation rolls back the whole batch and its error is returned with its index. In 'per-item' mode each operation is applied or rolled back on its own, and the result of each one holds its HTTP status
+//	@Tags			Tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			batch	body		service.BatchRequestBody	true	"Operations to apply"
+//	@Success		200		{object}	map[string]interface{}		"Batch applied"
+//	@Failure		400		{object}	map[string]interface{}		"Bad request"
+//	@Failure		403		{object}	map[string]interface{}		"Not allowed for the user role"
+//	@Failure		404		{object}	map[string]interface{}		"Task not found"
+//	@Failure		409		{object}	map[string]interface{}		"Project is archived or status transition not allowed"
+//	@Failure		412		{object}	map[string]interface{}		"Task modified since the expected version"
+//	@Failure		500		{object}	map[string]interface{}		"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}		"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}		"Database timeout"
+//	@Router			/api/tasks/batch [post]
+func batchTasks(c *gin.Context) {
+	var requestBody service.BatchRequestBody
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	operations, err := service.ValidateBatchInput(requestBody)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	results, err := service.RunBatch(c.Request.Context(), requestBody.Mode, operations)
+	var batchErr *service.BatchError
+	if errors.As(err, &batchErr) {
+		c.JSON(serviceErrorStatus(err), gin.H{"error": err.Error(), "index": batchErr.Index})
+		return
+	} else if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	response := []batchItemResponse{}
+	for _, result := range results {
+		item := batchItemResponse{Index: result.Index, Op: result.Op, TaskId: result.TaskId, Status: http.StatusOK}
+		if result.Op == service.OpCreate {
+			item.Status = http.StatusCreated
+		}
+		if result.Err != nil {
+			item.Status = serviceErrorStatus(result.Err)
+			item.Error = result.Err.Error()
+		}
+		response = append(response, item)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Batch applied", "results": response})
+}
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchTasksAtomic(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "task"}`, tokens)
+
+	batch := `{"operations": [{"op": "create", "task": {"title": "new task"}}, {"op": "delete", "task_id": 9}]}`
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tasks/batch", batch, tokens)
+
+	var response map[string]any
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.Equal(t, float64(1), response["index"], "Invalid index of the failed operation")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/2", "", tokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Created task should be rolled back")
+}
+
+func TestBatchTasksPerItem(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "task"}`, tokens)
+
+	batch := `{"mode": "per-item", "operations": [
+		{"op": "create", "task": {"title": "new task"}},
+		{"op": "patch", "task_id": 1, "version": 5, "task": {"priority": 2}},
+		{"op": "delete", "task_id": 1}
+	]}`
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tasks/batch", batch, tokens)
+
+	var response struct {
+		Results []batchItemResponse `json:"results"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.Equal(t, []batchItemResponse{
+		{Index: 0, Op: "create", TaskId: 2, Status: http.StatusCreated},
+		{Index: 1, Op: "patch", TaskId: 1, Status: http.StatusPreconditionFailed, Error: "task was modified since it was read"},
+		{Index: 2, Op: "delete", TaskId: 1, Status: http.StatusOK},
+	}, response.Results, "Invalid results")
+}
+
+func TestBatchTasksInvalidOperation(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tasks/batch", `{"operations": [{"op": "delete"}]}`, tokens)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, `{"error": "operation 0: missing required field: 'task_id'"}`, recorder.Body.String(), "Invalid response")
+}
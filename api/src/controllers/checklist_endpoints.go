@@ -0,0 +1,212 @@
+package controllers
+
+import (
+	"net/http"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetChecklist Lists the checklist items of a task
+//
+//	@Summary		Get the checklist of a task
+//	@Description	Lists the checklist items of the task in their order
+//	@Tags			Checklist
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId	path		int						true	"Task ID"
+//	@Success		200		{object}	map[string]interface{}	"Checklist retrieved successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		404		{object}	map[string]interface{}	"Task not found"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/checklist [get]
+func getChecklist(c *gin.Context) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	checklist, err := service.GetChecklist(c.Request.Context(), taskId)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Checklist retrieved successfully", "data": checklist})
+}
+
+// AddChecklistItem Adds an item to the checklist of a task
+//
+//	@Summary		Add a checklist item
+//	@Description	Appends an item at the end of the checklist of the task
+//	@Tags			Checklist
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId	path		int									true	"Task ID"
+//	@Param			item	body		service.ChecklistItemRequestBody	true	"Item to add"
+//	@Success		201		{object}	map[string]interface{}				"Checklist item created successfully"
+//	@Failure		400		{object}	map[string]interface{}				"Bad request"
+//	@Failure		403		{object}	map[string]interface{}				"Not allowed for the user role"
+//	@Failure		404		{object}	map[string]interface{}				"Task not found"
+//	@Failure		500		{object}	map[string]interface{}				"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}				"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}				"Database timeout"
+//	@Router			/api/tasks/{taskId}/checklist [post]
+func addChecklistItem(c *gin.Context) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var requestBody service.ChecklistItemRequestBody
+	if err = c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.ValidateChecklistItemInput(requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	itemId, err := service.AddChecklistItem(c.Request.Context(), taskId, requestBody)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Checklist item created successfully", "itemId": itemId})
+}
+
+// UpdateChecklistItem Changes the text of a checklist item or toggles it
+//
+//	@Summary		Update a checklist item
+//	@Description	Changes the text of the item, or checks and unchecks it. Fields missing from the request are kept
+//	@Tags			Checklist
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId	path		int									true	"Task ID"
+//	@Param			itemId	path		int									true	"Checklist item ID"
+//	@Param			item	body		service.ChecklistItemRequestBody	true	"Fields to change"
+//	@Success		200		{object}	map[string]interface{}				"Checklist item updated successfully"
+//	@Failure		400		{object}	map[string]interface{}				"Bad request"
+//	@Failure		403		{object}	map[string]interface{}				"Not allowed for the user role"
+//	@Failure		404		{object}	map[string]interface{}				"Task or item not found"
+//	@Failure		500		{object}	map[string]interface{}				"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}				"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}				"Database timeout"
+//	@Router			/api/tasks/{taskId}/checklist/{itemId} [patch]
+func updateChecklistItem(c *gin.Context) {
+	taskId, itemId, err := getChecklistItemParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var requestBody service.ChecklistItemRequestBody
+	if err = c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.ValidateChecklistItemUpdateInput(requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.UpdateChecklistItem(c.Request.Context(), taskId, itemId, requestBody); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Checklist item updated successfully"})
+}
+
+// ReorderChecklist Changes the order of the checklist items
+//
+//	@Summary		Reorder the checklist of a task
+//	@Description	Sorts the checklist as the given item IDs, which must list every item of the checklist once
+//	@Tags			Checklist
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId	path		int									true	"Task ID"
+//	@Param			order	body		service.ChecklistOrderRequestBody	true	"Item IDs in the new order"
+//	@Success		200		{object}	map[string]interface{}				"Checklist reordered successfully"
+//	@Failure		400		{object}	map[string]interface{}				"Bad request"
+//	@Failure		403		{object}	map[string]interface{}				"Not allowed for the user role"
+//	@Failure		404		{object}	map[string]interface{}				"Task not found"
+//	@Failure		500		{object}	map[string]interface{}				"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}				"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}				"Database timeout"
+//	@Router			/api/tasks/{taskId}/checklist/order [put]
+func reorderChecklist(c *gin.Context) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var requestBody service.ChecklistOrderRequestBody
+	if err = c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.ValidateChecklistOrderInput(requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.ReorderChecklist(c.Request.Context(), taskId, requestBody.ItemIds); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Checklist reordered successfully"})
+}
+
+// DeleteChecklistItem Removes an item from the checklist of a task
+//
+//	@Summary		Delete a checklist item
+//	@Description	Removes the item from the checklist of the task
+//	@Tags			Checklist
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId	path		int						true	"Task ID"
+//	@Param			itemId	path		int						true	"Checklist item ID"
+//	@Success		200		{object}	map[string]interface{}	"Checklist item deleted successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		403		{object}	map[string]interface{}	"Not allowed for the user role"
+//	@Failure		404		{object}	map[string]interface{}	"Task or item not found"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/checklist/{itemId} [delete]
+func deleteChecklistItem(c *gin.Context) {
+	taskId, itemId, err := getChecklistItemParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.DeleteChecklistItem(c.Request.Context(), taskId, itemId); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Checklist item deleted successfully"})
+}
+
+func getChecklistItemParams(c *gin.Context) (uint, uint, error) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	itemId, err := service.ValidateChecklistItemIdInput(c.Param("itemId"))
+	return taskId, itemId, err
+}
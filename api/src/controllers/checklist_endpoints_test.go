@@ -0,0 +1,68 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"to-do-api/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChecklistEndpoints(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "groceries"}`, tokens)
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tasks/1/checklist", `{"text": "milk"}`, tokens)
+	assert.Equal(t, http.StatusCreated, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	serveAuthenticated(router, http.MethodPost, "/api/tasks/1/checklist", `{"text": "bread"}`, tokens)
+
+	recorder = serveAuthenticated(router, http.MethodPatch, "/api/tasks/1/checklist/1", `{"checked": true}`, tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	recorder = serveAuthenticated(router, http.MethodPut, "/api/tasks/1/checklist/order", `{"item_ids": [2, 1]}`, tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1/checklist", "", tokens)
+	var response struct {
+		Data []service.ChecklistItemInfo `json:"data"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.Equal(t, []service.ChecklistItemInfo{
+		{Id: 2, Text: "bread", Position: 0},
+		{Id: 1, Text: "milk", Checked: true, Position: 1},
+	}, response.Data, "Invalid checklist")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks", "", tokens)
+	assert.Contains(t, recorder.Body.String(), `"checklist_total":2,"checklist_checked":1`, "Task list should count the checklist items")
+
+	recorder = serveAuthenticated(router, http.MethodDelete, "/api/tasks/1/checklist/2", "", tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	recorder = serveAuthenticated(router, http.MethodDelete, "/api/tasks/1/checklist/2", "", tokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Deleted item should not be found")
+}
+
+func TestChecklistEndpointsInvalidInput(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "groceries"}`, tokens)
+
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tasks/1/checklist", `{"checked": true}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, `{"error": "missing required field: 'text'"}`, recorder.Body.String(), "Invalid response")
+
+	recorder = serveAuthenticated(router, http.MethodPatch, "/api/tasks/1/checklist/a", `{"checked": true}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	recorder = serveAuthenticated(router, http.MethodPut, "/api/tasks/1/checklist/order", `{"item_ids": [3]}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Order should list the items of the checklist")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/4/checklist", "", tokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+}
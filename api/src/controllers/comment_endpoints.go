@@ -0,0 +1,168 @@
+package controllers
+
+import (
+	"net/http"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetComments Lists the comments of a task
+//
+//	@Summary		Get the comments of a task
+//	@Description	Lists the comments of the task oldest first, with their author and the bodies they had before each edit. Comments are hidden while the task is in the trash
+//	@Tags			Comments
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId	path		int						true	"Task ID"
+//	@Success		200		{object}	map[string]interface{}	"Comments retrieved successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		404		{object}	map[string]interface{}	"Task not found"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/comments [get]
+func getComments(c *gin.Context) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	comments, err := service.GetComments(c.Request.Context(), taskId)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comments retrieved successfully", "data": comments})
+}
+
+// AddComment Comments a task
+//
+//	@Summary		Add a comment to a task
+//	@Description	Adds a comment to the task as the current user. Every user who can see the task can comment it
+//	@Tags			Comments
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId	path		int							true	"Task ID"
+//	@Param			comment	body		service.CommentRequestBody	true	"Comment"
+//	@Success		201		{object}	map[string]interface{}		"Comment created successfully"
+//	@Failure		400		{object}	map[string]interface{}		"Bad request"
+//	@Failure		404		{object}	map[string]interface{}		"Task not found"
+//	@Failure		500		{object}	map[string]interface{}		"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}		"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}		"Database timeout"
+//	@Router			/api/tasks/{taskId}/comments [post]
+func addComment(c *gin.Context) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var requestBody service.CommentRequestBody
+	if err = c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.ValidateCommentInput(requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	commentId, err := service.AddComment(c.Request.Context(), taskId, requestBody)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Comment created successfully", "commentId": commentId})
+}
+
+// UpdateComment Edits a comment
+//
+//	@Summary		Edit a comment
+//	@Description	Replaces the body of a comment of the current user, the previous body being kept on its edits
+//	@Tags			Comments
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId		path		int							true	"Task ID"
+//	@Param			commentId	path		int							true	"Comment ID"
+//	@Param			comment		body		service.CommentRequestBody	true	"New comment"
+//	@Success		200			{object}	map[string]interface{}		"Comment updated successfully"
+//	@Failure		400			{object}	map[string]interface{}		"Bad request"
+//	@Failure		403			{object}	map[string]interface{}		"Comment of another user"
+//	@Failure		404			{object}	map[string]interface{}		"Task or comment not found"
+//	@Failure		500			{object}	map[string]interface{}		"Internal server error"
+//	@Failure		503			{object}	map[string]interface{}		"Database unavailable"
+//	@Failure		504			{object}	map[string]interface{}		"Database timeout"
+//	@Router			/api/tasks/{taskId}/comments/{commentId} [put]
+func updateComment(c *gin.Context) {
+	taskId, commentId, err := getCommentParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var requestBody service.CommentRequestBody
+	if err = c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.ValidateCommentInput(requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.UpdateComment(c.Request.Context(), taskId, commentId, requestBody); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment updated successfully"})
+}
+
+// DeleteComment Removes a comment
+//
+//	@Summary		Delete a comment
+//	@Description	Removes a comment of the current user, together with its edits
+//	@Tags			Comments
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId		path		int						true	"Task ID"
+//	@Param			commentId	path		int						true	"Comment ID"
+//	@Success		200			{object}	map[string]interface{}	"Comment deleted successfully"
+//	@Failure		400			{object}	map[string]interface{}	"Bad request"
+//	@Failure		403			{object}	map[string]interface{}	"Comment of another user"
+//	@Failure		404			{object}	map[string]interface{}	"Task or comment not found"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503			{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504			{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/comments/{commentId} [delete]
+func deleteComment(c *gin.Context) {
+	taskId, commentId, err := getCommentParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.DeleteComment(c.Request.Context(), taskId, commentId); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Comment deleted successfully"})
+}
+
+func getCommentParams(c *gin.Context) (uint, uint, error) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	commentId, err := service.ValidateCommentIdInput(c.Param("commentId"))
+	return taskId, commentId, err
+}
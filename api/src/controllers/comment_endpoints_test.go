@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"to-do-api/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommentEndpoints(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "release"}`, tokens)
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tasks/1/comments", `{"body": "Which version?"}`, tokens)
+	assert.Equal(t, http.StatusCreated, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	recorder = serveAuthenticated(router, http.MethodPut, "/api/tasks/1/comments/1", `{"body": "Which version number?"}`, tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1/comments", "", tokens)
+	var response struct {
+		Data []service.CommentInfo `json:"data"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.Len(t, response.Data, 1, "Invalid amount of comments")
+	assert.Equal(t, "Which version number?", response.Data[0].Body)
+	assert.Equal(t, "Which version?", response.Data[0].Edits[0].Body, "Edit history should be returned")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks", "", tokens)
+	assert.Contains(t, recorder.Body.String(), `"comment_count":1`, "Task list should count the comments")
+
+	serveAuthenticated(router, http.MethodDelete, "/api/tasks/1", "", tokens)
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1/comments", "", tokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Comments should be hidden with the task in the trash")
+	serveAuthenticated(router, http.MethodPost, "/api/tasks/1/restore", "", tokens)
+
+	recorder = serveAuthenticated(router, http.MethodDelete, "/api/tasks/1/comments/1", "", tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	recorder = serveAuthenticated(router, http.MethodDelete, "/api/tasks/1/comments/1", "", tokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Deleted comment should not be found")
+}
+
+func TestCommentEndpointsInvalidInput(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "release"}`, tokens)
+
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tasks/1/comments", `{"body": ""}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, `{"error": "body must not be empty"}`, recorder.Body.String(), "Invalid response")
+
+	recorder = serveAuthenticated(router, http.MethodPut, "/api/tasks/1/comments/a", `{"body": "edit"}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+}
@@ -1,13 +1,13 @@
 package controllers
 
 import (
+	"database/sql"
 	"fmt"
 	"io"
 	"net/http"
 	"testing"
-	"to-do-api/service"
+	"to-do-api/models"
 
-	"bou.ke/monkey"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -15,11 +15,7 @@ func TestCreateTask(t *testing.T) {
 	requestBody := TestTaskRequestBody{Title: "new task"}
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 
-	// Mock internal functions
-	monkey.Patch(service.CreateNewTask, func(task service.TaskRequestBody) (uint, error) {
-		return 1, nil
-	})
-	defer monkey.UnpatchAll()
+	setMockRepository()
 
 	// Call the handler
 	createTask(context)
@@ -64,11 +60,11 @@ func TestCreateTaskServerError(t *testing.T) {
 	requestBody := TestTaskRequestBody{Title: "new task"}
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 
-	// Mock internal functions
-	monkey.Patch(service.CreateNewTask, func(task service.TaskRequestBody) (uint, error) {
-		return 1, service.ErrDatabaseGeneral
-	})
-	defer monkey.UnpatchAll()
+	// Mock repository functions
+	repository := setMockRepository()
+	repository.addTask = func(newTask models.Task) (uint, error) {
+		return 0, sql.ErrConnDone
+	}
 
 	// Call the handler
 	createTask(context)
@@ -0,0 +1,191 @@
+package controllers
+
+import (
+	"net/http"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AddDependency Makes a task depend on another one
+//
+//	@Summary		Add a task dependency
+//	@Description	Makes the task wait for another task to be done. Dependencies closing a cycle are rejected.
+//	@Tags			Dependencies
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId		path		int						true	"Task ID"
+//	@Param			dependsOnId	path		int						true	"ID of the task it depends on"
+//	@Success		201			{object}	map[string]interface{}	"Dependency created successfully"
+//	@Failure		400			{object}	map[string]interface{}	"Bad request"
+//	@Failure		403			{object}	map[string]interface{}	"Not allowed for the user role"
+//	@Failure		404			{object}	map[string]interface{}	"Task not found"
+//	@Failure		409			{object}	map[string]interface{}	"Dependency cycle or already existing dependency"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503			{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504			{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/dependencies/{dependsOnId} [post]
+func addDependency(c *gin.Context) {
+	taskId, dependsOnId, err := getDependencyParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.AddTaskDependency(c.Request.Context(), taskId, dependsOnId); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Dependency created successfully"})
+}
+
+// DeleteDependency Removes a dependency between tasks
+//
+//	@Summary		Delete a task dependency
+//	@Description	Removes the dependency of a task on another one
+//	@Tags			Dependencies
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId		path		int						true	"Task ID"
+//	@Param			dependsOnId	path		int						true	"ID of the task it depends on"
+//	@Success		200			{object}	map[string]interface{}	"Dependency deleted successfully"
+//	@Failure		400			{object}	map[string]interface{}	"Bad request"
+//	@Failure		403			{object}	map[string]interface{}	"Not allowed for the user role"
+//	@Failure		404			{object}	map[string]interface{}	"Dependency not found"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503			{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504			{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/dependencies/{dependsOnId} [delete]
+func deleteDependency(c *gin.Context) {
+	taskId, dependsOnId, err := getDependencyParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.RemoveTaskDependency(c.Request.Context(), taskId, dependsOnId); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Dependency deleted successfully"})
+}
+
+// GetDependencies Lists the tasks a task depends on
+//
+//	@Summary		Get the dependencies of a task
+//	@Description	Lists the IDs of the tasks that must be done before the task
+//	@Tags			Dependencies
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId	path		int						true	"Task ID"
+//	@Success		200		{object}	map[string]interface{}	"Dependencies retrieved successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		404		{object}	map[string]interface{}	"Task not found"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/dependencies [get]
+func getDependencies(c *gin.Context) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	dependencies, err := service.GetTaskDependencies(c.Request.Context(), taskId)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Dependencies retrieved successfully", "dependencies": dependencies})
+}
+
+// GetExecutionOrder Lists tasks in an order respecting their dependencies
+//
+//	@Summary		Get the tasks execution order
+//	@Description	Lists the tasks matching the filters so every task comes after the tasks it depends on. Ties are broken by priority (highest first) then due date (earliest first). Tasks waiting for unfinished dependencies are reported as blocked.
+//	@Tags			Dependencies
+//	@Accept			json
+//	@Produce		json
+//	@Param			title_contains			query		string					false	"Filter by title (substring match)"
+//	@Param			description_contains	query		string					false	"Filter by description (substring match)"
+//	@Param			status					query		string					false	"Filter by task status"
+//	@Param			priority				query		string					false	"Filter by task priority"
+//	@Success		200						{object}	map[string]interface{}	"Successful response"
+//	@Failure		400						{object}	map[string]interface{}	"Bad request"
+//	@Failure		500						{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503						{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504						{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/execution-order [get]
+func getExecutionOrder(c *gin.Context) {
+	filtersConfig, err := getFilterConfig(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	executionOrder, err := service.GetExecutionOrder(c.Request.Context(), filtersConfig)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Execution order computed successfully", "data": executionOrder, "blocked": service.GetBlockedTasks(executionOrder)})
+}
+
+// GetSchedule Computes the schedule of the tasks
+//
+//	@Summary		Get the tasks schedule
+//	@Description	Computes the earliest and latest start of each task matching the filters from its effort estimate and dependencies, the slack of each task and the critical path. Tasks that can not be finished before their due date are flagged.
+//	@Tags			Dependencies
+//	@Accept			json
+//	@Produce		json
+//	@Param			start					query		int						false	"Schedule start as Unix timestamp (default: now)"
+//	@Param			title_contains			query		string					false	"Filter by title (substring match)"
+//	@Param			description_contains	query		string					false	"Filter by description (substring match)"
+//	@Param			status					query		string					false	"Filter by task status"
+//	@Param			priority				query		string					false	"Filter by task priority"
+//	@Success		200						{object}	map[string]interface{}	"Successful response"
+//	@Failure		400						{object}	map[string]interface{}	"Bad request"
+//	@Failure		500						{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503						{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504						{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/schedule [get]
+func getSchedule(c *gin.Context) {
+	filtersConfig, err := getFilterConfig(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	start, err := service.ValidateScheduleStartInput(c.Query("start"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	schedule, err := service.GetSchedule(c.Request.Context(), filtersConfig, start)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Schedule computed successfully", "schedule": schedule})
+}
+
+func getDependencyParams(c *gin.Context) (uint, uint, error) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	dependsOnId, err := service.ValidateTaskIdInput(c.Param("dependsOnId"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return taskId, dependsOnId, service.ValidateDependencyInput(taskId, dependsOnId)
+}
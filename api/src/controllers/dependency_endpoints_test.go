@@ -0,0 +1,162 @@
+package controllers
+
+import (
+	stdcontext "context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"to-do-api/models"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func setMockRepositoryWithTasks(amount int) *mockTaskRepository {
+	repository := setMockRepository()
+	for i := range amount {
+		repository.AddTask(stdcontext.Background(), models.Task{Title: fmt.Sprintf("task %d", i+1)})
+	}
+	return repository
+}
+
+func TestAddDependency(t *testing.T) {
+	context, recorder := getTestGinContextAndRecorder(TestTaskRequestBody{})
+	context.Params = []gin.Param{{Key: "taskId", Value: "1"}, {Key: "dependsOnId", Value: "2"}}
+
+	setMockRepositoryWithTasks(2)
+
+	addDependency(context)
+
+	responseBody, _ := io.ReadAll(recorder.Body)
+	assert.Equal(t, http.StatusCreated, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, "{\"message\":\"Dependency created successfully\"}", string(responseBody), "Invalid response JSON")
+}
+
+func TestAddDependencyOnItself(t *testing.T) {
+	context, recorder := getTestGinContextAndRecorder(TestTaskRequestBody{})
+	context.Params = []gin.Param{{Key: "taskId", Value: "1"}, {Key: "dependsOnId", Value: "1"}}
+
+	setMockRepositoryWithTasks(1)
+
+	addDependency(context)
+
+	responseBody, _ := io.ReadAll(recorder.Body)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, "{\"error\":\"a task can not depend on itself\"}", string(responseBody), "Invalid response JSON")
+}
+
+func TestAddDependencyCycle(t *testing.T) {
+	context, recorder := getTestGinContextAndRecorder(TestTaskRequestBody{})
+	context.Params = []gin.Param{{Key: "taskId", Value: "2"}, {Key: "dependsOnId", Value: "1"}}
+
+	repository := setMockRepositoryWithTasks(2)
+	repository.AddDependency(context.Request.Context(), 1, 2)
+
+	addDependency(context)
+
+	responseBody, _ := io.ReadAll(recorder.Body)
+	assert.Equal(t, http.StatusConflict, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, "{\"error\":\"dependency would create a cycle between tasks\"}", string(responseBody), "Invalid response JSON")
+}
+
+func TestDeleteDependencyNotFound(t *testing.T) {
+	context, recorder := getTestGinContextAndRecorder(TestTaskRequestBody{})
+	context.Params = []gin.Param{{Key: "taskId", Value: "1"}, {Key: "dependsOnId", Value: "2"}}
+
+	setMockRepositoryWithTasks(2)
+
+	deleteDependency(context)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+}
+
+func TestGetDependencies(t *testing.T) {
+	context, recorder := getTestGinContextAndRecorder(TestTaskRequestBody{})
+	context.Params = []gin.Param{{Key: "taskId", Value: "1"}}
+
+	repository := setMockRepositoryWithTasks(3)
+	repository.AddDependency(context.Request.Context(), 1, 3)
+	repository.AddDependency(context.Request.Context(), 1, 2)
+
+	getDependencies(context)
+
+	responseBody, _ := io.ReadAll(recorder.Body)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, "{\"message\":\"Dependencies retrieved successfully\",\"dependencies\":[2,3]}", string(responseBody), "Invalid response JSON")
+}
+
+func TestGetExecutionOrder(t *testing.T) {
+	context, recorder := getTestGinContextAndRecorder(TestTaskRequestBody{})
+	context.Request = httptest.NewRequest(http.MethodGet, "/api/tasks/execution-order?title_contains=task", nil)
+
+	repository := setMockRepositoryWithTasks(2)
+	repository.AddDependency(context.Request.Context(), 1, 2)
+
+	getExecutionOrder(context)
+
+	var response struct {
+		Data []struct {
+			Id        uint   `json:"id"`
+			BlockedBy []uint `json:"blocked_by"`
+		} `json:"data"`
+		Blocked []uint `json:"blocked"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&response)
+
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.Len(t, response.Data, 2, "Both tasks should be listed")
+	assert.Equal(t, uint(2), response.Data[0].Id, "Dependency should come first")
+	assert.Equal(t, []uint{2}, response.Data[1].BlockedBy, "Task 1 should be blocked by task 2")
+	assert.Equal(t, []uint{1}, response.Blocked, "Task 1 should be reported as blocked")
+}
+
+func TestGetExecutionOrderInvalidFilter(t *testing.T) {
+	context, recorder := getTestGinContextAndRecorder(TestTaskRequestBody{})
+	context.Request = httptest.NewRequest(http.MethodGet, "/api/tasks/execution-order?status=@done", nil)
+
+	setMockRepository()
+
+	getExecutionOrder(context)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+}
+
+func TestGetSchedule(t *testing.T) {
+	context, recorder := getTestGinContextAndRecorder(TestTaskRequestBody{})
+	context.Request = httptest.NewRequest(http.MethodGet, "/api/tasks/schedule?start=1738569600", nil)
+
+	repository := setMockRepository()
+	repository.AddTask(context.Request.Context(), models.Task{Title: "first", EstimateHours: 2})
+	repository.AddTask(context.Request.Context(), models.Task{Title: "second", EstimateHours: 3})
+	repository.AddDependency(context.Request.Context(), 2, 1)
+
+	getSchedule(context)
+
+	var response struct {
+		Schedule struct {
+			Finish       int64  `json:"finish"`
+			CriticalPath []uint `json:"critical_path"`
+		} `json:"schedule"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&response)
+
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.Equal(t, int64(1738569600+5*3600), response.Schedule.Finish, "Schedule should finish after both tasks")
+	assert.Equal(t, []uint{1, 2}, response.Schedule.CriticalPath, "Both tasks should be critical")
+}
+
+func TestGetScheduleInvalidStart(t *testing.T) {
+	context, recorder := getTestGinContextAndRecorder(TestTaskRequestBody{})
+	context.Request = httptest.NewRequest(http.MethodGet, "/api/tasks/schedule?start=tomorrow", nil)
+
+	setMockRepository()
+
+	getSchedule(context)
+
+	responseBody, _ := io.ReadAll(recorder.Body)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, "{\"error\":\"invalid 'start' value, must be a Unix timestamp\"}", string(responseBody), "Invalid response JSON")
+}
@@ -3,6 +3,7 @@ package controllers
 import (
 	"errors"
 	"net/http"
+	"to-do-api/models"
 	"to-do-api/service"
 
 	"github.com/gin-gonic/gin"
@@ -18,7 +19,11 @@ import (
 //	@Param			task	body		service.TaskRequestBody	true	"Task data"
 //	@Success		201		{object}	map[string]interface{}	"Task created successfully"
 //	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		403		{object}	map[string]interface{}	"Not allowed for the user role"
+//	@Failure		409		{object}	map[string]interface{}	"Project is archived"
 //	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
 //	@Router			/api/tasks [post]
 func createTask(c *gin.Context) {
 	var requestBody service.TaskRequestBody
@@ -34,8 +39,8 @@ func createTask(c *gin.Context) {
 	}
 
 	var taskId uint
-	if taskId, err = service.CreateNewTask(requestBody); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	if taskId, err = service.CreateNewTask(c.Request.Context(), requestBody); err != nil {
+		respondServiceError(c, err)
 		return
 	}
 
@@ -45,15 +50,18 @@ func createTask(c *gin.Context) {
 // GetTask Retrieves a single task by ID
 //
 //	@Summary		Get a task by ID
-//	@Description	Fetch a specific task from the To-Do List
+//	@Description	Fetch a specific task from the To-Do List. The ETag header holds the task version, to send on If-Match when changing it
 //	@Tags			Tasks
 //	@Accept			json
 //	@Produce		json
 //	@Param			taskId	path		int						true	"Task ID"
 //	@Success		200		{object}	map[string]interface{}	"Task retrieved successfully"
+//	@Header			200		{string}	ETag					"Version of the task"
 //	@Failure		400		{object}	map[string]interface{}	"Bad request"
 //	@Failure		404		{object}	map[string]interface{}	"Task not found"
 //	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
 //	@Router			/api/tasks/{taskId} [get]
 func getTask(c *gin.Context) {
 	taskIdString := c.Param("taskId")
@@ -63,33 +71,35 @@ func getTask(c *gin.Context) {
 		return
 	}
 
-	task, err := service.GetTaskById(taskId)
+	task, err := service.GetTaskById(c.Request.Context(), taskId)
 	if err != nil {
-		if errors.Is(err, service.ErrRowNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		} else if errors.Is(err, service.ErrDatabaseGeneral) {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		}
-
+		respondServiceError(c, err)
 		return
 	}
 
+	c.Header("ETag", service.TaskETag(task.Version))
 	c.JSON(http.StatusOK, gin.H{"message": "Task retrieved successfully", "task": task})
 }
 
-// UpdateTask Updates an existing task
+// UpdateTask Replaces an existing task
 //
-//	@Summary		Update a task
-//	@Description	Modifies an existing task in the To-Do List
+//	@Summary		Replace a task
+//	@Description	Replaces an existing task in the To-Do List: 'title' and 'status' are required, the other fields missing from the request take their default value. With If-Match, only applies when the task still has that ETag
 //	@Tags			Tasks
 //	@Accept			json
 //	@Produce		json
-//	@Param			taskId	path		int						true	"Task ID"
-//	@Param			task	body		service.TaskRequestBody	true	"Updated task data"
+//	@Param			taskId		path		int						true	"Task ID"
+//	@Param			If-Match	header		string					false	"ETag of the task version the changes are based on"
+//	@Param			task		body		service.TaskRequestBody	true	"Replacing task data"
 //	@Success		200		{object}	map[string]interface{}	"Task updated successfully"
 //	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		403		{object}	map[string]interface{}	"Not allowed for the user role"
 //	@Failure		404		{object}	map[string]interface{}	"Task not found"
+//	@Failure		409		{object}	map[string]interface{}	"Project is archived, status transition not allowed, parent would be a subtask of the task, or the task kept changing concurrently"
+//	@Failure		412		{object}	map[string]interface{}	"Task modified since the If-Match version"
 //	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
 //	@Router			/api/tasks/{taskId} [put]
 func updateTask(c *gin.Context) {
 	var err error
@@ -111,14 +121,80 @@ func updateTask(c *gin.Context) {
 		return
 	}
 
-	if err = service.UpdateTask(uint(taskId), requestBody); err != nil {
-		if errors.Is(err, service.ErrRowNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		} else if errors.Is(err, service.ErrDatabaseGeneral) {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		} else if errors.Is(err, service.ErrInvalidInput) {
-			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		}
+	expectedVersion, err := service.ValidateIfMatchInput(c.GetHeader("If-Match"))
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	if err = service.UpdateTask(c.Request.Context(), uint(taskId), requestBody, expectedVersion); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Task updated successfully"})
+}
+
+// PatchTask Partially updates an existing task
+//
+//	@Summary		Patch a task
+//	@Description	Applies a JSON Merge Patch (RFC 7386) to a task: the fields present are set, and the fields set to null are removed ('title' and 'status' can not be removed). With If-Match, only applies when the task still has that ETag. With the scope 'future', the patch also applies to the next occurrences of a recurring task (except 'due_date' and 'recurrence'), the task starting a new series
+//	@Tags			Tasks
+//	@Accept			json
+//	@Accept			application/merge-patch+json
+//	@Produce		json
+//	@Param			taskId		path		int						true	"Task ID"
+//	@Param			scope		query		string					false	"Occurrences the patch applies to: 'this' (default) or 'future'"
+//	@Param			If-Match	header		string					false	"ETag of the task version the changes are based on"
+//	@Param			patch		body		service.TaskRequestBody	true	"Fields to set, null to remove them"
+//	@Success		200			{object}	map[string]interface{}	"Task updated successfully"
+//	@Failure		400			{object}	map[string]interface{}	"Bad request"
+//	@Failure		403			{object}	map[string]interface{}	"Not allowed for the user role"
+//	@Failure		404			{object}	map[string]interface{}	"Task not found"
+//	@Failure		409			{object}	map[string]interface{}	"Project is archived, status transition not allowed, parent would be a subtask of the task, or the task kept changing concurrently"
+//	@Failure		412			{object}	map[string]interface{}	"Task modified since the If-Match version"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503			{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504			{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId} [patch]
+func patchTask(c *gin.Context) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	body, err := c.GetRawData()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	patch, err := service.ValidateTaskPatchInput(body)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	scope, err := service.ValidateEditScopeInput(c.Query("scope"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	expectedVersion, err := service.ValidateIfMatchInput(c.GetHeader("If-Match"))
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	if scope == service.ScopeFuture {
+		err = service.PatchFutureOccurrences(c.Request.Context(), taskId, patch, expectedVersion)
+	} else {
+		err = service.PatchTask(c.Request.Context(), taskId, patch, expectedVersion)
+	}
+	if err != nil {
+		respondServiceError(c, err)
 		return
 	}
 
@@ -128,15 +204,21 @@ func updateTask(c *gin.Context) {
 // DeleteTask Deletes a task by ID
 //
 //	@Summary		Delete a task
-//	@Description	Removes a task from the To-Do List
+//	@Description	Moves a task and its subtasks to the trash, from where they can be restored until they are purged. With If-Match, only applies when the task still has that ETag
 //	@Tags			Tasks
 //	@Accept			json
 //	@Produce		json
-//	@Param			taskId	path		int						true	"Task ID"
+//	@Param			taskId		path		int						true	"Task ID"
+//	@Param			If-Match	header		string					false	"ETag of the task version the deletion is based on"
 //	@Success		200		{object}	map[string]interface{}	"Task deleted successfully"
 //	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		403		{object}	map[string]interface{}	"Not allowed for the user role"
 //	@Failure		404		{object}	map[string]interface{}	"Task not found"
+//	@Failure		409		{object}	map[string]interface{}	"Task kept changing concurrently"
+//	@Failure		412		{object}	map[string]interface{}	"Task modified since the If-Match version"
 //	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
 //	@Router			/api/tasks/{taskId} [delete]
 func deleteTask(c *gin.Context) {
 	taskIdString := c.Param("taskId")
@@ -146,12 +228,14 @@ func deleteTask(c *gin.Context) {
 		return
 	}
 
-	if err = service.DeleteTask(uint(taskId)); err != nil {
-		if errors.Is(err, service.ErrRowNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		} else if errors.Is(err, service.ErrDatabaseGeneral) {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		}
+	expectedVersion, err := service.ValidateIfMatchInput(c.GetHeader("If-Match"))
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	if err = service.DeleteTask(c.Request.Context(), uint(taskId), expectedVersion); err != nil {
+		respondServiceError(c, err)
 		return
 	}
 
@@ -174,20 +258,20 @@ func deleteTask(c *gin.Context) {
 //	@Param			description_contains	query		string					false	"Filter by description (substring match)"
 //	@Param			status					query		string					false	"Filter by task status"
 //	@Param			priority				query		string					false	"Filter by task priority"
+//	@Param			project					query		int						false	"Filter by project ID"
+//	@Param			tags					query		string					false	"Filter by comma separated tag names"
+//	@Param			tags_match				query		string					false	"Tasks with any (default) or all of the tags"
 //	@Success		200						{object}	map[string]interface{}	"Successful response"
 //	@Failure		400						{object}	map[string]interface{}	"Bad request"
 //	@Failure		404						{object}	map[string]interface{}	"Tasks not found"
 //	@Failure		500						{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503						{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504						{object}	map[string]interface{}	"Database timeout"
 //	@Router			/api/tasks [get]
 func getTasksList(c *gin.Context) {
 
 	// Filtering
-	titleFilter := c.Query("title_contains")
-	descriptionFilter := c.Query("description_contains")
-	statusFilter := c.Query("status")
-	priorityFilter := c.Query("priority")
-
-	filtersConfig, err := service.CreateFilterConfig(titleFilter, descriptionFilter, statusFilter, priorityFilter)
+	filtersConfig, err := getFilterConfig(c)
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -205,18 +289,68 @@ func getTasksList(c *gin.Context) {
 		return
 	}
 
-	tasks, err := service.GetTasksList(filtersConfig, pageConfig)
+	tasks, err := service.GetTasksList(c.Request.Context(), filtersConfig, pageConfig)
 
 	if err != nil {
-		if errors.Is(err, service.ErrRowNotFound) {
-			c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
-		} else if errors.Is(err, service.ErrDatabaseGeneral) {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		}
-
+		respondServiceError(c, err)
 		return
 	}
 
-	pagination, sorting := service.GetReturnInfo(pageConfig)
+	pagination, sorting := service.GetReturnInfo(c.Request.Context(), pageConfig)
 	c.JSON(http.StatusOK, gin.H{"message": "Tasks queried successfully", "data": tasks, "pagination": pagination, "sorting": sorting})
 }
+
+// Builds the tasks filters from the list query parameters
+func getFilterConfig(c *gin.Context) ([]models.TasksFilterQuery, error) {
+	titleFilter := c.Query("title_contains")
+	descriptionFilter := c.Query("description_contains")
+	statusFilter := c.Query("status")
+	priorityFilter := c.Query("priority")
+	projectFilter := c.Query("project")
+	tagsFilter := c.Query("tags")
+	tagsMatch := c.Query("tags_match")
+
+	return service.CreateFilterConfig(titleFilter, descriptionFilter, statusFilter, priorityFilter, projectFilter, tagsFilter, tagsMatch)
+}
+
+// Status returned when the client closes the connection before the response (nginx convention)
+const statusClientClosedRequest = 499
+
+// Writes the HTTP error matching the error returned by the service layer
+func respondServiceError(c *gin.Context, err error) {
+	c.JSON(serviceErrorStatus(err), gin.H{"error": err.Error()})
+}
+
+// HTTP status matching the error returned by the service layer
+func serviceErrorStatus(err error) int {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, service.ErrRowNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, service.ErrDependencyCycle), errors.Is(err, service.ErrDependencyExists), errors.Is(err, service.ErrUserExists),
+		errors.Is(err, service.ErrProjectArchived), errors.Is(err, service.ErrStatusTransition), errors.Is(err, service.ErrParentCycle),
+		errors.Is(err, service.ErrParentDeleted), errors.Is(err, service.ErrTagExists), errors.Is(err, service.ErrConcurrentChange):
+		status = http.StatusConflict
+	case errors.Is(err, service.ErrInvalidCredentials), errors.Is(err, service.ErrInvalidToken):
+		status = http.StatusUnauthorized
+	case errors.Is(err, service.ErrForbidden), errors.Is(err, service.ErrNotCommentAuthor):
+		status = http.StatusForbidden
+	case errors.Is(err, service.ErrVersionMismatch):
+		status = http.StatusPreconditionFailed
+	case errors.Is(err, service.ErrInvalidInput):
+		status = http.StatusBadRequest
+	case errors.Is(err, service.ErrAttachmentTooLarge):
+		status = http.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrAttachmentType):
+		status = http.StatusUnsupportedMediaType
+	case errors.Is(err, service.ErrDatabaseUnavailable):
+		status = http.StatusServiceUnavailable
+	case errors.Is(err, service.ErrDatabaseTimeout):
+		status = http.StatusGatewayTimeout
+	case errors.Is(err, service.ErrRequestCanceled):
+		status = statusClientClosedRequest
+	}
+
+	return status
+}
@@ -2,6 +2,7 @@ package controllers
 
 import (
 	"bytes"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -11,8 +12,8 @@ import (
 	"to-do-api/models"
 	"to-do-api/service"
 
-	"bou.ke/monkey"
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -20,7 +21,7 @@ type TestTaskRequestBody struct {
 	Title       string
 	Priority    uint
 	Description string
-	Status      string
+	Status      string `json:",omitempty"`
 	DueDate     string
 }
 
@@ -49,10 +50,8 @@ func TestDeleteTask(t *testing.T) {
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 	context.Params = []gin.Param{{Key: "taskId", Value: "1"}}
 
-	monkey.Patch(service.DeleteTask, func(taskId uint) error {
-		return nil
-	})
-	defer monkey.UnpatchAll()
+	repository := setMockRepository()
+	repository.AddTask(context.Request.Context(), models.Task{Title: "test"})
 
 	deleteTask(context)
 
@@ -80,10 +79,7 @@ func TestDeleteTaskInexistentId(t *testing.T) {
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 	context.Params = []gin.Param{{Key: "taskId", Value: "10"}}
 
-	monkey.Patch(service.DeleteTask, func(taskId uint) error {
-		return service.ErrRowNotFound
-	})
-	defer monkey.UnpatchAll()
+	setMockRepository()
 
 	deleteTask(context)
 
@@ -98,10 +94,11 @@ func TestDeleteTaskServerError(t *testing.T) {
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 	context.Params = []gin.Param{{Key: "taskId", Value: "1"}}
 
-	monkey.Patch(service.DeleteTask, func(taskId uint) error {
-		return service.ErrDatabaseGeneral
-	})
-	defer monkey.UnpatchAll()
+	repository := setMockRepository()
+	repository.AddTask(context.Request.Context(), models.Task{Title: "test"})
+	repository.deleteTask = func(taskId uint) error {
+		return sql.ErrConnDone
+	}
 
 	deleteTask(context)
 
@@ -115,14 +112,7 @@ func TestGetTasksList(t *testing.T) {
 	requestBody := TestTaskRequestBody{}
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 
-	monkey.Patch(service.GetTasksList, func(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]service.TaskInfo, error) {
-		return []service.TaskInfo{}, nil
-	})
-	monkey.Patch(service.GetReturnInfo, func(pageConfig models.TasksPaginationQuery) (map[string]uint, map[string]string) {
-		return map[string]uint{"offset": 0, "limit": 10, "total_tasks": 0},
-			map[string]string{"by": "id", "order": "ASC"}
-	})
-	defer monkey.UnpatchAll()
+	setMockRepository()
 
 	getTasksList(context)
 
@@ -162,10 +152,10 @@ func TestGetTasksListNotFound(t *testing.T) {
 	requestBody := TestTaskRequestBody{}
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 
-	monkey.Patch(service.GetTasksList, func(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]service.TaskInfo, error) {
-		return []service.TaskInfo{}, service.ErrRowNotFound
-	})
-	defer monkey.UnpatchAll()
+	repository := setMockRepository()
+	repository.queryTasks = func(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]models.Task, error) {
+		return []models.Task{}, sql.ErrNoRows
+	}
 
 	getTasksList(context)
 
@@ -179,10 +169,10 @@ func TestGetTasksListServerError(t *testing.T) {
 	requestBody := TestTaskRequestBody{}
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 
-	monkey.Patch(service.GetTasksList, func(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]service.TaskInfo, error) {
-		return []service.TaskInfo{}, service.ErrDatabaseGeneral
-	})
-	defer monkey.UnpatchAll()
+	repository := setMockRepository()
+	repository.queryTasks = func(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]models.Task, error) {
+		return []models.Task{}, sql.ErrConnDone
+	}
 
 	getTasksList(context)
 
@@ -191,3 +181,77 @@ func TestGetTasksListServerError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
 	assert.JSONEq(t, expectedResponse, string(responseBody), "Invalid response JSON")
 }
+
+func TestGetTasksListDatabaseUnavailable(t *testing.T) {
+	requestBody := TestTaskRequestBody{}
+	context, recorder := getTestGinContextAndRecorder(requestBody)
+
+	repository := setMockRepository()
+	repository.queryTasks = func(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]models.Task, error) {
+		return []models.Task{}, &pgconn.ConnectError{}
+	}
+
+	getTasksList(context)
+
+	expectedResponse := "{\"error\":\"database unavailable, try again later\"}"
+	responseBody, _ := io.ReadAll(recorder.Body)
+	assert.Equal(t, http.StatusServiceUnavailable, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, expectedResponse, string(responseBody), "Invalid response JSON")
+}
+
+func TestGetTaskHistory(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "task", "priority": 1}`, tokens)
+	serveAuthenticated(router, http.MethodPatch, "/api/tasks/1", `{"priority": 3}`, tokens)
+
+	recorder := serveAuthenticated(router, http.MethodGet, "/api/tasks/1/history", "", tokens)
+
+	var response struct {
+		History []service.TaskEventInfo `json:"history"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.Len(t, response.History, 2, "Creation and update should be recorded")
+	assert.Equal(t, "alice", response.History[1].Actor, "Invalid actor")
+	assert.Equal(t, []models.FieldChange{{Field: "priority", Before: float64(1), After: float64(3)}}, response.History[1].Changes, "Invalid changes")
+}
+
+func TestTaskIfMatch(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "task"}`, tokens)
+	recorder := serveAuthenticated(router, http.MethodGet, "/api/tasks/1", "", tokens)
+	etag := recorder.Header().Get("ETag")
+	assert.Equal(t, `"1"`, etag, "Invalid ETag")
+
+	serveIfMatch := func(method string, body string, ifMatch string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, "/api/tasks/1", bytes.NewBufferString(body))
+		request.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+		request.Header.Set("If-Match", ifMatch)
+		recorder := httptest.NewRecorder()
+		router.ServeHTTP(recorder, request)
+		return recorder
+	}
+
+	recorder = serveIfMatch(http.MethodPatch, `{"priority": 2}`, etag)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	recorder = serveIfMatch(http.MethodPatch, `{"priority": 3}`, etag)
+	assert.Equal(t, http.StatusPreconditionFailed, recorder.Code, "Update based on a stale ETag should fail")
+
+	recorder = serveIfMatch(http.MethodDelete, "", etag)
+	assert.Equal(t, http.StatusPreconditionFailed, recorder.Code, "Deletion based on a stale ETag should fail")
+
+	recorder = serveIfMatch(http.MethodDelete, "", "1")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Malformed If-Match should be rejected")
+
+	recorder = serveIfMatch(http.MethodDelete, "", `"2"`)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+}
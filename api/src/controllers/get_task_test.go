@@ -1,14 +1,17 @@
 package controllers
 
 import (
+	stdcontext "context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"testing"
+	"time"
+	"to-do-api/models"
 	"to-do-api/service"
 
-	"bou.ke/monkey"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,7 +24,6 @@ func TestGetTask(t *testing.T) {
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 	context.Params = []gin.Param{{Key: "taskId", Value: "1"}} // Proper parameter setup
 
-	// Mocking the GetTaskById function
 	expectedTask := service.TaskResponseBody{
 		Id:          1,
 		Title:       "test",
@@ -32,10 +34,15 @@ func TestGetTask(t *testing.T) {
 		DueDate:     testDueDate,
 	}
 
-	monkey.Patch(service.GetTaskById, func(taskId uint) (service.TaskResponseBody, error) {
-		return expectedTask, nil
+	repository := setMockRepository()
+	repository.AddTask(context.Request.Context(), models.Task{
+		Title:       expectedTask.Title,
+		Description: expectedTask.Description,
+		Status:      expectedTask.Status,
+		Priority:    expectedTask.Priority,
+		CreatedAt:   time.Unix(expectedTask.CreatedAt, 0),
+		DueDate:     time.Unix(expectedTask.DueDate, 0),
 	})
-	defer monkey.UnpatchAll()
 
 	// Call the handler function
 	getTask(context)
@@ -47,13 +54,28 @@ func TestGetTask(t *testing.T) {
 	expectedResponseMap := map[string]interface{}{
 		"message": "Task retrieved successfully",
 		"task": map[string]interface{}{
-			"Id":          expectedTask.Id,
-			"Title":       expectedTask.Title,
-			"Description": expectedTask.Description,
-			"Status":      expectedTask.Status,
-			"Priority":    expectedTask.Priority,
-			"CreatedAt":   expectedTask.CreatedAt,
-			"DueDate":     expectedTask.DueDate,
+			"Id":            expectedTask.Id,
+			"Title":         expectedTask.Title,
+			"Description":   expectedTask.Description,
+			"Status":        expectedTask.Status,
+			"Priority":      expectedTask.Priority,
+			"CreatedAt":     expectedTask.CreatedAt,
+			"DueDate":       expectedTask.DueDate,
+			"EstimateHours": 0,
+			"ProjectId":     0,
+			"Dependencies":  []uint{},
+			"Version":       1,
+			"ParentId":      0,
+			"Subtasks":      []uint{},
+			"Completion":    nil,
+
+			"ChecklistTotal":   0,
+			"ChecklistChecked": 0,
+
+			"Tags":         []string{},
+			"CommentCount": 0,
+			"SeriesId":     0,
+			"Recurrence":   "",
 		},
 	}
 
@@ -64,6 +86,7 @@ func TestGetTask(t *testing.T) {
 	// Validate response
 	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
 	assert.JSONEq(t, expectedResponseString, string(responseBody), "Invalid response JSON")
+	assert.Equal(t, `"1"`, recorder.Header().Get("ETag"), "Invalid ETag")
 }
 
 func TestGetTaskInvalidId(t *testing.T) {
@@ -89,11 +112,7 @@ func TestGetTaskInexistentId(t *testing.T) {
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 	context.Params = []gin.Param{{Key: "taskId", Value: "10"}} // Proper parameter setup
 
-	// Mocking the GetTaskById function
-	monkey.Patch(service.GetTaskById, func(taskId uint) (service.TaskResponseBody, error) {
-		return service.TaskResponseBody{}, service.ErrRowNotFound
-	})
-	defer monkey.UnpatchAll()
+	setMockRepository()
 
 	// Call the handler function
 	getTask(context)
@@ -113,11 +132,11 @@ func TestGetTaskServerError(t *testing.T) {
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 	context.Params = []gin.Param{{Key: "taskId", Value: "10"}} // Proper parameter setup
 
-	// Mocking the GetTaskById function
-	monkey.Patch(service.GetTaskById, func(taskId uint) (service.TaskResponseBody, error) {
-		return service.TaskResponseBody{}, service.ErrDatabaseGeneral
-	})
-	defer monkey.UnpatchAll()
+	// Mock repository functions
+	repository := setMockRepository()
+	repository.queryTask = func(taskId uint) (models.Task, error) {
+		return models.Task{}, sql.ErrConnDone
+	}
 
 	// Call the handler function
 	getTask(context)
@@ -131,3 +150,27 @@ func TestGetTaskServerError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
 	assert.JSONEq(t, expectedResponse, string(responseBody), "Invalid response JSON")
 }
+
+func TestGetTaskDatabaseTimeout(t *testing.T) {
+	requestBody := TestTaskRequestBody{}
+	context, recorder := getTestGinContextAndRecorder(requestBody)
+	context.Params = []gin.Param{{Key: "taskId", Value: "1"}} // Proper parameter setup
+
+	// Mock repository functions
+	repository := setMockRepository()
+	repository.queryTask = func(taskId uint) (models.Task, error) {
+		return models.Task{}, fmt.Errorf("timeout: %w", stdcontext.DeadlineExceeded)
+	}
+
+	// Call the handler function
+	getTask(context)
+
+	// Read actual response body
+	responseBody, _ := io.ReadAll(recorder.Body)
+
+	expectedResponse := "{\"error\":\"database did not respond in time\"}"
+
+	// Validate response
+	assert.Equal(t, http.StatusGatewayTimeout, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, expectedResponse, string(responseBody), "Invalid response JSON")
+}
@@ -0,0 +1,85 @@
+package controllers
+
+import (
+	"net/http"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type massUpdateItemResponse struct {
+	Id     uint             `json:"id"`
+	Before service.TaskInfo `json:"before"`
+	After  service.TaskInfo `json:"after"`
+	Error  string           `json:"error,omitempty"` // why the task is left as it is
+}
+
+// MassUpdateTasks Changes every task matching the filters
+//
+//	@Summary		Update every task matching the filters
+//	@Description	Applies the same changes to every task matching the list filters: a merge patch of the task fields, and a shift of the due dates in days. Without 'confirm' it is a dry run returning the tasks that would change and the counts; with 'confirm' the changes are applied in one transaction. Tasks the user can not edit, or the workflow does not allow to move, are rejected and left as they are
+//	@Tags			Tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			title_contains			query		string							false	"Filter by title (substring match)"
+//	@Param			description_contains	query		string							false	"Filter by description (substring match)"
+//	@Param			status					query		string							false	"Filter by task status"
+//	@Param			priority				query		string							false	"Filter by task priority"
+//	@Param			project					query		int								false	"Filter by project ID"
+//	@Param			tags					query		string							false	"Filter by comma separated tag names"
+//	@Param			tags_match				query		string							false	"Tasks with any (default) or all of the tags"
+//	@Param			update					body		service.MassUpdateRequestBody	true	"Changes to apply"
+//	@Success		200						{object}	map[string]interface{}			"Tasks updated, or the preview of the update"
+//	@Failure		400						{object}	map[string]interface{}			"Bad request"
+//	@Failure		500						{object}	map[string]interface{}			"Internal server error"
+//	@Failure		503						{object}	map[string]interface{}			"Database unavailable"
+//	@Failure		504						{object}	map[string]interface{}			"Database timeout"
+//	@Router			/api/tasks/mass-update [post]
+func massUpdateTasks(c *gin.Context) {
+	filtersConfig, err := getFilterConfig(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var requestBody service.MassUpdateRequestBody
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	update, err := service.ValidateMassUpdateInput(requestBody)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	result, err := service.MassUpdateTasks(c.Request.Context(), filtersConfig, update, requestBody.Confirm)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	tasks := []massUpdateItemResponse{}
+	for _, item := range result.Tasks {
+		task := massUpdateItemResponse{Id: item.Before.Id, Before: item.Before, After: item.After}
+		if item.Err != nil {
+			task.Error = item.Err.Error()
+		}
+		tasks = append(tasks, task)
+	}
+
+	message := "Dry run, no task updated"
+	if requestBody.Confirm {
+		message = "Tasks updated successfully"
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"message":   message,
+		"dry_run":   !requestBody.Confirm,
+		"matched":   result.Matched,
+		"changed":   result.Changed,
+		"unchanged": result.Unchanged,
+		"rejected":  result.Rejected,
+		"tasks":     tasks,
+	})
+}
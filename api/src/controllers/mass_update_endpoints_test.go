@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMassUpdateTasks(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "task 1", "priority": 3}`, tokens)
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "task 2", "priority": 3}`, tokens)
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "task 3", "priority": 1}`, tokens)
+
+	update := `{"changes": {"status": "open"}%s}`
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tasks/mass-update?priority=3", fmt.Sprintf(update, ""), tokens)
+
+	var response map[string]any
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.Equal(t, true, response["dry_run"])
+	assert.Equal(t, float64(2), response["matched"], "Invalid amount of matched tasks")
+	assert.Equal(t, float64(2), response["changed"], "Invalid amount of changed tasks")
+	assert.Len(t, response["tasks"], 2, "Preview should list the changed tasks")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1", "", tokens)
+	assert.Contains(t, recorder.Body.String(), `"Status":"backlog"`, "Dry run should not update the task")
+
+	recorder = serveAuthenticated(router, http.MethodPost, "/api/tasks/mass-update?priority=3", fmt.Sprintf(update, `, "confirm": true`), tokens)
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.Equal(t, false, response["dry_run"])
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1", "", tokens)
+	assert.Contains(t, recorder.Body.String(), `"Status":"open"`, "Confirmed update should update the task")
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/3", "", tokens)
+	assert.Contains(t, recorder.Body.String(), `"Status":"backlog"`, "Tasks not matching the filters should not be updated")
+}
+
+func TestMassUpdateTasksInvalidInput(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tasks/mass-update", `{"confirm": true}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, `{"error": "at least one change must be present: 'changes', 'shift_due_date_days'"}`, recorder.Body.String(), "Invalid response")
+
+	recorder = serveAuthenticated(router, http.MethodPost, "/api/tasks/mass-update?priority=high", `{"shift_due_date_days": 7}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+}
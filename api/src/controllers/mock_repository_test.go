@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"context"
+	"to-do-api/models"
+	"to-do-api/service"
+)
+
+// Repository used by tests: every call is forwarded to an in-memory
+// repository unless the test overrides the function.
+type mockTaskRepository struct {
+	*models.MemoryTaskRepository
+	addTask           func(newTask models.Task) (uint, error)
+	queryTask         func(taskId uint) (models.Task, error)
+	updateTask        func(updatedTask models.Task) error
+	deleteTask        func(taskId uint) error
+	checkExistence    func(taskId uint) (bool, error)
+	queryTasks        func(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]models.Task, error)
+	getAmountOfTasks  func() (uint, error)
+	addDependency     func(taskId uint, dependsOnId uint) error
+	queryDependencies func(taskId uint) ([]uint, error)
+}
+
+func setMockRepository() *mockTaskRepository {
+	repository := &mockTaskRepository{MemoryTaskRepository: models.NewMemoryTaskRepository()}
+	service.SetTaskRepository(repository)
+	service.SetDependencyRepository(repository)
+	service.SetProjectRepository(repository)
+	service.SetTaskEventRepository(repository)
+	service.SetTrashRepository(repository)
+	service.SetChecklistRepository(repository)
+	service.SetTagRepository(repository)
+	service.SetCommentRepository(repository)
+	service.SetAttachmentRepository(repository)
+	service.SetSeriesRepository(repository)
+	service.SetReminderRepository(repository)
+	service.SetTransactor(repository)
+	service.SetBlobStore(models.NewMemoryBlobStore())
+	return repository
+}
+
+func (m *mockTaskRepository) AddTask(ctx context.Context, newTask models.Task) (uint, error) {
+	if m.addTask != nil {
+		return m.addTask(newTask)
+	}
+	return m.MemoryTaskRepository.AddTask(ctx, newTask)
+}
+
+func (m *mockTaskRepository) QueryTask(ctx context.Context, userId uint, taskId uint) (models.Task, error) {
+	if m.queryTask != nil {
+		return m.queryTask(taskId)
+	}
+	return m.MemoryTaskRepository.QueryTask(ctx, userId, taskId)
+}
+
+func (m *mockTaskRepository) UpdateTask(ctx context.Context, userId uint, updatedTask models.Task) error {
+	if m.updateTask != nil {
+		return m.updateTask(updatedTask)
+	}
+	return m.MemoryTaskRepository.UpdateTask(ctx, userId, updatedTask)
+}
+
+func (m *mockTaskRepository) DeleteTask(ctx context.Context, userId uint, taskId uint, version uint) error {
+	if m.deleteTask != nil {
+		return m.deleteTask(taskId)
+	}
+	return m.MemoryTaskRepository.DeleteTask(ctx, userId, taskId, version)
+}
+
+func (m *mockTaskRepository) CheckExistence(ctx context.Context, userId uint, taskId uint) (bool, error) {
+	if m.checkExistence != nil {
+		return m.checkExistence(taskId)
+	}
+	return m.MemoryTaskRepository.CheckExistence(ctx, userId, taskId)
+}
+
+func (m *mockTaskRepository) QueryTasks(ctx context.Context, userId uint, filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]models.Task, error) {
+	if m.queryTasks != nil {
+		return m.queryTasks(filterConfig, pageConfig)
+	}
+	return m.MemoryTaskRepository.QueryTasks(ctx, userId, filterConfig, pageConfig)
+}
+
+func (m *mockTaskRepository) GetAmountOfTasks(ctx context.Context, userId uint) (uint, error) {
+	if m.getAmountOfTasks != nil {
+		return m.getAmountOfTasks()
+	}
+	return m.MemoryTaskRepository.GetAmountOfTasks(ctx, userId)
+}
+
+func (m *mockTaskRepository) AddDependency(ctx context.Context, taskId uint, dependsOnId uint) error {
+	if m.addDependency != nil {
+		return m.addDependency(taskId, dependsOnId)
+	}
+	return m.MemoryTaskRepository.AddDependency(ctx, taskId, dependsOnId)
+}
+
+func (m *mockTaskRepository) QueryDependencies(ctx context.Context, taskId uint) ([]uint, error) {
+	if m.queryDependencies != nil {
+		return m.queryDependencies(taskId)
+	}
+	return m.MemoryTaskRepository.QueryDependencies(ctx, taskId)
+}
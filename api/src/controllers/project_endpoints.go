@@ -0,0 +1,295 @@
+package controllers
+
+import (
+	"net/http"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateProject Creates a new project
+//
+//	@Summary		Create a new project
+//	@Description	Adds a new project to group tasks
+//	@Tags			Projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			project	body		service.ProjectRequestBody	true	"Project data"
+//	@Success		201		{object}	map[string]interface{}		"Project created successfully"
+//	@Failure		400		{object}	map[string]interface{}		"Bad request"
+//	@Failure		500		{object}	map[string]interface{}		"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}		"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}		"Database timeout"
+//	@Router			/api/projects [post]
+func createProject(c *gin.Context) {
+	var requestBody service.ProjectRequestBody
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := service.ValidateNewProjectInput(requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	projectId, err := service.CreateProject(c.Request.Context(), requestBody)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Project created successfully", "projectId": projectId})
+}
+
+// ListProjects Lists the projects of the user
+//
+//	@Summary		List projects
+//	@Description	Lists the projects of the user, archived ones only when requested
+//	@Tags			Projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			archived	query		bool					false	"Include archived projects (default: false)"
+//	@Success		200			{object}	map[string]interface{}	"Projects queried successfully"
+//	@Failure		400			{object}	map[string]interface{}	"Bad request"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503			{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504			{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/projects [get]
+func getProjectsList(c *gin.Context) {
+	includeArchived := false
+	switch c.Query("archived") {
+	case "", "false":
+	case "true":
+		includeArchived = true
+	default:
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid 'archived' value, must be true or false"})
+		return
+	}
+
+	projects, err := service.GetProjects(c.Request.Context(), includeArchived)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Projects queried successfully", "data": projects})
+}
+
+// GetProject Retrieves a single project by ID
+//
+//	@Summary		Get a project by ID
+//	@Description	Fetch a specific project
+//	@Tags			Projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectId	path		int						true	"Project ID"
+//	@Success		200			{object}	map[string]interface{}	"Project retrieved successfully"
+//	@Failure		400			{object}	map[string]interface{}	"Bad request"
+//	@Failure		404			{object}	map[string]interface{}	"Project not found"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503			{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504			{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/projects/{projectId} [get]
+func getProject(c *gin.Context) {
+	projectId, err := service.ValidateProjectIdInput(c.Param("projectId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	project, err := service.GetProjectById(c.Request.Context(), projectId)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project retrieved successfully", "project": project})
+}
+
+// UpdateProject Updates an existing project
+//
+//	@Summary		Update a project
+//	@Description	Renames, describes, archives or unarchives a project
+//	@Tags			Projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectId	path		int							true	"Project ID"
+//	@Param			project		body		service.ProjectRequestBody	true	"Updated project data"
+//	@Success		200			{object}	map[string]interface{}		"Project updated successfully"
+//	@Failure		400			{object}	map[string]interface{}		"Bad request"
+//	@Failure		403			{object}	map[string]interface{}		"Not allowed for the user role"
+//	@Failure		404			{object}	map[string]interface{}		"Project not found"
+//	@Failure		500			{object}	map[string]interface{}		"Internal server error"
+//	@Failure		503			{object}	map[string]interface{}		"Database unavailable"
+//	@Failure		504			{object}	map[string]interface{}		"Database timeout"
+//	@Router			/api/projects/{projectId} [put]
+func updateProject(c *gin.Context) {
+	projectId, err := service.ValidateProjectIdInput(c.Param("projectId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var requestBody service.ProjectRequestBody
+	if err = c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.ValidateUpdateProjectInput(requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.UpdateProject(c.Request.Context(), projectId, requestBody); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project updated successfully"})
+}
+
+// DeleteProject Deletes a project by ID
+//
+//	@Summary		Delete a project
+//	@Description	Removes a project, its tasks are kept without project
+//	@Tags			Projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectId	path		int						true	"Project ID"
+//	@Success		200			{object}	map[string]interface{}	"Project deleted successfully"
+//	@Failure		400			{object}	map[string]interface{}	"Bad request"
+//	@Failure		403			{object}	map[string]interface{}	"Not allowed for the user role"
+//	@Failure		404			{object}	map[string]interface{}	"Project not found"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503			{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504			{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/projects/{projectId} [delete]
+func deleteProject(c *gin.Context) {
+	projectId, err := service.ValidateProjectIdInput(c.Param("projectId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.DeleteProject(c.Request.Context(), projectId); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project deleted successfully"})
+}
+
+// GetProjectMembers Lists the users with access to a project
+//
+//	@Summary		List project members
+//	@Description	Lists the users with access to the project and their roles, starting by the user who created it
+//	@Tags			Projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectId	path		int						true	"Project ID"
+//	@Success		200			{object}	map[string]interface{}	"Project members retrieved successfully"
+//	@Failure		400			{object}	map[string]interface{}	"Bad request"
+//	@Failure		404			{object}	map[string]interface{}	"Project not found"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503			{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504			{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/projects/{projectId}/members [get]
+func getProjectMembers(c *gin.Context) {
+	projectId, err := service.ValidateProjectIdInput(c.Param("projectId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	members, err := service.GetProjectMembers(c.Request.Context(), projectId)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project members retrieved successfully", "members": members})
+}
+
+// SetProjectMember Shares a project with a user
+//
+//	@Summary		Share a project
+//	@Description	Shares the project with a user as viewer, editor or owner, or changes the role of a member. Only owners can share the project.
+//	@Tags			Projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectId	path		int									true	"Project ID"
+//	@Param			member		body		service.ProjectMemberRequestBody	true	"User and role"
+//	@Success		200			{object}	map[string]interface{}				"Project shared successfully"
+//	@Failure		400			{object}	map[string]interface{}				"Bad request"
+//	@Failure		403			{object}	map[string]interface{}				"Not allowed for the user role"
+//	@Failure		404			{object}	map[string]interface{}				"Project not found"
+//	@Failure		500			{object}	map[string]interface{}				"Internal server error"
+//	@Failure		503			{object}	map[string]interface{}				"Database unavailable"
+//	@Failure		504			{object}	map[string]interface{}				"Database timeout"
+//	@Router			/api/projects/{projectId}/members [post]
+func setProjectMember(c *gin.Context) {
+	projectId, err := service.ValidateProjectIdInput(c.Param("projectId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var requestBody service.ProjectMemberRequestBody
+	if err = c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.ValidateProjectMemberInput(requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userId, err := service.SetProjectMember(c.Request.Context(), projectId, requestBody)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project shared successfully", "userId": userId})
+}
+
+// DeleteProjectMember Stops sharing a project with a user
+//
+//	@Summary		Remove a project member
+//	@Description	Stops sharing the project with a user. Only owners can remove other members, any member can leave the project.
+//	@Tags			Projects
+//	@Accept			json
+//	@Produce		json
+//	@Param			projectId	path		int						true	"Project ID"
+//	@Param			userId		path		int						true	"User ID"
+//	@Success		200			{object}	map[string]interface{}	"Project member removed successfully"
+//	@Failure		400			{object}	map[string]interface{}	"Bad request"
+//	@Failure		403			{object}	map[string]interface{}	"Not allowed for the user role"
+//	@Failure		404			{object}	map[string]interface{}	"Project or member not found"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503			{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504			{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/projects/{projectId}/members/{userId} [delete]
+func deleteProjectMember(c *gin.Context) {
+	projectId, err := service.ValidateProjectIdInput(c.Param("projectId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	userId, err := service.ValidateUserIdInput(c.Param("userId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.RemoveProjectMember(c.Request.Context(), projectId, userId); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Project member removed successfully"})
+}
@@ -0,0 +1,113 @@
+package controllers
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"to-do-api/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectEndpoints(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/projects", `{"name": "Backend"}`, tokens)
+	assert.Equal(t, http.StatusCreated, recorder.Code, "Project should be created")
+	assert.JSONEq(t, `{"message":"Project created successfully","projectId":1}`, recorder.Body.String(), "Invalid response JSON")
+
+	recorder = serveAuthenticated(router, http.MethodPost, "/api/projects", `{"name": ""}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Empty project name should be rejected")
+
+	recorder = serveAuthenticated(router, http.MethodPut, "/api/projects/1", `{"archived": true}`, tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Project should be archived")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/projects", "", tokens)
+	assert.JSONEq(t, `{"message":"Projects queried successfully","data":[]}`, recorder.Body.String(), "Archived projects should not be listed")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/projects?archived=true", "", tokens)
+	var response struct {
+		Data []service.ProjectInfo `json:"data"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Len(t, response.Data, 1, "Archived projects should be listed when requested")
+
+	recorder = serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "task", "project_id": 1}`, tokens)
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Tasks should not be added to archived projects")
+
+	recorder = serveAuthenticated(router, http.MethodDelete, "/api/projects/1", "", tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Project should be deleted")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/projects/1", "", tokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Deleted project should not be found")
+}
+
+func TestTasksProjectFilter(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	serveAuthenticated(router, http.MethodPost, "/api/projects", `{"name": "Backend"}`, tokens)
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "in project", "project_id": 1}`, tokens)
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "moved"}`, tokens)
+
+	recorder := serveAuthenticated(router, http.MethodPatch, "/api/tasks/2", `{"project_id": 1}`, tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Task should be moved to the project")
+
+	recorder = serveAuthenticated(router, http.MethodPatch, "/api/tasks/2", `{"project_id": 9}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Task should not be moved to unknown projects")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks?project=1", "", tokens)
+	var response struct {
+		Data []service.TaskInfo `json:"data"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Tasks should be listed")
+	assert.Len(t, response.Data, 2, "Both tasks should be in the project")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks?project=abc", "", tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Invalid project filter should be rejected")
+}
+
+func TestSharedProjectRoles(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	ownerTokens := registerNamedTestUser(t, "alice")
+	memberTokens := registerNamedTestUser(t, "bob")
+	router := newRouter()
+
+	serveAuthenticated(router, http.MethodPost, "/api/projects", `{"name": "Backend"}`, ownerTokens)
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "task", "project_id": 1}`, ownerTokens)
+
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/projects/1/members", `{"username": "bob", "role": "admin"}`, ownerTokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Unknown roles should be rejected")
+
+	recorder = serveAuthenticated(router, http.MethodPost, "/api/projects/1/members", `{"username": "bob", "role": "viewer"}`, ownerTokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Project should be shared")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1", "", memberTokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Viewers should read the task")
+	recorder = serveAuthenticated(router, http.MethodPatch, "/api/tasks/1", `{"title": "bob title"}`, memberTokens)
+	assert.Equal(t, http.StatusForbidden, recorder.Code, "Viewers should not update the task")
+	recorder = serveAuthenticated(router, http.MethodDelete, "/api/tasks/1", "", memberTokens)
+	assert.Equal(t, http.StatusForbidden, recorder.Code, "Viewers should not delete the task")
+
+	serveAuthenticated(router, http.MethodPost, "/api/projects/1/members", `{"username": "bob", "role": "editor"}`, ownerTokens)
+
+	recorder = serveAuthenticated(router, http.MethodPatch, "/api/tasks/1", `{"title": "bob title"}`, memberTokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Editors should update the task")
+	recorder = serveAuthenticated(router, http.MethodDelete, "/api/projects/1", "", memberTokens)
+	assert.Equal(t, http.StatusForbidden, recorder.Code, "Editors should not delete the project")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/projects/1/members", "", memberTokens)
+	assert.JSONEq(t, `{"message":"Project members retrieved successfully","members":[{"user_id":1,"username":"alice","role":"owner"},{"user_id":2,"username":"bob","role":"editor"}]}`, recorder.Body.String(), "Invalid response JSON")
+
+	recorder = serveAuthenticated(router, http.MethodDelete, "/api/projects/1/members/2", "", ownerTokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Owner should remove members")
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1", "", memberTokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Removed members should not read the task")
+}
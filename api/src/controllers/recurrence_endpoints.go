@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"net/http"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetOccurrences Previews the next occurrences of a recurring task
+//
+//	@Summary		Preview the occurrences of a task
+//	@Description	Lists the due dates (Unix timestamps) of the next occurrences of the recurring task, after its own due date. With 'rrule', previews that recurrence rule instead, starting from the due date of the task. Empty when the task does not recur or the series ended
+//	@Tags			Tasks
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId	path		int						true	"Task ID"
+//	@Param			count	query		int						false	"Occurrences to list, between 1 and 50 (default 5)"
+//	@Param			rrule	query		string					false	"RFC 5545 recurrence rule to preview, like 'FREQ=WEEKLY;BYDAY=MO,WE'"
+//	@Success		200		{object}	map[string]interface{}	"Occurrences retrieved successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		404		{object}	map[string]interface{}	"Task not found"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/occurrences [get]
+func getOccurrences(c *gin.Context) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	count, err := service.ValidateOccurrenceCountInput(c.Query("count"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	rule := c.Query("rrule")
+	if rule != "" {
+		if err = service.ValidateRecurrenceInput(rule); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	occurrences, err := service.PreviewOccurrences(c.Request.Context(), taskId, rule, count)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Occurrences retrieved successfully", "data": occurrences})
+}
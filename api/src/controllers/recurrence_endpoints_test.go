@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOccurrencesEndpoint(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "standup", "due_date": 1770000000, "recurrence": "FREQ=DAILY;COUNT=3"}`, tokens)
+	assert.Equal(t, http.StatusCreated, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1/occurrences", "", tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, `{"message": "Occurrences retrieved successfully", "data": [1770086400, 1770172800]}`, recorder.Body.String(), "Invalid response")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1/occurrences?count=1&rrule=FREQ=WEEKLY", "", tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, `{"message": "Occurrences retrieved successfully", "data": [1770604800]}`, recorder.Body.String(), "Invalid response")
+
+	for _, query := range []string{"count=0", "count=51", "rrule=FREQ=HOURLY", "rrule=INTERVAL=2"} {
+		recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1/occurrences?"+query, "", tokens)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Query '%s' should be rejected", query))
+	}
+}
+
+func TestPatchTaskScope(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "standup", "due_date": 1770000000, "recurrence": "FREQ=DAILY"}`, tokens)
+
+	recorder := serveAuthenticated(router, http.MethodPatch, "/api/tasks/1?scope=all", `{"title": "daily"}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	recorder = serveAuthenticated(router, http.MethodPatch, "/api/tasks/1?scope=future", `{"title": "daily", "due_date": null}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Recurring task without due date should be rejected")
+
+	recorder = serveAuthenticated(router, http.MethodPatch, "/api/tasks/1?scope=future", `{"title": "daily"}`, tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+}
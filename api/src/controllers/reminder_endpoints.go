@@ -0,0 +1,119 @@
+package controllers
+
+import (
+	"net/http"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetReminders Lists the reminders of the user on a task
+//
+//	@Summary		Get the reminders of a task
+//	@Description	Lists the reminders of the current user on the task, with when they fire and whether they were sent
+//	@Tags			Reminders
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId	path		int						true	"Task ID"
+//	@Success		200		{object}	map[string]interface{}	"Reminders retrieved successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		404		{object}	map[string]interface{}	"Task not found"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/reminders [get]
+func getReminders(c *gin.Context) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reminders, err := service.GetReminders(c.Request.Context(), taskId)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reminders retrieved successfully", "data": reminders})
+}
+
+// AddReminder Adds a reminder on a task
+//
+//	@Summary		Add a reminder to a task
+//	@Description	Reminds the current user about the task at 'remind_at', or 'before_due' minutes before its due date (following it when it changes). The reminder fires once on a configured channel: 'webhook', or 'email' to the 'recipient' address. Reminders of done tasks are dropped
+//	@Tags			Reminders
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId		path		int							true	"Task ID"
+//	@Param			reminder	body		service.ReminderRequestBody	true	"Reminder"
+//	@Success		201			{object}	map[string]interface{}		"Reminder created successfully"
+//	@Failure		400			{object}	map[string]interface{}		"Bad request"
+//	@Failure		404			{object}	map[string]interface{}		"Task not found"
+//	@Failure		500			{object}	map[string]interface{}		"Internal server error"
+//	@Failure		503			{object}	map[string]interface{}		"Database unavailable"
+//	@Failure		504			{object}	map[string]interface{}		"Database timeout"
+//	@Router			/api/tasks/{taskId}/reminders [post]
+func addReminder(c *gin.Context) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var requestBody service.ReminderRequestBody
+	if err = c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.ValidateReminderInput(requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reminderId, err := service.AddReminder(c.Request.Context(), taskId, requestBody)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Reminder created successfully", "reminderId": reminderId})
+}
+
+// DeleteReminder Removes a reminder
+//
+//	@Summary		Delete a reminder
+//	@Description	Removes a reminder of the current user on the task
+//	@Tags			Reminders
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId		path		int						true	"Task ID"
+//	@Param			reminderId	path		int						true	"Reminder ID"
+//	@Success		200			{object}	map[string]interface{}	"Reminder deleted successfully"
+//	@Failure		400			{object}	map[string]interface{}	"Bad request"
+//	@Failure		404			{object}	map[string]interface{}	"Task or reminder not found"
+//	@Failure		500			{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503			{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504			{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/reminders/{reminderId} [delete]
+func deleteReminder(c *gin.Context) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	reminderId, err := service.ValidateReminderIdInput(c.Param("reminderId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.DeleteReminder(c.Request.Context(), taskId, reminderId); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Reminder deleted successfully"})
+}
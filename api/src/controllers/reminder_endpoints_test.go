@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"to-do-api/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReminderEndpoints(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	payloads := []map[string]any{}
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var payload map[string]any
+		json.NewDecoder(r.Body).Decode(&payload)
+		payloads = append(payloads, payload)
+	}))
+	defer webhook.Close()
+	service.SetNotifiers(map[string]service.Notifier{service.ChannelWebhook: service.NewWebhookNotifier(webhook.URL, "")})
+	defer service.SetNotifiers(map[string]service.Notifier{})
+
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "renew certificate", "due_date": 1770000000}`, tokens)
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tasks/1/reminders", `{"before_due": 60, "channel": "webhook"}`, tokens)
+	assert.Equal(t, http.StatusCreated, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, `{"message": "Reminder created successfully", "reminderId": 1}`, recorder.Body.String(), "Invalid response")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1/reminders", "", tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, `{"message": "Reminders retrieved successfully", "data": [{"id": 1, "remind_at": 0, "before_due": 60, "channel": "webhook",
+		"recipient": "", "fire_at": 1769996400, "sent_at": 0, "attempts": 0, "last_error": ""}]}`, recorder.Body.String(), "Invalid response")
+
+	delivered, err := service.FireDueReminders(context.Background(), time.Unix(1769996400, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), delivered)
+	assert.Equal(t, []map[string]any{{"reminder_id": float64(1), "task_id": float64(1), "title": "renew certificate",
+		"due_date": float64(1770000000), "username": "alice", "remind_at": float64(1769996400)}}, payloads)
+
+	recorder = serveAuthenticated(router, http.MethodDelete, "/api/tasks/1/reminders/1", "", tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	recorder = serveAuthenticated(router, http.MethodDelete, "/api/tasks/1/reminders/1", "", tokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Deleted reminder should not be found")
+}
+
+func TestReminderEndpointsInvalid(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "renew certificate"}`, tokens)
+
+	for _, body := range []string{
+		`{"channel": "webhook"}`,
+		`{"before_due": 60, "channel": "pager"}`,
+		`{"before_due": 60, "channel": "email", "recipient": "not an address"}`,
+		`{"before_due": 60, "channel": "webhook"}`,
+	} {
+		recorder := serveAuthenticated(router, http.MethodPost, "/api/tasks/1/reminders", body, tokens)
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Reminder '%s' should be rejected", body))
+	}
+
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tasks/2/reminders", `{"remind_at": 4102444800, "channel": "webhook"}`, tokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+}
@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"to-do-api/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubtaskEndpoints(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "epic"}`, tokens)
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "story", "parent_id": 1}`, tokens)
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "other story"}`, tokens)
+	recorder := serveAuthenticated(router, http.MethodPatch, "/api/tasks/3", `{"parent_id": 1}`, tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1/subtasks", "", tokens)
+	var response struct {
+		Data []service.TaskInfo `json:"data"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.Len(t, response.Data, 2, "Invalid amount of subtasks")
+	assert.Equal(t, uint(1), response.Data[0].ParentId, "Invalid parent")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1", "", tokens)
+	var task struct {
+		Task service.TaskResponseBody `json:"task"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&task)
+	assert.Equal(t, []uint{2, 3}, task.Task.Subtasks, "Invalid subtasks")
+	assert.Equal(t, uint(0), *task.Task.Completion, "No subtask is done")
+
+	recorder = serveAuthenticated(router, http.MethodPatch, "/api/tasks/1", `{"parent_id": 2}`, tokens)
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Task should not become a subtask of its subtasks")
+
+	serveAuthenticated(router, http.MethodDelete, "/api/tasks/1", "", tokens)
+	recorder = serveAuthenticated(router, http.MethodPost, "/api/tasks/2/restore", "", tokens)
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Subtask should not be restored before its parent")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/9/subtasks", "", tokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+}
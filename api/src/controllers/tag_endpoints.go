@@ -0,0 +1,213 @@
+package controllers
+
+import (
+	"net/http"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CreateTag Creates a new tag
+//
+//	@Summary		Create a new tag
+//	@Description	Adds a tag to label tasks with. Names are unique among the tags of the user, the color defaults to gray
+//	@Tags			Tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			tag	body		service.TagRequestBody	true	"Tag data"
+//	@Success		201	{object}	map[string]interface{}	"Tag created successfully"
+//	@Failure		400	{object}	map[string]interface{}	"Bad request"
+//	@Failure		409	{object}	map[string]interface{}	"Tag name already taken"
+//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503	{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504	{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tags [post]
+func createTag(c *gin.Context) {
+	var requestBody service.TagRequestBody
+	if err := c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err := service.ValidateNewTagInput(requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	tagId, err := service.CreateTag(c.Request.Context(), requestBody)
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{"message": "Tag created successfully", "tagId": tagId})
+}
+
+// ListTags Lists the tags of the user
+//
+//	@Summary		List tags
+//	@Description	Lists the tags of the user ordered by name
+//	@Tags			Tags
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	map[string]interface{}	"Tags retrieved successfully"
+//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503	{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504	{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tags [get]
+func getTagsList(c *gin.Context) {
+	tags, err := service.GetTags(c.Request.Context())
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tags retrieved successfully", "data": tags})
+}
+
+// UpdateTag Renames a tag or changes its color
+//
+//	@Summary		Update a tag
+//	@Description	Changes the name or the color of the tag, the fields missing from the request being kept
+//	@Tags			Tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			tagId	path		int						true	"Tag ID"
+//	@Param			tag		body		service.TagRequestBody	true	"Fields to change"
+//	@Success		200		{object}	map[string]interface{}	"Tag updated successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		404		{object}	map[string]interface{}	"Tag not found"
+//	@Failure		409		{object}	map[string]interface{}	"Tag name already taken"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tags/{tagId} [patch]
+func updateTag(c *gin.Context) {
+	tagId, err := service.ValidateTagIdInput(c.Param("tagId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var requestBody service.TagRequestBody
+	if err = c.ShouldBindJSON(&requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.ValidateUpdateTagInput(requestBody); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.UpdateTag(c.Request.Context(), tagId, requestBody); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tag updated successfully"})
+}
+
+// DeleteTag Removes a tag
+//
+//	@Summary		Delete a tag
+//	@Description	Removes the tag, together with its labels on the tasks
+//	@Tags			Tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			tagId	path		int						true	"Tag ID"
+//	@Success		200		{object}	map[string]interface{}	"Tag deleted successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		404		{object}	map[string]interface{}	"Tag not found"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tags/{tagId} [delete]
+func deleteTag(c *gin.Context) {
+	tagId, err := service.ValidateTagIdInput(c.Param("tagId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.DeleteTag(c.Request.Context(), tagId); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tag deleted successfully"})
+}
+
+// AddTaskTag Labels a task with a tag
+//
+//	@Summary		Add a tag to a task
+//	@Description	Labels the task with one of the tags of the user. Adding a tag the task already has does nothing
+//	@Tags			Tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId	path		int						true	"Task ID"
+//	@Param			tagId	path		int						true	"Tag ID"
+//	@Success		200		{object}	map[string]interface{}	"Tag added successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		403		{object}	map[string]interface{}	"Not allowed for the user role"
+//	@Failure		404		{object}	map[string]interface{}	"Task or tag not found"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/tags/{tagId} [post]
+func addTaskTag(c *gin.Context) {
+	taskId, tagId, err := getTaskTagParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.AddTaskTag(c.Request.Context(), taskId, tagId); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tag added successfully"})
+}
+
+// RemoveTaskTag Removes a tag from a task
+//
+//	@Summary		Remove a tag from a task
+//	@Description	Removes the tag from the task, whichever user the tag belongs to
+//	@Tags			Tags
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId	path		int						true	"Task ID"
+//	@Param			tagId	path		int						true	"Tag ID"
+//	@Success		200		{object}	map[string]interface{}	"Tag removed successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		403		{object}	map[string]interface{}	"Not allowed for the user role"
+//	@Failure		404		{object}	map[string]interface{}	"Task not found or not labeled with the tag"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/tags/{tagId} [delete]
+func removeTaskTag(c *gin.Context) {
+	taskId, tagId, err := getTaskTagParams(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.RemoveTaskTag(c.Request.Context(), taskId, tagId); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Tag removed successfully"})
+}
+
+func getTaskTagParams(c *gin.Context) (uint, uint, error) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	tagId, err := service.ValidateTagIdInput(c.Param("tagId"))
+	return taskId, tagId, err
+}
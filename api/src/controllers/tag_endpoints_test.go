@@ -0,0 +1,72 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"to-do-api/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagEndpoints(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "login fails"}`, tokens)
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "slow board"}`, tokens)
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tags", `{"name": "bug", "color": "#ff0000"}`, tokens)
+	assert.Equal(t, http.StatusCreated, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	serveAuthenticated(router, http.MethodPost, "/api/tags", `{"name": "urgent"}`, tokens)
+
+	recorder = serveAuthenticated(router, http.MethodPost, "/api/tags", `{"name": "bug"}`, tokens)
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Duplicated tag name should be rejected")
+
+	recorder = serveAuthenticated(router, http.MethodPost, "/api/tasks/1/tags/1", "", tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	serveAuthenticated(router, http.MethodPost, "/api/tasks/1/tags/2", "", tokens)
+	serveAuthenticated(router, http.MethodPost, "/api/tasks/2/tags/2", "", tokens)
+
+	var response struct {
+		Data []service.TaskInfo `json:"data"`
+	}
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks?tags=bug,urgent&tags_match=all", "", tokens)
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.Len(t, response.Data, 1, "Only the task with all the tags should match")
+	assert.Equal(t, []string{"bug", "urgent"}, response.Data[0].Tags, "Invalid tags")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks?tags=urgent", "", tokens)
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Len(t, response.Data, 2, "Both tasks have the tag")
+
+	recorder = serveAuthenticated(router, http.MethodPatch, "/api/tags/2", `{"color": "#00FF00"}`, tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tags", "", tokens)
+	assert.JSONEq(t, `{"message": "Tags retrieved successfully", "data": [{"id": 1, "name": "bug", "color": "#ff0000"}, {"id": 2, "name": "urgent", "color": "#00ff00"}]}`, recorder.Body.String(), "Invalid response")
+
+	recorder = serveAuthenticated(router, http.MethodDelete, "/api/tags/1", "", tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	recorder = serveAuthenticated(router, http.MethodDelete, "/api/tasks/1/tags/1", "", tokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Deleted tag should be removed from the tasks")
+}
+
+func TestTagEndpointsInvalidInput(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	recorder := serveAuthenticated(router, http.MethodPost, "/api/tags", `{"name": "bug", "color": "red"}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.JSONEq(t, `{"error": "invalid color: must be hexadecimal RGB, as '#1e90ff'"}`, recorder.Body.String(), "Invalid response")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks?tags=bug&tags_match=most", "", tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	recorder = serveAuthenticated(router, http.MethodPost, "/api/tasks/1/tags/a", "", tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+}
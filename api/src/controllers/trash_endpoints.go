@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"net/http"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetTrash Lists the deleted tasks
+//
+//	@Summary		Get the deleted tasks
+//	@Description	Lists the deleted tasks the user can access, the last deleted first. They are purged once the retention period (TRASH_RETENTION_DAYS) is over
+//	@Tags			Trash
+//	@Accept			json
+//	@Produce		json
+//	@Success		200	{object}	map[string]interface{}	"Trash retrieved successfully"
+//	@Failure		500	{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503	{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504	{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/trash [get]
+func getTrash(c *gin.Context) {
+	trash, err := service.GetTrash(c.Request.Context())
+	if err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Trash retrieved successfully", "tasks": trash})
+}
+
+// RestoreTask Moves a deleted task back from the trash
+//
+//	@Summary		Restore a deleted task
+//	@Description	Moves a task back from the trash, with its dependencies and the subtasks deleted together with it. Requires the same role as deleting it
+//	@Tags			Trash
+//	@Accept			json
+//	@Produce		json
+//	@Param			taskId	path		int						true	"Task ID"
+//	@Success		200		{object}	map[string]interface{}	"Task restored successfully"
+//	@Failure		400		{object}	map[string]interface{}	"Bad request"
+//	@Failure		403		{object}	map[string]interface{}	"Not allowed for the user role"
+//	@Failure		404		{object}	map[string]interface{}	"Task not found in the trash"
+//	@Failure		409		{object}	map[string]interface{}	"Parent task is in the trash"
+//	@Failure		500		{object}	map[string]interface{}	"Internal server error"
+//	@Failure		503		{object}	map[string]interface{}	"Database unavailable"
+//	@Failure		504		{object}	map[string]interface{}	"Database timeout"
+//	@Router			/api/tasks/{taskId}/restore [post]
+func restoreTask(c *gin.Context) {
+	taskId, err := service.ValidateTaskIdInput(c.Param("taskId"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if err = service.RestoreTask(c.Request.Context(), taskId); err != nil {
+		respondServiceError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Task restored successfully"})
+}
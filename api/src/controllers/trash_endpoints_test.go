@@ -0,0 +1,52 @@
+package controllers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"to-do-api/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTrashEndpoints(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "task"}`, tokens)
+	recorder := serveAuthenticated(router, http.MethodDelete, "/api/tasks/1", "", tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1", "", tokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Deleted task should not be found")
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/trash", "", tokens)
+	var response struct {
+		Tasks []service.TrashedTaskInfo `json:"tasks"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+	assert.Len(t, response.Tasks, 1, "Deleted task should be in the trash")
+	assert.Equal(t, "task", response.Tasks[0].Title, "Invalid title")
+
+	recorder = serveAuthenticated(router, http.MethodPost, "/api/tasks/1/restore", "", tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1", "", tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Restored task should be found")
+
+	recorder = serveAuthenticated(router, http.MethodPost, "/api/tasks/1/restore", "", tokens)
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Task out of the trash should not be restored")
+}
+
+func TestTrashRequiresAuth(t *testing.T) {
+	setMockRepository()
+	router := newRouter()
+
+	recorder := serveAuthenticated(router, http.MethodGet, "/api/trash", "", service.AuthTokens{})
+
+	assert.Equal(t, http.StatusUnauthorized, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+}
@@ -2,28 +2,28 @@ package controllers
 
 import (
 	"bytes"
+	"database/sql"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
+	"to-do-api/models"
 	"to-do-api/service"
 
-	"bou.ke/monkey"
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
 )
 
 func TestUpdateTask(t *testing.T) {
-	requestBody := TestTaskRequestBody{Title: "new title"}
+	requestBody := TestTaskRequestBody{Title: "new title", Status: "backlog"}
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 	context.Params = []gin.Param{{Key: "taskId", Value: "1"}} // Proper parameter setup
 
-	// Mock internal functions
-	monkey.Patch(service.UpdateTask, func(taskId uint, task service.TaskRequestBody) error {
-		return nil
-	})
-	defer monkey.UnpatchAll()
+	repository := setMockRepository()
+	repository.AddTask(context.Request.Context(), models.Task{Title: "old title"})
 
 	// Call the handler
 	updateTask(context)
@@ -36,7 +36,7 @@ func TestUpdateTask(t *testing.T) {
 }
 
 func TestUpdateTaskInvalidId(t *testing.T) {
-	requestBody := TestTaskRequestBody{Title: "new title"}
+	requestBody := TestTaskRequestBody{Title: "new title", Status: "backlog"}
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 	context.Params = []gin.Param{{Key: "taskId", Value: "a"}} // Proper parameter setup
 
@@ -51,15 +51,11 @@ func TestUpdateTaskInvalidId(t *testing.T) {
 }
 
 func TestUpdateTaskInexistentId(t *testing.T) {
-	requestBody := TestTaskRequestBody{Title: "new title"}
+	requestBody := TestTaskRequestBody{Title: "new title", Status: "backlog"}
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 	context.Params = []gin.Param{{Key: "taskId", Value: "10"}} // Proper parameter setup
 
-	// Mock internal functions
-	monkey.Patch(service.UpdateTask, func(taskId uint, task service.TaskRequestBody) error {
-		return service.ErrRowNotFound
-	})
-	defer monkey.UnpatchAll()
+	setMockRepository()
 
 	// Call the handler
 	updateTask(context)
@@ -72,15 +68,18 @@ func TestUpdateTaskInexistentId(t *testing.T) {
 }
 
 func TestUpdateTaskServerError(t *testing.T) {
-	requestBody := TestTaskRequestBody{Title: "new title"}
+	requestBody := TestTaskRequestBody{Title: "new title", Status: "backlog"}
 	context, recorder := getTestGinContextAndRecorder(requestBody)
 	context.Params = []gin.Param{{Key: "taskId", Value: "10"}} // Proper parameter setup
 
-	// Mock internal functions
-	monkey.Patch(service.UpdateTask, func(taskId uint, task service.TaskRequestBody) error {
-		return service.ErrDatabaseGeneral
-	})
-	defer monkey.UnpatchAll()
+	// Mock repository functions
+	repository := setMockRepository()
+	repository.queryTask = func(taskId uint) (models.Task, error) {
+		return models.Task{Id: taskId}, nil
+	}
+	repository.updateTask = func(updatedTask models.Task) error {
+		return sql.ErrConnDone
+	}
 
 	// Call the handler
 	updateTask(context)
@@ -120,8 +119,50 @@ func TestUpdateTaskEmptyBody(t *testing.T) {
 	updateTask(context)
 
 	// Validate response
-	expectedResponse := "{\"error\":\"at least one field must be present: 'title', 'description', 'priority', 'status, 'due_date'\"}"
+	expectedResponse := "{\"error\":\"missing required field: 'title'\"}"
 	responseBody, _ := io.ReadAll(w.Body)
 	assert.Equal(t, http.StatusBadRequest, w.Code, fmt.Sprintf("Unexpected status code: %d", w.Code))
 	assert.JSONEq(t, expectedResponse, string(responseBody), "Invalid response pattern")
 }
+
+func TestPatchTaskEmptyBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	w := httptest.NewRecorder()
+	context, _ := gin.CreateTestContext(w)
+	context.Params = []gin.Param{{Key: "taskId", Value: "1"}}
+	context.Request = httptest.NewRequest(http.MethodPatch, "/tasks/1", bytes.NewBufferString("{}"))
+	context.Request.Header.Set("Content-Type", "application/merge-patch+json")
+
+	// Call the handler
+	patchTask(context)
+
+	// Validate response
+	expectedResponse := "{\"error\":\"at least one field must be present: 'title', 'description', 'priority', 'status', 'due_date', 'estimate_hours', 'project_id', 'parent_id', 'recurrence'\"}"
+	responseBody, _ := io.ReadAll(w.Body)
+	assert.Equal(t, http.StatusBadRequest, w.Code, fmt.Sprintf("Unexpected status code: %d", w.Code))
+	assert.JSONEq(t, expectedResponse, string(responseBody), "Invalid response pattern")
+}
+
+func TestPatchTaskRemovesNullFields(t *testing.T) {
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "task", "description": "text", "due_date": 1770844785}`, tokens)
+
+	recorder := serveAuthenticated(router, http.MethodPatch, "/api/tasks/1", `{"title": "new title", "due_date": null}`, tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, fmt.Sprintf("Unexpected status code: %d", recorder.Code))
+
+	recorder = serveAuthenticated(router, http.MethodGet, "/api/tasks/1", "", tokens)
+	var response struct {
+		Task service.TaskResponseBody `json:"task"`
+	}
+	json.NewDecoder(recorder.Body).Decode(&response)
+	assert.Equal(t, "new title", response.Task.Title, "Title should be updated")
+	assert.Equal(t, "text", response.Task.Description, "Missing fields should be kept")
+	assert.Equal(t, time.Time{}.Unix(), response.Task.DueDate, "Null fields should be removed")
+
+	recorder = serveAuthenticated(router, http.MethodPatch, "/api/tasks/1", `{"status": null}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Required fields should not be removed")
+}
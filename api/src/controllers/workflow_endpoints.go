@@ -0,0 +1,20 @@
+package controllers
+
+import (
+	"net/http"
+	"to-do-api/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetWorkflow Retrieves the status workflow
+//
+//	@Summary		Get the status workflow
+//	@Description	Lists the task statuses in display order, with the statuses each one can move to. New tasks start on the first status.
+//	@Tags			Workflow
+//	@Produce		json
+//	@Success		200	{object}	map[string]interface{}	"Workflow retrieved successfully"
+//	@Router			/api/workflow [get]
+func getWorkflow(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"message": "Workflow retrieved successfully", "workflow": service.GetWorkflow()})
+}
@@ -0,0 +1,48 @@
+package controllers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"to-do-api/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetWorkflow(t *testing.T) {
+	recorder := httptest.NewRecorder()
+	newRouter().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/workflow", nil))
+
+	expectedResponse := `{"message":"Workflow retrieved successfully","workflow":{"statuses":[
+		{"name":"backlog","done":false,"transitions":["open","done"]},
+		{"name":"open","done":false,"transitions":["backlog","done"]},
+		{"name":"done","done":true,"transitions":["backlog","open"]}]}}`
+	assert.Equal(t, http.StatusOK, recorder.Code, "Workflow should be public")
+	assert.JSONEq(t, expectedResponse, recorder.Body.String(), "Invalid response JSON")
+}
+
+func TestUpdateTaskStatusTransition(t *testing.T) {
+	defaultWorkflow := service.GetWorkflow()
+	service.SetWorkflow(service.Workflow{Statuses: []service.WorkflowStatus{
+		{Name: "backlog", Transitions: []string{"open"}},
+		{Name: "open", Transitions: []string{"backlog", "done"}},
+		{Name: "done", Done: true, Transitions: []string{"open"}},
+	}})
+	t.Cleanup(func() { service.SetWorkflow(defaultWorkflow) })
+	setMockUserRepository()
+	setMockRepository()
+	tokens := registerTestUser(t)
+	router := newRouter()
+
+	serveAuthenticated(router, http.MethodPost, "/api/tasks", `{"title": "task"}`, tokens)
+
+	recorder := serveAuthenticated(router, http.MethodPatch, "/api/tasks/1", `{"status": "finished"}`, tokens)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Unknown statuses should be rejected")
+
+	recorder = serveAuthenticated(router, http.MethodPatch, "/api/tasks/1", `{"status": "done"}`, tokens)
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Transitions outside the workflow should be rejected")
+	assert.JSONEq(t, `{"error":"status transition not allowed by the workflow: from 'backlog' to 'done'"}`, recorder.Body.String(), "Invalid response JSON")
+
+	recorder = serveAuthenticated(router, http.MethodPatch, "/api/tasks/1", `{"status": "open"}`, tokens)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Transitions of the workflow should be accepted")
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"to-do-api/models"
+)
+
+const migrateUsage = "usage: to-do-api migrate up | down [steps] | status"
+
+// Runs the "migrate" command: up applies all pending migrations, down reverts
+// the last applied ones (1 by default) and status lists every migration
+func runMigrateCommand(db models.Database, args []string) error {
+	if len(args) == 0 {
+		return errors.New(migrateUsage)
+	}
+
+	switch args[0] {
+	case "up":
+		applied, err := models.MigrateUp(db)
+		if err != nil {
+			return err
+		}
+		for _, migration := range applied {
+			fmt.Printf("applied %04d_%s\n", migration.Version, migration.Name)
+		}
+		fmt.Printf("%d migration(s) applied\n", len(applied))
+
+	case "down":
+		steps := uint64(1)
+		if len(args) > 1 {
+			var err error
+			if steps, err = strconv.ParseUint(args[1], 10, 32); err != nil || steps == 0 {
+				return errors.New("invalid 'steps' value, must be int > 0")
+			}
+		}
+
+		reverted, err := models.MigrateDown(db, uint(steps))
+		if err != nil {
+			return err
+		}
+		for _, migration := range reverted {
+			fmt.Printf("reverted %04d_%s\n", migration.Version, migration.Name)
+		}
+		fmt.Printf("%d migration(s) reverted\n", len(reverted))
+
+	case "status":
+		status, err := models.GetMigrationsStatus(db)
+		if err != nil {
+			return err
+		}
+		for _, migration := range status {
+			appliedAt := "pending"
+			if migration.Applied {
+				appliedAt = "applied at " + migration.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%s\t%s\n", migration.Version, migration.Name, appliedAt)
+		}
+
+	default:
+		return errors.New(migrateUsage)
+	}
+
+	return nil
+}
@@ -0,0 +1,110 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const attachmentColumns = "id, task_id, COALESCE(uploader_id, 0), filename, content_type, size, blob_key, created_at"
+
+func (r *PostgresTaskRepository) AddAttachment(ctx context.Context, newAttachment Attachment) (uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	newAttachmentQuery := `
+		INSERT INTO attachments (task_id, uploader_id, filename, content_type, size, blob_key, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id;
+	`
+
+	var attachmentId uint
+	err := r.conn(ctx).QueryRow(ctx, newAttachmentQuery, newAttachment.TaskId, nullableId(newAttachment.UploaderId), newAttachment.Filename,
+		newAttachment.ContentType, newAttachment.Size, newAttachment.BlobKey, newAttachment.CreatedAt).Scan(&attachmentId)
+
+	return attachmentId, err
+}
+
+func (r *PostgresTaskRepository) QueryAttachments(ctx context.Context, taskId uint) ([]Attachment, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).Query(ctx, "SELECT "+attachmentColumns+" FROM attachments WHERE task_id = $1 ORDER BY id;", taskId)
+	if err != nil {
+		return []Attachment{}, err
+	}
+
+	return scanAttachments(rows)
+}
+
+func (r *PostgresTaskRepository) QueryAttachment(ctx context.Context, taskId uint, attachmentId uint) (Attachment, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	row := r.conn(ctx).QueryRow(ctx, "SELECT "+attachmentColumns+" FROM attachments WHERE id = $1 AND task_id = $2;", attachmentId, taskId)
+
+	return scanAttachment(row)
+}
+
+func (r *PostgresTaskRepository) DeleteAttachment(ctx context.Context, taskId uint, attachmentId uint) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := r.conn(ctx).Exec(ctx, "DELETE FROM attachments WHERE id = $1 AND task_id = $2;", attachmentId, taskId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PostgresTaskRepository) QueryPurgeableAttachments(ctx context.Context, deletedBefore time.Time) ([]Attachment, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	purgeableQuery := `
+		SELECT a.id, a.task_id, COALESCE(a.uploader_id, 0), a.filename, a.content_type, a.size, a.blob_key, a.created_at
+		FROM attachments a JOIN tasks t ON t.id = a.task_id
+		WHERE t.deleted_at < $1
+		ORDER BY a.id
+		FOR UPDATE OF t;
+	`
+
+	rows, err := r.conn(ctx).Query(ctx, purgeableQuery, deletedBefore)
+	if err != nil {
+		return []Attachment{}, err
+	}
+
+	return scanAttachments(rows)
+}
+
+func scanAttachment(row pgx.Row) (Attachment, error) {
+	var attachment Attachment
+	err := row.Scan(&attachment.Id, &attachment.TaskId, &attachment.UploaderId, &attachment.Filename,
+		&attachment.ContentType, &attachment.Size, &attachment.BlobKey, &attachment.CreatedAt)
+
+	return attachment, err
+}
+
+func scanAttachments(rows pgx.Rows) ([]Attachment, error) {
+	defer rows.Close()
+
+	attachments := []Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return []Attachment{}, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	if err := rows.Err(); err != nil {
+		return []Attachment{}, err
+	}
+
+	return attachments, nil
+}
@@ -0,0 +1,64 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Attachments Tests ///////////////////////////////////
+func TestAddAttachment(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	createdAt := time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC)
+	mockConn.ExpectQuery("INSERT INTO attachments \\(task_id, uploader_id, filename, content_type, size, blob_key, created_at\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7\\) RETURNING id;").
+		WithArgs(uint(1), testOwnerId, "crash.log", "text/plain; charset=utf-8", int64(120), "KEY1", createdAt).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(4)))
+
+	attachmentId, err := repository.AddAttachment(context.Background(), Attachment{
+		TaskId: 1, UploaderId: testOwnerId, Filename: "crash.log", ContentType: "text/plain; charset=utf-8", Size: 120, BlobKey: "KEY1", CreatedAt: createdAt,
+	})
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, uint(4), attachmentId, "Returned value should be 4")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestQueryPurgeableAttachments(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	deletedBefore := time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC)
+	mockConn.ExpectQuery("SELECT a.id, .* FROM attachments a JOIN tasks t ON t.id = a.task_id WHERE t.deleted_at < \\$1 ORDER BY a.id FOR UPDATE OF t;").
+		WithArgs(deletedBefore).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "task_id", "uploader_id", "filename", "content_type", "size", "blob_key", "created_at"}).
+			AddRow(uint(2), uint(5), uint(0), "screen.png", "image/png", int64(2048), "KEY2", deletedBefore))
+
+	attachments, err := repository.QueryPurgeableAttachments(context.Background(), deletedBefore)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, []Attachment{
+		{Id: 2, TaskId: 5, Filename: "screen.png", ContentType: "image/png", Size: 2048, BlobKey: "KEY2", CreatedAt: deletedBefore},
+	}, attachments, "Returned wrong attachments")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestDeleteAttachmentNotFound(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectExec("DELETE FROM attachments WHERE id = \\$1 AND task_id = \\$2;").
+		WithArgs(uint(3), uint(1)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err := repository.DeleteAttachment(context.Background(), 1, 3)
+
+	assert.Equal(t, sql.ErrNoRows, err, "Missing attachment should not be found")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
@@ -0,0 +1,32 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type Attachment struct {
+	Id          uint
+	TaskId      uint
+	UploaderId  uint // 0 when the uploader was deleted
+	Filename    string
+	ContentType string
+	Size        int64
+	BlobKey     string // key of the contents on the blob store
+	CreatedAt   time.Time
+}
+
+// AttachmentRepository stores the metadata of the files attached to the tasks, the contents
+// being kept on a BlobStore. Access to the task is checked by the caller.
+type AttachmentRepository interface {
+	AddAttachment(ctx context.Context, newAttachment Attachment) (uint, error)
+	// QueryAttachments lists the attachments of the task, oldest first
+	QueryAttachments(ctx context.Context, taskId uint) ([]Attachment, error)
+	// QueryAttachment fails with sql.ErrNoRows when the task has no such attachment
+	QueryAttachment(ctx context.Context, taskId uint, attachmentId uint) (Attachment, error)
+	// DeleteAttachment fails with sql.ErrNoRows when the task has no such attachment
+	DeleteAttachment(ctx context.Context, taskId uint, attachmentId uint) error
+	// QueryPurgeableAttachments lists the attachments of the tasks deleted before deletedBefore.
+	// In a transaction those tasks stay locked until it ends, so they can not be restored before being purged.
+	QueryPurgeableAttachments(ctx context.Context, deletedBefore time.Time) ([]Attachment, error)
+}
@@ -0,0 +1,132 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+)
+
+// Directory of the local blob store when ATTACHMENTS_DIR is not defined
+const defaultBlobStoreDir = "attachments"
+
+var ErrBlobNotFound = errors.New("blob not found")
+var ErrInvalidBlobKey = errors.New("invalid blob key")
+
+// Keys are used as file names by the local store, so they can not hold path separators
+var blobKeyPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// BlobStore keeps the contents of the attachments, referenced by key from their metadata
+type BlobStore interface {
+	// PutBlob stores the content under key. Nothing is kept when reading the content fails.
+	PutBlob(ctx context.Context, key string, content io.Reader) error
+	// OpenBlob fails with ErrBlobNotFound when there is no blob with the key
+	OpenBlob(ctx context.Context, key string) (io.ReadCloser, error)
+	// DeleteBlob does nothing when there is no blob with the key
+	DeleteBlob(ctx context.Context, key string) error
+}
+
+// LocalBlobStore keeps each blob in a file of a local directory
+type LocalBlobStore struct {
+	dir string
+}
+
+// NewLocalBlobStore stores the blobs in dir, creating it when missing
+func NewLocalBlobStore(dir string) (*LocalBlobStore, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return nil, err
+	}
+
+	return &LocalBlobStore{dir: dir}, nil
+}
+
+// Reads the directory of the local blob store from ATTACHMENTS_DIR env variable
+func getBlobStoreDir() string {
+	if dir := os.Getenv("ATTACHMENTS_DIR"); dir != "" {
+		return dir
+	}
+
+	return defaultBlobStoreDir
+}
+
+// ConnectBlobStore opens the local blob store in the ATTACHMENTS_DIR directory
+func ConnectBlobStore() *LocalBlobStore {
+	store, err := NewLocalBlobStore(getBlobStoreDir())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to open the attachments directory: %v\n", err)
+		os.Exit(1)
+	}
+
+	return store
+}
+
+func (s *LocalBlobStore) PutBlob(ctx context.Context, key string, content io.Reader) error {
+	path, err := s.blobPath(key)
+	if err != nil {
+		return err
+	}
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	// Written to a temporary file first, so a failed upload never leaves a partial blob
+	tmp, err := os.CreateTemp(s.dir, ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = io.Copy(tmp, content); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing blob %s: %w", key, err)
+	}
+	if err = tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
+
+func (s *LocalBlobStore) OpenBlob(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := s.blobPath(key)
+	if err != nil {
+		return nil, err
+	}
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, ErrBlobNotFound
+	}
+
+	return file, err
+}
+
+func (s *LocalBlobStore) DeleteBlob(ctx context.Context, key string) error {
+	path, err := s.blobPath(key)
+	if err != nil {
+		return err
+	}
+	if err = ctx.Err(); err != nil {
+		return err
+	}
+
+	if err = os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+func (s *LocalBlobStore) blobPath(key string) (string, error) {
+	if !blobKeyPattern.MatchString(key) {
+		return "", ErrInvalidBlobKey
+	}
+
+	return filepath.Join(s.dir, key), nil
+}
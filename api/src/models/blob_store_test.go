@@ -0,0 +1,62 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Reader failing after its content, like an interrupted upload
+type failingReader struct {
+	content io.Reader
+}
+
+func (f failingReader) Read(p []byte) (int, error) {
+	n, err := f.content.Read(p)
+	if err == io.EOF {
+		return n, errors.New("connection reset")
+	}
+	return n, err
+}
+
+func TestLocalBlobStore(t *testing.T) {
+	dir := t.TempDir()
+	store, err := NewLocalBlobStore(dir)
+	assert.NoError(t, err)
+
+	assert.NoError(t, store.PutBlob(context.Background(), "key1", strings.NewReader("content")))
+	blob, err := store.OpenBlob(context.Background(), "key1")
+	assert.NoError(t, err)
+	data, _ := io.ReadAll(blob)
+	blob.Close()
+	assert.Equal(t, "content", string(data))
+
+	assert.NoError(t, store.DeleteBlob(context.Background(), "key1"))
+	_, err = store.OpenBlob(context.Background(), "key1")
+	assert.Equal(t, ErrBlobNotFound, err)
+	assert.NoError(t, store.DeleteBlob(context.Background(), "key1"), "Deleting a missing blob should do nothing")
+}
+
+func TestLocalBlobStoreFailedPut(t *testing.T) {
+	dir := t.TempDir()
+	store, _ := NewLocalBlobStore(dir)
+
+	err := store.PutBlob(context.Background(), "key1", failingReader{content: strings.NewReader("partial")})
+	assert.ErrorContains(t, err, "connection reset")
+
+	entries, _ := os.ReadDir(dir)
+	assert.Empty(t, entries, "Failed upload should leave no file")
+}
+
+func TestLocalBlobStoreInvalidKey(t *testing.T) {
+	store, _ := NewLocalBlobStore(t.TempDir())
+
+	assert.Equal(t, ErrInvalidBlobKey, store.PutBlob(context.Background(), "../escape", strings.NewReader("content")))
+	_, err := store.OpenBlob(context.Background(), "a/b")
+	assert.Equal(t, ErrInvalidBlobKey, err)
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+)
+
+func (r *PostgresTaskRepository) AddChecklistItem(ctx context.Context, item ChecklistItem) (uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	addItemQuery := `
+		INSERT INTO checklist_items (task_id, text, checked, position)
+		SELECT $1, $2, $3, COALESCE(MAX(position) + 1, 0) FROM checklist_items WHERE task_id = $1
+		RETURNING id;
+	`
+
+	var itemId uint
+	err := r.conn(ctx).QueryRow(ctx, addItemQuery, item.TaskId, item.Text, item.Checked).Scan(&itemId)
+
+	return itemId, err
+}
+
+func (r *PostgresTaskRepository) QueryChecklist(ctx context.Context, taskId uint) ([]ChecklistItem, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).Query(ctx, "SELECT id, task_id, text, checked, position FROM checklist_items WHERE task_id = $1 ORDER BY position, id;", taskId)
+	if err != nil {
+		return []ChecklistItem{}, err
+	}
+	defer rows.Close()
+
+	items := []ChecklistItem{}
+	for rows.Next() {
+		var item ChecklistItem
+		if err = rows.Scan(&item.Id, &item.TaskId, &item.Text, &item.Checked, &item.Position); err != nil {
+			return []ChecklistItem{}, err
+		}
+		items = append(items, item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return []ChecklistItem{}, err
+	}
+
+	return items, nil
+}
+
+func (r *PostgresTaskRepository) UpdateChecklistItem(ctx context.Context, item ChecklistItem) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := r.conn(ctx).Exec(ctx, "UPDATE checklist_items SET text = $1, checked = $2 WHERE id = $3 AND task_id = $4;", item.Text, item.Checked, item.Id, item.TaskId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PostgresTaskRepository) ReorderChecklist(ctx context.Context, taskId uint, itemIds []uint) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	ids := make([]int64, len(itemIds))
+	for idx, itemId := range itemIds {
+		ids[idx] = int64(itemId)
+	}
+
+	// Positions start at 0, ORDINALITY at 1
+	reorderQuery := `
+		UPDATE checklist_items c SET position = o.position - 1
+		FROM unnest($2::int[]) WITH ORDINALITY AS o(id, position)
+		WHERE c.id = o.id AND c.task_id = $1;
+	`
+	_, err := r.conn(ctx).Exec(ctx, reorderQuery, taskId, ids)
+
+	return err
+}
+
+func (r *PostgresTaskRepository) DeleteChecklistItem(ctx context.Context, taskId uint, itemId uint) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := r.conn(ctx).Exec(ctx, "DELETE FROM checklist_items WHERE id = $1 AND task_id = $2;", itemId, taskId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,104 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Checklist Tests ///////////////////////////////////
+func TestAddChecklistItem(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectQuery("INSERT INTO checklist_items \\(task_id, text, checked, position\\) SELECT \\$1, \\$2, \\$3, COALESCE\\(MAX\\(position\\) \\+ 1, 0\\) FROM checklist_items WHERE task_id = \\$1 RETURNING id;").
+		WithArgs(uint(1), "Write tests", false).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(4)))
+
+	itemId, err := repository.AddChecklistItem(context.Background(), ChecklistItem{TaskId: 1, Text: "Write tests"})
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, uint(4), itemId, "Returned value should be 4")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestQueryChecklist(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectQuery("SELECT id, task_id, text, checked, position FROM checklist_items WHERE task_id = \\$1 ORDER BY position, id;").
+		WithArgs(uint(1)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "task_id", "text", "checked", "position"}).
+			AddRow(uint(2), uint(1), "First", true, uint(0)).
+			AddRow(uint(1), uint(1), "Second", false, uint(1)))
+
+	items, err := repository.QueryChecklist(context.Background(), 1)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, []ChecklistItem{
+		{Id: 2, TaskId: 1, Text: "First", Checked: true, Position: 0},
+		{Id: 1, TaskId: 1, Text: "Second", Checked: false, Position: 1},
+	}, items, "Returned wrong items")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestReorderChecklist(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectExec("UPDATE checklist_items c SET position = o.position - 1 FROM unnest\\(\\$2::int\\[\\]\\) WITH ORDINALITY AS o\\(id, position\\) WHERE c.id = o.id AND c.task_id = \\$1;").
+		WithArgs(uint(1), []int64{3, 1, 2}).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 3))
+
+	err := repository.ReorderChecklist(context.Background(), 1, []uint{3, 1, 2})
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestDeleteChecklistItemNotFound(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectExec("DELETE FROM checklist_items WHERE id = \\$1 AND task_id = \\$2;").
+		WithArgs(uint(5), uint(1)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err := repository.DeleteChecklistItem(context.Background(), 1, 5)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Should return ErrNoRows for inexistent item")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+// Memory Checklist Tests ///////////////////////////////////
+func TestMemoryChecklist(t *testing.T) {
+	repository := getTestMemoryRepository()
+	for _, text := range []string{"First", "Second", "Third"} {
+		repository.AddChecklistItem(context.Background(), ChecklistItem{TaskId: 1, Text: text})
+	}
+
+	err := repository.UpdateChecklistItem(context.Background(), ChecklistItem{Id: 2, TaskId: 1, Text: "Second", Checked: true})
+	assert.NoError(t, err, "Unexpected error updating item")
+	err = repository.ReorderChecklist(context.Background(), 1, []uint{3, 1, 2})
+	assert.NoError(t, err, "Unexpected error reordering items")
+
+	items, _ := repository.QueryChecklist(context.Background(), 1)
+	assert.Equal(t, []string{"Third", "First", "Second"}, []string{items[0].Text, items[1].Text, items[2].Text}, "Items should be reordered")
+
+	task, _ := repository.QueryTask(context.Background(), testOwnerId, 1)
+	assert.Equal(t, uint(3), task.ChecklistTotal, "Task should count its items")
+	assert.Equal(t, uint(1), task.ChecklistChecked, "Task should count its checked items")
+
+	err = repository.DeleteChecklistItem(context.Background(), 2, 1)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Items of other tasks should not be deleted")
+	err = repository.DeleteChecklistItem(context.Background(), 1, 1)
+	assert.NoError(t, err, "Unexpected error deleting item")
+
+	itemId, _ := repository.AddChecklistItem(context.Background(), ChecklistItem{TaskId: 1, Text: "Fourth"})
+	items, _ = repository.QueryChecklist(context.Background(), 1)
+	assert.Equal(t, itemId, items[len(items)-1].Id, "New item should be appended")
+}
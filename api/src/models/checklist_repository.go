@@ -0,0 +1,25 @@
+package models
+
+import "context"
+
+type ChecklistItem struct {
+	Id       uint
+	TaskId   uint
+	Text     string
+	Checked  bool
+	Position uint // items are listed by increasing position
+}
+
+// ChecklistRepository stores the checklist items of the tasks. Access to the task
+// is checked by the caller, the items being removed with the task when it is purged.
+type ChecklistRepository interface {
+	// AddChecklistItem appends the item at the end of the checklist of its task
+	AddChecklistItem(ctx context.Context, item ChecklistItem) (uint, error)
+	QueryChecklist(ctx context.Context, taskId uint) ([]ChecklistItem, error)
+	// UpdateChecklistItem sets the text and checked flag, failing with sql.ErrNoRows when the task has no such item
+	UpdateChecklistItem(ctx context.Context, item ChecklistItem) error
+	// ReorderChecklist moves each item to its index on itemIds
+	ReorderChecklist(ctx context.Context, taskId uint, itemIds []uint) error
+	// DeleteChecklistItem fails with sql.ErrNoRows when the task has no such item
+	DeleteChecklistItem(ctx context.Context, taskId uint, itemId uint) error
+}
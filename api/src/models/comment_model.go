@@ -0,0 +1,118 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Amount of comments of the task
+const commentCountColumn = "(SELECT COUNT(*) FROM comments cm WHERE cm.task_id = tasks.id)"
+
+func (r *PostgresTaskRepository) AddComment(ctx context.Context, newComment Comment) (uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	newCommentQuery := `
+		INSERT INTO comments (task_id, author_id, body, created_at)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id;
+	`
+
+	var commentId uint
+	err := r.conn(ctx).QueryRow(ctx, newCommentQuery, newComment.TaskId, nullableId(newComment.AuthorId), newComment.Body, newComment.CreatedAt).Scan(&commentId)
+
+	return commentId, err
+}
+
+func (r *PostgresTaskRepository) QueryComments(ctx context.Context, taskId uint) ([]Comment, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).Query(ctx, "SELECT id, task_id, COALESCE(author_id, 0), body, created_at, updated_at FROM comments WHERE task_id = $1 ORDER BY id;", taskId)
+	if err != nil {
+		return []Comment{}, err
+	}
+	defer rows.Close()
+
+	comments := []Comment{}
+	commentIdx := map[uint]int{}
+	for rows.Next() {
+		var comment Comment
+		var updatedAt *time.Time
+		if err = rows.Scan(&comment.Id, &comment.TaskId, &comment.AuthorId, &comment.Body, &comment.CreatedAt, &updatedAt); err != nil {
+			return []Comment{}, err
+		}
+		if updatedAt != nil {
+			comment.UpdatedAt = *updatedAt
+		}
+		comment.Edits = []CommentEdit{}
+		commentIdx[comment.Id] = len(comments)
+		comments = append(comments, comment)
+	}
+	if err = rows.Err(); err != nil {
+		return []Comment{}, err
+	}
+
+	editRows, err := r.conn(ctx).Query(ctx, "SELECT e.comment_id, e.body, e.edited_at FROM comment_edits e JOIN comments c ON c.id = e.comment_id WHERE c.task_id = $1 ORDER BY e.id;", taskId)
+	if err != nil {
+		return []Comment{}, err
+	}
+	defer editRows.Close()
+
+	for editRows.Next() {
+		var commentId uint
+		var edit CommentEdit
+		if err = editRows.Scan(&commentId, &edit.Body, &edit.EditedAt); err != nil {
+			return []Comment{}, err
+		}
+		if idx, found := commentIdx[commentId]; found {
+			comments[idx].Edits = append(comments[idx].Edits, edit)
+		}
+	}
+	if err = editRows.Err(); err != nil {
+		return []Comment{}, err
+	}
+
+	return comments, nil
+}
+
+func (r *PostgresTaskRepository) UpdateComment(ctx context.Context, taskId uint, commentId uint, body string, editedAt time.Time) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	// The previous body is read and kept on the edits in the same statement as the update
+	updateCommentQuery := `
+		WITH previous AS (
+			SELECT id, body FROM comments WHERE id = $1 AND task_id = $2 FOR UPDATE
+		), edit AS (
+			INSERT INTO comment_edits (comment_id, body, edited_at) SELECT id, body, $4 FROM previous
+		)
+		UPDATE comments SET body = $3, updated_at = $4 WHERE id IN (SELECT id FROM previous);
+	`
+
+	result, err := r.conn(ctx).Exec(ctx, updateCommentQuery, commentId, taskId, body, editedAt)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PostgresTaskRepository) DeleteComment(ctx context.Context, taskId uint, commentId uint) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := r.conn(ctx).Exec(ctx, "DELETE FROM comments WHERE id = $1 AND task_id = $2;", commentId, taskId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Comments Tests ///////////////////////////////////
+func TestAddComment(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	createdAt := time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC)
+	mockConn.ExpectQuery("INSERT INTO comments \\(task_id, author_id, body, created_at\\) VALUES \\(\\$1, \\$2, \\$3, \\$4\\) RETURNING id;").
+		WithArgs(uint(1), testOwnerId, "Looks good", createdAt).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(3)))
+
+	commentId, err := repository.AddComment(context.Background(), Comment{TaskId: 1, AuthorId: testOwnerId, Body: "Looks good", CreatedAt: createdAt})
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, uint(3), commentId, "Returned value should be 3")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestQueryComments(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	createdAt := time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC)
+	editedAt := createdAt.Add(time.Hour)
+	mockConn.ExpectQuery("SELECT id, task_id, COALESCE\\(author_id, 0\\), body, created_at, updated_at FROM comments WHERE task_id = \\$1 ORDER BY id;").
+		WithArgs(uint(1)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "task_id", "author_id", "body", "created_at", "updated_at"}).
+			AddRow(uint(1), uint(1), testOwnerId, "Looks great", createdAt, &editedAt).
+			AddRow(uint(2), uint(1), uint(0), "Agreed", createdAt, nil))
+	mockConn.ExpectQuery("SELECT e.comment_id, e.body, e.edited_at FROM comment_edits e JOIN comments c ON c.id = e.comment_id WHERE c.task_id = \\$1 ORDER BY e.id;").
+		WithArgs(uint(1)).
+		WillReturnRows(pgxmock.NewRows([]string{"comment_id", "body", "edited_at"}).AddRow(uint(1), "Looks good", editedAt))
+
+	comments, err := repository.QueryComments(context.Background(), 1)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, []Comment{
+		{Id: 1, TaskId: 1, AuthorId: testOwnerId, Body: "Looks great", CreatedAt: createdAt, UpdatedAt: editedAt, Edits: []CommentEdit{{Body: "Looks good", EditedAt: editedAt}}},
+		{Id: 2, TaskId: 1, Body: "Agreed", CreatedAt: createdAt, Edits: []CommentEdit{}},
+	}, comments, "Returned wrong comments")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestUpdateCommentNotFound(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	editedAt := time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC)
+	mockConn.ExpectExec("WITH previous AS \\(.+\\) UPDATE comments SET body = \\$3, updated_at = \\$4 WHERE id IN \\(SELECT id FROM previous\\);").
+		WithArgs(uint(4), uint(1), "Edited", editedAt).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err := repository.UpdateComment(context.Background(), 1, 4, "Edited", editedAt)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Should return ErrNoRows for inexistent comment")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+// Memory Comments Tests ///////////////////////////////////
+func TestMemoryComments(t *testing.T) {
+	repository := getTestMemoryRepository()
+	repository.AddComment(context.Background(), Comment{TaskId: 1, AuthorId: testOwnerId, Body: "First"})
+	repository.AddComment(context.Background(), Comment{TaskId: 1, AuthorId: testOwnerId, Body: "Second"})
+
+	editedAt := time.Now()
+	assert.NoError(t, repository.UpdateComment(context.Background(), 1, 1, "First, edited", editedAt))
+	assert.ErrorIs(t, repository.UpdateComment(context.Background(), 2, 1, "Other task", editedAt), sql.ErrNoRows)
+
+	comments, _ := repository.QueryComments(context.Background(), 1)
+	assert.Equal(t, "First, edited", comments[0].Body)
+	assert.Equal(t, []CommentEdit{{Body: "First", EditedAt: editedAt}}, comments[0].Edits, "Previous body should be kept")
+	task, _ := repository.QueryTask(context.Background(), testOwnerId, 1)
+	assert.Equal(t, uint(2), task.CommentCount, "Task should count its comments")
+
+	assert.NoError(t, repository.DeleteComment(context.Background(), 1, 2))
+	repository.DeleteTask(context.Background(), testOwnerId, 1, 1)
+	repository.PurgeDeletedTasks(context.Background(), time.Now().Add(time.Minute))
+	comments, _ = repository.QueryComments(context.Background(), 1)
+	assert.Empty(t, comments, "Comments should be removed with the purged task")
+}
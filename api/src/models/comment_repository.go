@@ -0,0 +1,35 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+type Comment struct {
+	Id        uint
+	TaskId    uint
+	AuthorId  uint // 0 when the author was deleted
+	Body      string
+	CreatedAt time.Time
+	UpdatedAt time.Time     // zero when the comment was never edited
+	Edits     []CommentEdit // previous bodies, oldest first
+}
+
+// CommentEdit holds the body of a comment before it was edited
+type CommentEdit struct {
+	Body     string
+	EditedAt time.Time
+}
+
+// CommentRepository stores the comments of the tasks. Access to the task is checked by the
+// caller, the comments being hidden with the task in the trash and removed when it is purged.
+type CommentRepository interface {
+	AddComment(ctx context.Context, newComment Comment) (uint, error)
+	// QueryComments lists the comments of the task with their edits, oldest first
+	QueryComments(ctx context.Context, taskId uint) ([]Comment, error)
+	// UpdateComment replaces the body, keeping the previous one on the edits.
+	// It fails with sql.ErrNoRows when the task has no such comment.
+	UpdateComment(ctx context.Context, taskId uint, commentId uint, body string, editedAt time.Time) error
+	// DeleteComment fails with sql.ErrNoRows when the task has no such comment
+	DeleteComment(ctx context.Context, taskId uint, commentId uint) error
+}
@@ -2,10 +2,13 @@ package models
 
 import (
 	"context"
-	_ "embed"
+	"errors"
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -13,6 +16,8 @@ import (
 	"github.com/joho/godotenv"
 )
 
+const defaultQueryTimeout = 5 * time.Second
+
 // Database interface for mocking
 type Database interface {
 	Begin(context.Context) (pgx.Tx, error)
@@ -22,37 +27,131 @@ type Database interface {
 	Close()
 }
 
-var dbConn Database
+// ConnectDatabase opens the connection pool shared by the Postgres repositories.
+// The pool lives for the whole process and must be closed on shutdown.
+func ConnectDatabase() *pgxpool.Pool {
+	poolConfig, err := pgxpool.ParseConfig(getDatabaseUrl())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid database configuration: %v\n", err)
+		os.Exit(1)
+	}
 
-//go:embed schema.sql
-var dbInitQuery string
+	if err = configurePool(poolConfig); err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid database pool configuration: %v\n", err)
+		os.Exit(1)
+	}
 
-func InitDatabase() {
-	conn, err := pgx.Connect(context.Background(), getDatabaseUrl())
+	pool, err := pgxpool.NewWithConfig(context.Background(), poolConfig)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Unable to connect to database: %v\n", err)
 		os.Exit(1)
 	}
 
-	if _, err := conn.Exec(context.Background(), dbInitQuery); err != nil {
-		panic(err)
+	pingCtx, cancel := context.WithTimeout(context.Background(), poolConfig.ConnConfig.ConnectTimeout+5*time.Second)
+	defer cancel()
+	if err = pool.Ping(pingCtx); err != nil {
+		pool.Close()
+		fmt.Fprintf(os.Stderr, "Unable to connect to database: %v\n", err)
+		os.Exit(1)
 	}
 
-	defer conn.Close(context.Background())
+	return pool
 }
 
-func getDatabaseConnection() Database {
-	if dbConn != nil {
-		return dbConn
+// GetQueryTimeout returns the maximum duration of each database query, defined
+// by DB_QUERY_TIMEOUT env variable (5s by default, 0 disables the limit)
+func GetQueryTimeout() time.Duration {
+	value, exist := os.LookupEnv("DB_QUERY_TIMEOUT")
+	if !exist || value == "" {
+		return defaultQueryTimeout
 	}
 
-	// conn, err := pgx.Connect(context.Background(), getDatabaseUrl())
-	conn, err := pgxpool.New(context.Background(), getDatabaseUrl())
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Unable to connect to database: %v\n", err)
-		os.Exit(1)
+	queryTimeout, err := time.ParseDuration(value)
+	if err != nil || queryTimeout < 0 {
+		log.Fatalf("Invalid DB_QUERY_TIMEOUT value '%s', must be a duration >= 0", value)
+	}
+
+	return queryTimeout
+}
+
+// IsUnavailableError reports whether the error means the database can not
+// serve requests right now (unreachable server, no connections left, shutting down)
+func IsUnavailableError(err error) bool {
+	var connectErr *pgconn.ConnectError
+	if errors.As(err, &connectErr) {
+		return true
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		// Classes 08 (connection exception), 53 (insufficient resources) and 57P (operator intervention)
+		return strings.HasPrefix(pgErr.Code, "08") || strings.HasPrefix(pgErr.Code, "53") || strings.HasPrefix(pgErr.Code, "57P")
+	}
+
+	return false
+}
+
+// Applies the optional pool settings defined by env variables:
+// DB_MAX_CONNS, DB_MIN_CONNS, DB_MAX_CONN_LIFETIME, DB_MAX_CONN_IDLE_TIME,
+// DB_HEALTH_CHECK_PERIOD and DB_CONNECT_TIMEOUT (durations as "30s", "5m", ...)
+func configurePool(poolConfig *pgxpool.Config) error {
+	var err error
+
+	if poolConfig.MaxConns, err = lookupInt32Env("DB_MAX_CONNS", poolConfig.MaxConns); err != nil {
+		return err
 	}
-	return conn
+	if poolConfig.MinConns, err = lookupInt32Env("DB_MIN_CONNS", poolConfig.MinConns); err != nil {
+		return err
+	}
+	if poolConfig.MaxConns < 1 {
+		return fmt.Errorf("DB_MAX_CONNS must be at least 1")
+	}
+	if poolConfig.MinConns > poolConfig.MaxConns {
+		return fmt.Errorf("DB_MIN_CONNS (%d) must not be greater than DB_MAX_CONNS (%d)", poolConfig.MinConns, poolConfig.MaxConns)
+	}
+
+	if poolConfig.MaxConnLifetime, err = lookupDurationEnv("DB_MAX_CONN_LIFETIME", poolConfig.MaxConnLifetime); err != nil {
+		return err
+	}
+	if poolConfig.MaxConnIdleTime, err = lookupDurationEnv("DB_MAX_CONN_IDLE_TIME", poolConfig.MaxConnIdleTime); err != nil {
+		return err
+	}
+	if poolConfig.HealthCheckPeriod, err = lookupDurationEnv("DB_HEALTH_CHECK_PERIOD", poolConfig.HealthCheckPeriod); err != nil {
+		return err
+	}
+	if poolConfig.ConnConfig.ConnectTimeout, err = lookupDurationEnv("DB_CONNECT_TIMEOUT", poolConfig.ConnConfig.ConnectTimeout); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func lookupInt32Env(name string, defaultValue int32) (int32, error) {
+	value, exist := os.LookupEnv(name)
+	if !exist || value == "" {
+		return defaultValue, nil
+	}
+
+	parsedValue, err := strconv.ParseInt(value, 10, 32)
+	if err != nil || parsedValue < 0 {
+		return defaultValue, fmt.Errorf("invalid %s value '%s', must be int >= 0", name, value)
+	}
+
+	return int32(parsedValue), nil
+}
+
+func lookupDurationEnv(name string, defaultValue time.Duration) (time.Duration, error) {
+	value, exist := os.LookupEnv(name)
+	if !exist || value == "" {
+		return defaultValue, nil
+	}
+
+	parsedValue, err := time.ParseDuration(value)
+	if err != nil || parsedValue <= 0 {
+		return defaultValue, fmt.Errorf("invalid %s value '%s', must be a positive duration", name, value)
+	}
+
+	return parsedValue, nil
 }
 
 func getDatabaseUrl() string {
@@ -0,0 +1,88 @@
+package models
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestPoolConfig(t *testing.T) *pgxpool.Config {
+	poolConfig, err := pgxpool.ParseConfig("host=localhost user=test password=test dbname=test sslmode=disable")
+	assert.NoError(t, err, "Unexpected error parsing connection string")
+	return poolConfig
+}
+
+func TestConfigurePoolDefaults(t *testing.T) {
+	poolConfig := getTestPoolConfig(t)
+	defaultMaxConns := poolConfig.MaxConns
+	defaultLifetime := poolConfig.MaxConnLifetime
+
+	err := configurePool(poolConfig)
+
+	assert.NoError(t, err, "Pool without env settings should be valid")
+	assert.Equal(t, defaultMaxConns, poolConfig.MaxConns, "MaxConns should keep pgxpool default")
+	assert.Equal(t, defaultLifetime, poolConfig.MaxConnLifetime, "MaxConnLifetime should keep pgxpool default")
+}
+
+func TestConfigurePoolFromEnv(t *testing.T) {
+	t.Setenv("DB_MAX_CONNS", "20")
+	t.Setenv("DB_MIN_CONNS", "2")
+	t.Setenv("DB_MAX_CONN_LIFETIME", "30m")
+	t.Setenv("DB_MAX_CONN_IDLE_TIME", "5m")
+	t.Setenv("DB_HEALTH_CHECK_PERIOD", "15s")
+	t.Setenv("DB_CONNECT_TIMEOUT", "3s")
+
+	poolConfig := getTestPoolConfig(t)
+	err := configurePool(poolConfig)
+
+	assert.NoError(t, err, "Unexpected error configuring pool")
+	assert.Equal(t, int32(20), poolConfig.MaxConns)
+	assert.Equal(t, int32(2), poolConfig.MinConns)
+	assert.Equal(t, 30*time.Minute, poolConfig.MaxConnLifetime)
+	assert.Equal(t, 5*time.Minute, poolConfig.MaxConnIdleTime)
+	assert.Equal(t, 15*time.Second, poolConfig.HealthCheckPeriod)
+	assert.Equal(t, 3*time.Second, poolConfig.ConnConfig.ConnectTimeout)
+}
+
+func TestConfigurePoolInvalidEnv(t *testing.T) {
+	t.Setenv("DB_MAX_CONNS", "many")
+	err := configurePool(getTestPoolConfig(t))
+	assert.EqualError(t, err, "invalid DB_MAX_CONNS value 'many', must be int >= 0")
+
+	t.Setenv("DB_MAX_CONNS", "0")
+	err = configurePool(getTestPoolConfig(t))
+	assert.EqualError(t, err, "DB_MAX_CONNS must be at least 1")
+
+	t.Setenv("DB_MAX_CONNS", "2")
+	t.Setenv("DB_MIN_CONNS", "5")
+	err = configurePool(getTestPoolConfig(t))
+	assert.EqualError(t, err, "DB_MIN_CONNS (5) must not be greater than DB_MAX_CONNS (2)")
+
+	t.Setenv("DB_MIN_CONNS", "")
+	t.Setenv("DB_MAX_CONN_LIFETIME", "forever")
+	err = configurePool(getTestPoolConfig(t))
+	assert.EqualError(t, err, "invalid DB_MAX_CONN_LIFETIME value 'forever', must be a positive duration")
+}
+
+func TestIsUnavailableError(t *testing.T) {
+	assert.True(t, IsUnavailableError(&pgconn.ConnectError{}), "Connection errors should be unavailable")
+	assert.True(t, IsUnavailableError(&pgconn.PgError{Code: "08006"}), "Connection failure should be unavailable")
+	assert.True(t, IsUnavailableError(&pgconn.PgError{Code: "53300"}), "Too many connections should be unavailable")
+	assert.True(t, IsUnavailableError(&pgconn.PgError{Code: "57P01"}), "Admin shutdown should be unavailable")
+	assert.False(t, IsUnavailableError(&pgconn.PgError{Code: "23505"}), "Unique violation should not be unavailable")
+	assert.False(t, IsUnavailableError(errors.New("unknown")), "Unknown error should not be unavailable")
+}
+
+func TestGetQueryTimeout(t *testing.T) {
+	assert.Equal(t, defaultQueryTimeout, GetQueryTimeout(), "Should return default timeout when env is not set")
+
+	t.Setenv("DB_QUERY_TIMEOUT", "250ms")
+	assert.Equal(t, 250*time.Millisecond, GetQueryTimeout(), "Should return timeout from env")
+
+	t.Setenv("DB_QUERY_TIMEOUT", "0")
+	assert.Equal(t, time.Duration(0), GetQueryTimeout(), "Should allow disabling the timeout")
+}
@@ -0,0 +1,126 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Key of the advisory lock serializing the dependency insertions, so two
+// concurrent requests can not create a cycle together
+const dependenciesLockKey = 707371
+
+// Checks if $2 reaches $1 following the dependencies, meaning that
+// adding the dependency ($1 depends on $2) would close a cycle
+const dependencyCycleQuery = `
+	WITH RECURSIVE reachable(id) AS (
+		SELECT depends_on_id FROM task_dependencies WHERE task_id = $2
+		UNION
+		SELECT d.depends_on_id FROM task_dependencies d JOIN reachable r ON d.task_id = r.id
+	)
+	SELECT EXISTS(SELECT 1 FROM reachable WHERE id = $1);
+`
+
+func (r *PostgresTaskRepository) AddDependency(ctx context.Context, taskId uint, dependsOnId uint) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	tx, err := r.conn(ctx).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if _, err = tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1);", dependenciesLockKey); err != nil {
+		return err
+	}
+
+	var createsCycle bool
+	if err = tx.QueryRow(ctx, dependencyCycleQuery, taskId, dependsOnId).Scan(&createsCycle); err != nil {
+		return err
+	}
+	if createsCycle {
+		return ErrDependencyCycle
+	}
+
+	result, err := tx.Exec(ctx, "INSERT INTO task_dependencies (task_id, depends_on_id) VALUES ($1, $2) ON CONFLICT DO NOTHING;", taskId, dependsOnId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrDependencyExists
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *PostgresTaskRepository) DeleteDependency(ctx context.Context, taskId uint, dependsOnId uint) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := r.conn(ctx).Exec(ctx, "DELETE FROM task_dependencies WHERE task_id = $1 AND depends_on_id = $2;", taskId, dependsOnId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PostgresTaskRepository) QueryDependencies(ctx context.Context, taskId uint) ([]uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).Query(ctx, "SELECT d.depends_on_id FROM task_dependencies d JOIN tasks t ON t.id = d.depends_on_id WHERE d.task_id = $1 AND t.deleted_at IS NULL ORDER BY d.depends_on_id;", taskId)
+	if err != nil {
+		return []uint{}, err
+	}
+	defer rows.Close()
+
+	dependencies := []uint{}
+	for rows.Next() {
+		var dependsOnId uint
+		if err = rows.Scan(&dependsOnId); err != nil {
+			return []uint{}, err
+		}
+		dependencies = append(dependencies, dependsOnId)
+	}
+
+	if err = rows.Err(); err != nil {
+		return []uint{}, err
+	}
+
+	return dependencies, nil
+}
+
+func (r *PostgresTaskRepository) QueryDependenciesOf(ctx context.Context, taskIds []uint) (map[uint][]uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	ids := make([]int64, len(taskIds))
+	for idx, taskId := range taskIds {
+		ids[idx] = int64(taskId)
+	}
+
+	rows, err := r.conn(ctx).Query(ctx, "SELECT d.task_id, d.depends_on_id FROM task_dependencies d JOIN tasks t ON t.id = d.depends_on_id WHERE d.task_id = ANY($1) AND t.deleted_at IS NULL ORDER BY d.task_id, d.depends_on_id;", ids)
+	if err != nil {
+		return map[uint][]uint{}, err
+	}
+	defer rows.Close()
+
+	dependencies := map[uint][]uint{}
+	for rows.Next() {
+		var taskId, dependsOnId uint
+		if err = rows.Scan(&taskId, &dependsOnId); err != nil {
+			return map[uint][]uint{}, err
+		}
+		dependencies[taskId] = append(dependencies[taskId], dependsOnId)
+	}
+
+	if err = rows.Err(); err != nil {
+		return map[uint][]uint{}, err
+	}
+
+	return dependencies, nil
+}
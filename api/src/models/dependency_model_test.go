@@ -0,0 +1,154 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Set the expectations for locking the dependencies and checking for cycles
+func expectDependencyCycleCheck(mockConn pgxmock.PgxPoolIface, taskId uint, dependsOnId uint, createsCycle bool) {
+	mockConn.ExpectBegin()
+	mockConn.ExpectExec("SELECT pg_advisory_xact_lock\\(\\$1\\);").
+		WithArgs(dependenciesLockKey).
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	mockConn.ExpectQuery("WITH RECURSIVE reachable").
+		WithArgs(taskId, dependsOnId).
+		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(createsCycle))
+}
+
+// Dependencies Tests ///////////////////////////////////
+func TestAddDependency(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	expectDependencyCycleCheck(mockConn, 1, 2, false)
+	mockConn.ExpectExec("INSERT INTO task_dependencies \\(task_id, depends_on_id\\) VALUES \\(\\$1, \\$2\\) ON CONFLICT DO NOTHING;").
+		WithArgs(uint(1), uint(2)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mockConn.ExpectCommit()
+	mockConn.ExpectRollback()
+
+	err := repository.AddDependency(context.Background(), 1, 2)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestAddDependencyCycle(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	expectDependencyCycleCheck(mockConn, 1, 2, true)
+	mockConn.ExpectRollback()
+
+	err := repository.AddDependency(context.Background(), 1, 2)
+
+	assert.ErrorIs(t, err, ErrDependencyCycle, "Should reject dependency closing a cycle")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestAddDependencyAlreadyExists(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	expectDependencyCycleCheck(mockConn, 1, 2, false)
+	mockConn.ExpectExec("INSERT INTO task_dependencies").
+		WithArgs(uint(1), uint(2)).
+		WillReturnResult(pgxmock.NewResult("INSERT", 0))
+	mockConn.ExpectRollback()
+
+	err := repository.AddDependency(context.Background(), 1, 2)
+
+	assert.ErrorIs(t, err, ErrDependencyExists, "Should reject duplicated dependency")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestDeleteDependencyNotFound(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectExec("DELETE FROM task_dependencies WHERE task_id = \\$1 AND depends_on_id = \\$2;").
+		WithArgs(uint(1), uint(2)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err := repository.DeleteDependency(context.Background(), 1, 2)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Should return ErrNoRows for inexistent dependency")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestQueryDependencies(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectQuery("SELECT d.depends_on_id FROM task_dependencies d JOIN tasks t ON t.id = d.depends_on_id WHERE d.task_id = \\$1 AND t.deleted_at IS NULL ORDER BY d.depends_on_id;").
+		WithArgs(uint(1)).
+		WillReturnRows(pgxmock.NewRows([]string{"depends_on_id"}).AddRow(uint(2)).AddRow(uint(3)))
+
+	dependencies, err := repository.QueryDependencies(context.Background(), 1)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, []uint{2, 3}, dependencies, "Returned wrong dependencies")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+// Memory Dependencies Tests ///////////////////////////////////
+func TestMemoryAddDependencyCycle(t *testing.T) {
+	repository := getTestMemoryRepository()
+
+	assert.NoError(t, repository.AddDependency(context.Background(), 1, 2), "Unexpected error adding dependency")
+	assert.NoError(t, repository.AddDependency(context.Background(), 2, 3), "Unexpected error adding dependency")
+
+	err := repository.AddDependency(context.Background(), 3, 1)
+	assert.ErrorIs(t, err, ErrDependencyCycle, "Transitive cycle should be rejected")
+
+	err = repository.AddDependency(context.Background(), 1, 2)
+	assert.ErrorIs(t, err, ErrDependencyExists, "Duplicated dependency should be rejected")
+
+	dependencies, _ := repository.QueryDependencies(context.Background(), 3)
+	assert.Empty(t, dependencies, "Rejected dependency should not be stored")
+}
+
+func TestMemoryDeletedTaskDependencies(t *testing.T) {
+	repository := getTestMemoryRepository()
+	repository.AddDependency(context.Background(), 1, 2)
+	repository.AddDependency(context.Background(), 1, 3)
+
+	err := repository.DeleteTask(context.Background(), testOwnerId, 2, 1)
+	assert.NoError(t, err, "Unexpected error deleting task")
+
+	dependencies, err := repository.QueryDependencies(context.Background(), 1)
+	assert.NoError(t, err, "Unexpected error querying dependencies")
+	assert.Equal(t, []uint{3}, dependencies, "Dependency on deleted task should be hidden")
+
+	repository.RestoreTask(context.Background(), testOwnerId, 2)
+	dependencies, _ = repository.QueryDependencies(context.Background(), 1)
+	assert.Equal(t, []uint{2, 3}, dependencies, "Dependency on restored task should be back")
+
+	repository.DeleteTask(context.Background(), testOwnerId, 2, 3)
+	repository.PurgeDeletedTasks(context.Background(), time.Now().Add(time.Minute))
+
+	err = repository.DeleteDependency(context.Background(), 1, 2)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Dependency on purged task should be removed")
+}
+
+func TestQueryDependenciesOf(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectQuery("SELECT d.task_id, d.depends_on_id FROM task_dependencies d JOIN tasks t ON t.id = d.depends_on_id WHERE d.task_id = ANY\\(\\$1\\) AND t.deleted_at IS NULL").
+		WithArgs([]int64{1, 2}).
+		WillReturnRows(pgxmock.NewRows([]string{"task_id", "depends_on_id"}).AddRow(uint(1), uint(2)).AddRow(uint(1), uint(3)))
+
+	dependencies, err := repository.QueryDependenciesOf(context.Background(), []uint{1, 2})
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, map[uint][]uint{1: {2, 3}}, dependencies, "Returned wrong dependencies")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrDependencyCycle = errors.New("dependency would create a cycle")
+var ErrDependencyExists = errors.New("dependency already exists")
+
+// DependencyRepository stores which tasks must be done before others.
+// A dependency (taskId, dependsOnId) means taskId can only start after dependsOnId.
+type DependencyRepository interface {
+	// AddDependency fails with ErrDependencyCycle when dependsOnId already
+	// depends on taskId (directly or transitively)
+	AddDependency(ctx context.Context, taskId uint, dependsOnId uint) error
+	// DeleteDependency fails with sql.ErrNoRows when the dependency does not exist
+	DeleteDependency(ctx context.Context, taskId uint, dependsOnId uint) error
+	QueryDependencies(ctx context.Context, taskId uint) ([]uint, error)
+	// QueryDependenciesOf returns the dependencies of each given task, by task id
+	QueryDependenciesOf(ctx context.Context, taskIds []uint) (map[uint][]uint, error)
+}
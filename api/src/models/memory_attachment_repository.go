@@ -0,0 +1,91 @@
+package models
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+)
+
+func (r *MemoryTaskRepository) AddAttachment(ctx context.Context, newAttachment Attachment) (uint, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	newAttachment.Id = r.nextAttachmentId
+	r.attachments[newAttachment.TaskId] = append(r.attachments[newAttachment.TaskId], newAttachment)
+	r.nextAttachmentId++
+
+	return newAttachment.Id, nil
+}
+
+func (r *MemoryTaskRepository) QueryAttachments(ctx context.Context, taskId uint) ([]Attachment, error) {
+	if err := ctx.Err(); err != nil {
+		return []Attachment{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Attachment{}, r.attachments[taskId]...), nil
+}
+
+func (r *MemoryTaskRepository) QueryAttachment(ctx context.Context, taskId uint, attachmentId uint) (Attachment, error) {
+	if err := ctx.Err(); err != nil {
+		return Attachment{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	attachments := r.attachments[taskId]
+	idx := slices.IndexFunc(attachments, func(attachment Attachment) bool { return attachment.Id == attachmentId })
+	if idx < 0 {
+		return Attachment{}, sql.ErrNoRows
+	}
+
+	return attachments[idx], nil
+}
+
+func (r *MemoryTaskRepository) DeleteAttachment(ctx context.Context, taskId uint, attachmentId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	attachments := r.attachments[taskId]
+	idx := slices.IndexFunc(attachments, func(attachment Attachment) bool { return attachment.Id == attachmentId })
+	if idx < 0 {
+		return sql.ErrNoRows
+	}
+	r.attachments[taskId] = slices.Delete(slices.Clone(attachments), idx, idx+1)
+
+	return nil
+}
+
+// Unlike the SQL query the tasks are not locked, the memory transactions not being isolated
+func (r *MemoryTaskRepository) QueryPurgeableAttachments(ctx context.Context, deletedBefore time.Time) ([]Attachment, error) {
+	if err := ctx.Err(); err != nil {
+		return []Attachment{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	purgeable := []Attachment{}
+	for taskId, attachments := range r.attachments {
+		task, found := r.tasks[taskId]
+		if found && !task.DeletedAt.IsZero() && task.DeletedAt.Before(deletedBefore) {
+			purgeable = append(purgeable, attachments...)
+		}
+	}
+	slices.SortFunc(purgeable, func(a, b Attachment) int { return cmp.Compare(a.Id, b.Id) })
+
+	return purgeable, nil
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"sync"
+)
+
+// MemoryBlobStore keeps the blobs in memory, to use with the memory repository for demos and tests
+type MemoryBlobStore struct {
+	mu    sync.RWMutex
+	blobs map[string][]byte
+}
+
+func NewMemoryBlobStore() *MemoryBlobStore {
+	return &MemoryBlobStore{blobs: map[string][]byte{}}
+}
+
+func (s *MemoryBlobStore) PutBlob(ctx context.Context, key string, content io.Reader) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.blobs[key] = data
+
+	return nil
+}
+
+func (s *MemoryBlobStore) OpenBlob(ctx context.Context, key string) (io.ReadCloser, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	data, found := s.blobs[key]
+	if !found {
+		return nil, ErrBlobNotFound
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (s *MemoryBlobStore) DeleteBlob(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.blobs, key)
+
+	return nil
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+)
+
+func (r *MemoryTaskRepository) AddChecklistItem(ctx context.Context, item ChecklistItem) (uint, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	checklist := r.checklists[item.TaskId]
+	item.Id = r.nextChecklistItemId
+	item.Position = 0
+	if len(checklist) > 0 {
+		item.Position = checklist[len(checklist)-1].Position + 1
+	}
+	r.checklists[item.TaskId] = append(checklist, item)
+	r.nextChecklistItemId++
+
+	return item.Id, nil
+}
+
+func (r *MemoryTaskRepository) QueryChecklist(ctx context.Context, taskId uint) ([]ChecklistItem, error) {
+	if err := ctx.Err(); err != nil {
+		return []ChecklistItem{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]ChecklistItem{}, r.checklists[taskId]...), nil
+}
+
+func (r *MemoryTaskRepository) UpdateChecklistItem(ctx context.Context, item ChecklistItem) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	checklist := r.checklists[item.TaskId]
+	idx := slices.IndexFunc(checklist, func(stored ChecklistItem) bool { return stored.Id == item.Id })
+	if idx < 0 {
+		return sql.ErrNoRows
+	}
+	checklist[idx].Text = item.Text
+	checklist[idx].Checked = item.Checked
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) ReorderChecklist(ctx context.Context, taskId uint, itemIds []uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	checklist := r.checklists[taskId]
+	for position, itemId := range itemIds {
+		if idx := slices.IndexFunc(checklist, func(item ChecklistItem) bool { return item.Id == itemId }); idx >= 0 {
+			checklist[idx].Position = uint(position)
+		}
+	}
+	slices.SortStableFunc(checklist, compareChecklistItems)
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) DeleteChecklistItem(ctx context.Context, taskId uint, itemId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	checklist := r.checklists[taskId]
+	idx := slices.IndexFunc(checklist, func(item ChecklistItem) bool { return item.Id == itemId })
+	if idx < 0 {
+		return sql.ErrNoRows
+	}
+	r.checklists[taskId] = slices.Delete(checklist, idx, idx+1)
+
+	return nil
+}
+
+// Same order as the SQL query: by position, then by id
+func compareChecklistItems(a, b ChecklistItem) int {
+	if a.Position != b.Position {
+		return int(a.Position) - int(b.Position)
+	}
+	return int(a.Id) - int(b.Id)
+}
+
+// Task with the counts of its checklist items, as read from the SQL taskColumns
+func (r *MemoryTaskRepository) withChecklistCounts(task Task) Task {
+	task.ChecklistTotal = uint(len(r.checklists[task.Id]))
+	task.ChecklistChecked = 0
+	for _, item := range r.checklists[task.Id] {
+		if item.Checked {
+			task.ChecklistChecked++
+		}
+	}
+
+	return task
+}
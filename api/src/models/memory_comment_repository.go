@@ -0,0 +1,75 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+)
+
+func (r *MemoryTaskRepository) AddComment(ctx context.Context, newComment Comment) (uint, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	newComment.Id = r.nextCommentId
+	newComment.UpdatedAt = time.Time{}
+	newComment.Edits = []CommentEdit{}
+	r.comments[newComment.TaskId] = append(r.comments[newComment.TaskId], newComment)
+	r.nextCommentId++
+
+	return newComment.Id, nil
+}
+
+func (r *MemoryTaskRepository) QueryComments(ctx context.Context, taskId uint) ([]Comment, error) {
+	if err := ctx.Err(); err != nil {
+		return []Comment{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append([]Comment{}, r.comments[taskId]...), nil
+}
+
+func (r *MemoryTaskRepository) UpdateComment(ctx context.Context, taskId uint, commentId uint, body string, editedAt time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	comments := r.comments[taskId]
+	idx := slices.IndexFunc(comments, func(comment Comment) bool { return comment.Id == commentId })
+	if idx < 0 {
+		return sql.ErrNoRows
+	}
+	// Edits are cloned, so the slices returned before and the transaction snapshots are not changed
+	comments[idx].Edits = append(slices.Clone(comments[idx].Edits), CommentEdit{Body: comments[idx].Body, EditedAt: editedAt})
+	comments[idx].Body = body
+	comments[idx].UpdatedAt = editedAt
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) DeleteComment(ctx context.Context, taskId uint, commentId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	comments := r.comments[taskId]
+	idx := slices.IndexFunc(comments, func(comment Comment) bool { return comment.Id == commentId })
+	if idx < 0 {
+		return sql.ErrNoRows
+	}
+	r.comments[taskId] = slices.Delete(comments, idx, idx+1)
+
+	return nil
+}
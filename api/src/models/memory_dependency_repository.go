@@ -0,0 +1,118 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+)
+
+func (r *MemoryTaskRepository) AddDependency(ctx context.Context, taskId uint, dependsOnId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.dependencies[taskId][dependsOnId] {
+		return ErrDependencyExists
+	}
+	if r.reachesTask(dependsOnId, taskId) {
+		return ErrDependencyCycle
+	}
+
+	if r.dependencies[taskId] == nil {
+		r.dependencies[taskId] = map[uint]bool{}
+	}
+	r.dependencies[taskId][dependsOnId] = true
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) DeleteDependency(ctx context.Context, taskId uint, dependsOnId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.dependencies[taskId][dependsOnId] {
+		return sql.ErrNoRows
+	}
+	delete(r.dependencies[taskId], dependsOnId)
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) QueryDependencies(ctx context.Context, taskId uint) ([]uint, error) {
+	if err := ctx.Err(); err != nil {
+		return []uint{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	dependencies := []uint{}
+	for dependsOnId := range r.dependencies[taskId] {
+		if r.inTrash(dependsOnId) {
+			continue
+		}
+		dependencies = append(dependencies, dependsOnId)
+	}
+	slices.Sort(dependencies)
+
+	return dependencies, nil
+}
+
+func (r *MemoryTaskRepository) QueryDependenciesOf(ctx context.Context, taskIds []uint) (map[uint][]uint, error) {
+	if err := ctx.Err(); err != nil {
+		return map[uint][]uint{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	dependencies := map[uint][]uint{}
+	for _, taskId := range taskIds {
+		for dependsOnId := range r.dependencies[taskId] {
+			if r.inTrash(dependsOnId) {
+				continue
+			}
+			dependencies[taskId] = append(dependencies[taskId], dependsOnId)
+		}
+		slices.Sort(dependencies[taskId])
+	}
+
+	return dependencies, nil
+}
+
+// Dependencies on tasks in the trash are kept, but ignored until the task is restored
+func (r *MemoryTaskRepository) inTrash(taskId uint) bool {
+	return !r.tasks[taskId].DeletedAt.IsZero()
+}
+
+// Checks if targetId is reachable from startId following the dependencies
+func (r *MemoryTaskRepository) reachesTask(startId uint, targetId uint) bool {
+	visited := map[uint]bool{}
+	pending := []uint{startId}
+
+	for len(pending) > 0 {
+		current := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+
+		if current == targetId {
+			return true
+		}
+		if visited[current] {
+			continue
+		}
+		visited[current] = true
+
+		for dependsOnId := range r.dependencies[current] {
+			pending = append(pending, dependsOnId)
+		}
+	}
+
+	return false
+}
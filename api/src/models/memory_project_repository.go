@@ -0,0 +1,173 @@
+package models
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"slices"
+)
+
+func (r *MemoryTaskRepository) AddProject(ctx context.Context, newProject Project) (uint, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	newProject.Id = r.nextProjectId
+	r.projects[newProject.Id] = newProject
+	r.nextProjectId++
+
+	return newProject.Id, nil
+}
+
+func (r *MemoryTaskRepository) QueryProject(ctx context.Context, userId uint, projectId uint) (Project, error) {
+	if err := ctx.Err(); err != nil {
+		return Project{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	project, found := r.projects[projectId]
+	project.Role = r.projectRole(projectId, userId)
+	if !found || project.Role == "" {
+		return Project{}, sql.ErrNoRows
+	}
+
+	return project, nil
+}
+
+func (r *MemoryTaskRepository) QueryProjects(ctx context.Context, userId uint, includeArchived bool) ([]Project, error) {
+	if err := ctx.Err(); err != nil {
+		return []Project{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	projects := []Project{}
+	for _, project := range r.projects {
+		project.Role = r.projectRole(project.Id, userId)
+		if project.Role != "" && (includeArchived || !project.Archived) {
+			projects = append(projects, project)
+		}
+	}
+	slices.SortFunc(projects, func(a, b Project) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+
+	return projects, nil
+}
+
+func (r *MemoryTaskRepository) UpdateProject(ctx context.Context, userId uint, updatedProject Project) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	currentProject, found := r.projects[updatedProject.Id]
+	if !found || r.projectRole(updatedProject.Id, userId) == "" {
+		return sql.ErrNoRows
+	}
+
+	// Creation date and owner are not updatable, same as on the SQL repository
+	updatedProject.CreatedAt = currentProject.CreatedAt
+	updatedProject.OwnerId = currentProject.OwnerId
+	updatedProject.Role = ""
+	r.projects[updatedProject.Id] = updatedProject
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) DeleteProject(ctx context.Context, userId uint, projectId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.projectRole(projectId, userId) == "" {
+		return sql.ErrNoRows
+	}
+	delete(r.projects, projectId)
+	delete(r.members, projectId)
+
+	// The tasks stay without project, same as the SQL ON DELETE SET NULL
+	for id, task := range r.tasks {
+		if task.ProjectId == projectId {
+			task.ProjectId = 0
+			r.tasks[id] = task
+		}
+	}
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) SetProjectMember(ctx context.Context, projectId uint, member ProjectMember) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.members[projectId] == nil {
+		r.members[projectId] = map[uint]string{}
+	}
+	r.members[projectId][member.UserId] = member.Role
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) DeleteProjectMember(ctx context.Context, projectId uint, userId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, found := r.members[projectId][userId]; !found {
+		return sql.ErrNoRows
+	}
+	delete(r.members[projectId], userId)
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) QueryProjectMembers(ctx context.Context, projectId uint) ([]ProjectMember, error) {
+	if err := ctx.Err(); err != nil {
+		return []ProjectMember{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	members := []ProjectMember{}
+	for userId, role := range r.members[projectId] {
+		members = append(members, ProjectMember{UserId: userId, Role: role})
+	}
+	slices.SortFunc(members, func(a, b ProjectMember) int {
+		return cmp.Compare(a.UserId, b.UserId)
+	})
+
+	return members, nil
+}
+
+// Role of the user on the project, empty when the project is unknown or not shared with the user
+func (r *MemoryTaskRepository) projectRole(projectId uint, userId uint) string {
+	project, found := r.projects[projectId]
+	if !found {
+		return ""
+	}
+	if project.OwnerId == userId {
+		return RoleOwner
+	}
+
+	return r.members[projectId][userId]
+}
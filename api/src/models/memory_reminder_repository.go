@@ -0,0 +1,147 @@
+package models
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+)
+
+func (r *MemoryTaskRepository) AddReminder(ctx context.Context, newReminder Reminder) (uint, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	newReminder.Id = r.nextReminderId
+	r.reminders[newReminder.Id] = newReminder
+	r.nextReminderId++
+
+	return newReminder.Id, nil
+}
+
+func (r *MemoryTaskRepository) QueryReminders(ctx context.Context, userId uint, taskId uint) ([]Reminder, error) {
+	if err := ctx.Err(); err != nil {
+		return []Reminder{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	reminders := []Reminder{}
+	for _, reminder := range r.reminders {
+		if reminder.TaskId == taskId && reminder.UserId == userId {
+			reminders = append(reminders, r.withFireAt(reminder))
+		}
+	}
+	slices.SortFunc(reminders, func(a, b Reminder) int { return cmp.Compare(a.Id, b.Id) })
+
+	return reminders, nil
+}
+
+func (r *MemoryTaskRepository) DeleteReminder(ctx context.Context, userId uint, taskId uint, reminderId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reminder, found := r.reminders[reminderId]
+	if !found || reminder.TaskId != taskId || reminder.UserId != userId {
+		return sql.ErrNoRows
+	}
+	delete(r.reminders, reminderId)
+
+	return nil
+}
+
+// Unlike the SQL query the reminder is not locked, the memory transactions not being isolated
+func (r *MemoryTaskRepository) ClaimDueReminder(ctx context.Context, now time.Time, maxAttempts uint) (Reminder, error) {
+	if err := ctx.Err(); err != nil {
+		return Reminder{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var claimed Reminder
+	for _, reminder := range r.reminders {
+		reminder = r.withFireAt(reminder)
+		task, found := r.tasks[reminder.TaskId]
+		due := reminder.SentAt.IsZero() && reminder.Attempts < maxAttempts && !reminder.RetryAt.After(now) &&
+			found && task.DeletedAt.IsZero() && !reminder.FireAt.IsZero() && !reminder.FireAt.After(now)
+		if due && (claimed.Id == 0 || reminder.FireAt.Before(claimed.FireAt) || (reminder.FireAt.Equal(claimed.FireAt) && reminder.Id < claimed.Id)) {
+			claimed = reminder
+		}
+	}
+	if claimed.Id == 0 {
+		return Reminder{}, sql.ErrNoRows
+	}
+
+	return claimed, nil
+}
+
+func (r *MemoryTaskRepository) LeaseReminder(ctx context.Context, reminderId uint, until time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if reminder, found := r.reminders[reminderId]; found {
+		reminder.RetryAt = until
+		r.reminders[reminderId] = reminder
+	}
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) MarkReminderSent(ctx context.Context, reminderId uint, sentAt time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if reminder, found := r.reminders[reminderId]; found {
+		reminder.SentAt = sentAt
+		r.reminders[reminderId] = reminder
+	}
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) MarkReminderFailed(ctx context.Context, reminderId uint, lastError string, retryAt time.Time) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if reminder, found := r.reminders[reminderId]; found {
+		reminder.Attempts++
+		reminder.LastError = lastError
+		reminder.RetryAt = retryAt
+		r.reminders[reminderId] = reminder
+	}
+
+	return nil
+}
+
+// Same rule as the SQL reminderFireAtColumn
+func (r *MemoryTaskRepository) withFireAt(reminder Reminder) Reminder {
+	if !reminder.RemindAt.IsZero() {
+		reminder.FireAt = reminder.RemindAt
+	} else if dueDate := r.tasks[reminder.TaskId].DueDate; !dueDate.IsZero() {
+		reminder.FireAt = dueDate.Add(-reminder.BeforeDue)
+	}
+
+	return reminder
+}
@@ -0,0 +1,74 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+func (r *MemoryTaskRepository) AddSeries(ctx context.Context, newSeries TaskSeries) (uint, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	newSeries.Id = r.nextSeriesId
+	r.series[newSeries.Id] = newSeries
+	r.nextSeriesId++
+
+	return newSeries.Id, nil
+}
+
+func (r *MemoryTaskRepository) QuerySeries(ctx context.Context, seriesId uint) (TaskSeries, error) {
+	if err := ctx.Err(); err != nil {
+		return TaskSeries{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	series, found := r.series[seriesId]
+	if !found {
+		return TaskSeries{}, sql.ErrNoRows
+	}
+
+	return series, nil
+}
+
+func (r *MemoryTaskRepository) QuerySeriesTasks(ctx context.Context, userId uint, seriesId uint) ([]Task, error) {
+	if err := ctx.Err(); err != nil {
+		return []Task{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tasks := []Task{}
+	for _, task := range r.tasks {
+		if task.SeriesId == seriesId && r.isActive(task, userId) {
+			tasks = append(tasks, r.withComputedColumns(task))
+		}
+	}
+	return sortedById(tasks), nil
+}
+
+func (r *MemoryTaskRepository) AdvanceSeries(ctx context.Context, seriesId uint, latestTaskId uint, nextTaskId uint, nextDue time.Time) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	series, found := r.series[seriesId]
+	if !found || series.LatestTaskId != latestTaskId {
+		return false, nil
+	}
+	series.LatestTaskId = nextTaskId
+	series.LatestDue = nextDue
+	r.series[seriesId] = series
+
+	return true, nil
+}
@@ -0,0 +1,85 @@
+package models
+
+import (
+	"cmp"
+	"context"
+	"slices"
+)
+
+func (r *MemoryTaskRepository) QuerySubtasks(ctx context.Context, userId uint, taskId uint) ([]Task, error) {
+	if err := ctx.Err(); err != nil {
+		return []Task{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	subtasks := []Task{}
+	for _, task := range r.tasks {
+		if task.ParentId == taskId && r.isActive(task, userId) {
+			subtasks = append(subtasks, r.withComputedColumns(task))
+		}
+	}
+
+	return sortedById(subtasks), nil
+}
+
+func (r *MemoryTaskRepository) QueryDescendants(ctx context.Context, userId uint, taskId uint) ([]Task, error) {
+	if err := ctx.Err(); err != nil {
+		return []Task{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	// Same as the SQL query, the subtasks the user can not access are walked through but not returned
+	descendants := []Task{}
+	reached := map[uint]bool{taskId: true}
+	pending := []uint{taskId}
+	for len(pending) > 0 {
+		parentId := pending[0]
+		pending = pending[1:]
+		for _, task := range r.tasks {
+			if task.ParentId != parentId || !task.DeletedAt.IsZero() || reached[task.Id] {
+				continue
+			}
+			reached[task.Id] = true
+			pending = append(pending, task.Id)
+			if r.canAccess(task, userId) {
+				descendants = append(descendants, r.withComputedColumns(task))
+			}
+		}
+	}
+
+	return sortedById(descendants), nil
+}
+
+func (r *MemoryTaskRepository) QueryAncestorIds(ctx context.Context, taskId uint) ([]uint, error) {
+	if err := ctx.Err(); err != nil {
+		return []uint{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	ancestorIds := []uint{}
+	reached := map[uint]bool{taskId: true}
+	for parentId := r.tasks[taskId].ParentId; parentId != 0 && !reached[parentId]; parentId = r.tasks[parentId].ParentId {
+		reached[parentId] = true
+		ancestorIds = append(ancestorIds, parentId)
+	}
+
+	return ancestorIds, nil
+}
+
+// The memory transactions are not isolated, so there is nothing to lock
+func (r *MemoryTaskRepository) LockParents(ctx context.Context) error {
+	return ctx.Err()
+}
+
+func sortedById(tasks []Task) []Task {
+	slices.SortFunc(tasks, func(a, b Task) int {
+		return cmp.Compare(a.Id, b.Id)
+	})
+	return tasks
+}
@@ -0,0 +1,156 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"slices"
+	"strings"
+)
+
+func (r *MemoryTaskRepository) AddTag(ctx context.Context, newTag Tag) (uint, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.tagNameTaken(newTag) {
+		return 0, ErrTagExists
+	}
+	newTag.Id = r.nextTagId
+	r.tags[newTag.Id] = newTag
+	r.nextTagId++
+
+	return newTag.Id, nil
+}
+
+func (r *MemoryTaskRepository) QueryTag(ctx context.Context, userId uint, tagId uint) (Tag, error) {
+	if err := ctx.Err(); err != nil {
+		return Tag{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tag, found := r.tags[tagId]
+	if !found || tag.OwnerId != userId {
+		return Tag{}, sql.ErrNoRows
+	}
+
+	return tag, nil
+}
+
+func (r *MemoryTaskRepository) QueryTags(ctx context.Context, userId uint) ([]Tag, error) {
+	if err := ctx.Err(); err != nil {
+		return []Tag{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tags := []Tag{}
+	for _, tag := range r.tags {
+		if tag.OwnerId == userId {
+			tags = append(tags, tag)
+		}
+	}
+	slices.SortFunc(tags, func(a, b Tag) int { return strings.Compare(a.Name, b.Name) })
+
+	return tags, nil
+}
+
+func (r *MemoryTaskRepository) UpdateTag(ctx context.Context, updatedTag Tag) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tag, found := r.tags[updatedTag.Id]
+	if !found || tag.OwnerId != updatedTag.OwnerId {
+		return sql.ErrNoRows
+	}
+	if r.tagNameTaken(updatedTag) {
+		return ErrTagExists
+	}
+	r.tags[updatedTag.Id] = updatedTag
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) DeleteTag(ctx context.Context, userId uint, tagId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	tag, found := r.tags[tagId]
+	if !found || tag.OwnerId != userId {
+		return sql.ErrNoRows
+	}
+	delete(r.tags, tagId)
+	for _, tagIds := range r.taskTags {
+		delete(tagIds, tagId)
+	}
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) AddTaskTag(ctx context.Context, taskId uint, tagId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.taskTags[taskId] == nil {
+		r.taskTags[taskId] = map[uint]bool{}
+	}
+	r.taskTags[taskId][tagId] = true
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) DeleteTaskTag(ctx context.Context, taskId uint, tagId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.taskTags[taskId][tagId] {
+		return sql.ErrNoRows
+	}
+	delete(r.taskTags[taskId], tagId)
+
+	return nil
+}
+
+// Another tag of the owner has the name of the tag, same as the SQL unique constraint
+func (r *MemoryTaskRepository) tagNameTaken(tag Tag) bool {
+	for _, stored := range r.tags {
+		if stored.Id != tag.Id && stored.OwnerId == tag.OwnerId && stored.Name == tag.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// Task with the names of its tags in alphabetical order, as read from the SQL taskColumns
+func (r *MemoryTaskRepository) withTagNames(task Task) Task {
+	task.Tags = []string{}
+	for tagId := range r.taskTags[task.Id] {
+		if name := r.tags[tagId].Name; !slices.Contains(task.Tags, name) {
+			task.Tags = append(task.Tags, name)
+		}
+	}
+	slices.Sort(task.Tags)
+
+	return task
+}
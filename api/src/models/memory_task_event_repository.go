@@ -0,0 +1,35 @@
+package models
+
+import "context"
+
+func (r *MemoryTaskRepository) AddTaskEvent(ctx context.Context, event TaskEvent) (uint, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	event.Id = uint(len(r.events)) + 1
+	r.events = append(r.events, event)
+
+	return event.Id, nil
+}
+
+func (r *MemoryTaskRepository) QueryTaskEvents(ctx context.Context, taskId uint) ([]TaskEvent, error) {
+	if err := ctx.Err(); err != nil {
+		return []TaskEvent{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	events := []TaskEvent{}
+	for _, event := range r.events {
+		if event.TaskId == taskId {
+			events = append(events, event)
+		}
+	}
+
+	return events, nil
+}
@@ -0,0 +1,355 @@
+package models
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MemoryTaskRepository keeps tasks in memory. It is meant for demos and tests,
+// all data is lost when the process stops.
+type MemoryTaskRepository struct {
+	mu sync.RWMutex
+	memoryState
+}
+
+// Data of the repository, copied to undo the changes of a failed transaction
+type memoryState struct {
+	tasks        map[uint]Task
+	dependencies map[uint]map[uint]bool // task id -> ids of the tasks it depends on
+	nextId       uint
+
+	projects      map[uint]Project
+	members       map[uint]map[uint]string // project id -> user id -> role
+	nextProjectId uint
+
+	events []TaskEvent // history of the tasks, kept after they are deleted
+
+	checklists          map[uint][]ChecklistItem // task id -> items sorted by position
+	nextChecklistItemId uint
+
+	tags      map[uint]Tag
+	taskTags  map[uint]map[uint]bool // task id -> ids of the tags labeling it
+	nextTagId uint
+
+	comments      map[uint][]Comment // task id -> comments, oldest first
+	nextCommentId uint
+
+	attachments      map[uint][]Attachment // task id -> attachments, oldest first
+	nextAttachmentId uint
+
+	series       map[uint]TaskSeries
+	nextSeriesId uint
+
+	reminders      map[uint]Reminder
+	nextReminderId uint
+}
+
+func NewMemoryTaskRepository() *MemoryTaskRepository {
+	return &MemoryTaskRepository{memoryState: memoryState{
+		tasks:               map[uint]Task{},
+		dependencies:        map[uint]map[uint]bool{},
+		nextId:              1,
+		projects:            map[uint]Project{},
+		members:             map[uint]map[uint]string{},
+		nextProjectId:       1,
+		checklists:          map[uint][]ChecklistItem{},
+		nextChecklistItemId: 1,
+		tags:                map[uint]Tag{},
+		taskTags:            map[uint]map[uint]bool{},
+		nextTagId:           1,
+		comments:            map[uint][]Comment{},
+		nextCommentId:       1,
+		attachments:         map[uint][]Attachment{},
+		nextAttachmentId:    1,
+		series:              map[uint]TaskSeries{},
+		nextSeriesId:        1,
+		reminders:           map[uint]Reminder{},
+		nextReminderId:      1,
+	}}
+}
+
+// Deep copy of the state, so later changes do not alter it
+func (s memoryState) copyState() memoryState {
+	copied := s
+	copied.tasks = maps.Clone(s.tasks)
+	copied.dependencies = map[uint]map[uint]bool{}
+	for taskId, dependsOn := range s.dependencies {
+		copied.dependencies[taskId] = maps.Clone(dependsOn)
+	}
+	copied.projects = maps.Clone(s.projects)
+	copied.members = map[uint]map[uint]string{}
+	for projectId, members := range s.members {
+		copied.members[projectId] = maps.Clone(members)
+	}
+	copied.events = slices.Clone(s.events)
+	copied.checklists = map[uint][]ChecklistItem{}
+	for taskId, checklist := range s.checklists {
+		copied.checklists[taskId] = slices.Clone(checklist)
+	}
+	copied.tags = maps.Clone(s.tags)
+	copied.taskTags = map[uint]map[uint]bool{}
+	for taskId, tagIds := range s.taskTags {
+		copied.taskTags[taskId] = maps.Clone(tagIds)
+	}
+	copied.comments = map[uint][]Comment{}
+	for taskId, comments := range s.comments {
+		copied.comments[taskId] = slices.Clone(comments)
+	}
+	copied.attachments = map[uint][]Attachment{}
+	for taskId, attachments := range s.attachments {
+		copied.attachments[taskId] = slices.Clone(attachments)
+	}
+	copied.series = maps.Clone(s.series)
+	copied.reminders = maps.Clone(s.reminders)
+
+	return copied
+}
+
+func (r *MemoryTaskRepository) restoreState(snapshot memoryState) {
+	r.memoryState = snapshot
+}
+
+func (r *MemoryTaskRepository) AddTask(ctx context.Context, newTask Task) (uint, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	newTask.Id = r.nextId
+	newTask.Version = 1
+	r.tasks[newTask.Id] = newTask
+	r.nextId++
+
+	return newTask.Id, nil
+}
+
+func (r *MemoryTaskRepository) QueryTask(ctx context.Context, userId uint, taskId uint) (Task, error) {
+	if err := ctx.Err(); err != nil {
+		return Task{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	task, found := r.tasks[taskId]
+	if !found || !r.isActive(task, userId) {
+		return Task{}, sql.ErrNoRows
+	}
+
+	return r.withComputedColumns(task), nil
+}
+
+func (r *MemoryTaskRepository) UpdateTask(ctx context.Context, userId uint, updatedTask Task) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	currentTask, found := r.tasks[updatedTask.Id]
+	if !found || !r.isActive(currentTask, userId) || currentTask.Version != updatedTask.Version {
+		return ErrVersionConflict
+	}
+
+	// Creation date and owner are not updatable, same as on the SQL repository
+	updatedTask.CreatedAt = currentTask.CreatedAt
+	updatedTask.OwnerId = currentTask.OwnerId
+	updatedTask.DeletedAt = currentTask.DeletedAt
+	updatedTask.Version++
+	r.tasks[updatedTask.Id] = updatedTask
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) DeleteTask(ctx context.Context, userId uint, taskId uint, version uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, found := r.tasks[taskId]
+	if !found || !r.isActive(task, userId) || task.Version != version {
+		return ErrVersionConflict
+	}
+
+	// The task is moved to the trash, its dependencies are kept until it is purged
+	task.DeletedAt = transactionTime(ctx)
+	task.Version++
+	r.tasks[taskId] = task
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) CheckExistence(ctx context.Context, userId uint, taskId uint) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	task, found := r.tasks[taskId]
+
+	return found && r.isActive(task, userId), nil
+}
+
+func (r *MemoryTaskRepository) QueryTasks(ctx context.Context, userId uint, filterConfig []TasksFilterQuery, pageConfig TasksPaginationQuery) ([]Task, error) {
+	if err := ctx.Err(); err != nil {
+		return []Task{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tasks := []Task{}
+	for _, task := range r.tasks {
+		task = r.withComputedColumns(task)
+		if r.isActive(task, userId) && matchFilters(task, filterConfig) {
+			tasks = append(tasks, task)
+		}
+	}
+
+	descending := strings.EqualFold(pageConfig.SortOrder, "desc")
+	slices.SortStableFunc(tasks, func(a, b Task) int {
+		result := CompareTaskColumn(a, b, pageConfig.SortBy)
+		if result == 0 {
+			result = cmp.Compare(a.Id, b.Id)
+		} else if descending {
+			result = -result
+		}
+		return result
+	})
+
+	if pageConfig.Offset >= uint(len(tasks)) {
+		return []Task{}, nil
+	}
+	end := min(pageConfig.Offset+pageConfig.Limit, uint(len(tasks)))
+
+	return tasks[pageConfig.Offset:end], nil
+}
+
+func (r *MemoryTaskRepository) GetAmountOfTasks(ctx context.Context, userId uint) (uint, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tasksAmount := uint(0)
+	for _, task := range r.tasks {
+		if r.isActive(task, userId) {
+			tasksAmount++
+		}
+	}
+
+	return tasksAmount, nil
+}
+
+// Same rule as the SQL activeTaskCondition: the task is not in the trash and the user can access it
+func (r *MemoryTaskRepository) isActive(task Task, userId uint) bool {
+	return task.DeletedAt.IsZero() && r.canAccess(task, userId)
+}
+
+// Same rule as the SQL taskAccessCondition: the user created the task or can access its project
+func (r *MemoryTaskRepository) canAccess(task Task, userId uint) bool {
+	return task.OwnerId == userId || (task.ProjectId != 0 && r.projectRole(task.ProjectId, userId) != "")
+}
+
+func matchFilters(task Task, filterConfig []TasksFilterQuery) bool {
+	for _, filter := range filterConfig {
+		value := taskColumnValue(task, filter.Column)
+
+		switch filter.Match {
+		case MatchContains:
+			if !strings.Contains(value, strings.Trim(filter.Value, "%")) {
+				return false
+			}
+		case MatchAny, MatchAll:
+			values := taskColumnValues(task, filter.Column)
+			contained := func(searched string) bool { return slices.Contains(values, searched) }
+			searched := strings.Split(filter.Value, ",")
+			if filter.Match == MatchAny && !slices.ContainsFunc(searched, contained) {
+				return false
+			}
+			if filter.Match == MatchAll && slices.ContainsFunc(searched, func(name string) bool { return !contained(name) }) {
+				return false
+			}
+		default:
+			if value != filter.Value {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+func taskColumnValue(task Task, column string) string {
+	switch column {
+	case "id":
+		return fmt.Sprint(task.Id)
+	case "title":
+		return task.Title
+	case "description":
+		return task.Description
+	case "status":
+		return task.Status
+	case "priority":
+		return fmt.Sprint(task.Priority)
+	case "project_id":
+		return fmt.Sprint(task.ProjectId)
+	default:
+		return ""
+	}
+}
+
+// Values of the list columns, matched by MatchAny and MatchAll
+func taskColumnValues(task Task, column string) []string {
+	switch column {
+	case "tags":
+		return task.Tags
+	default:
+		return []string{}
+	}
+}
+
+// Task with the columns computed from the other tables, as read from the SQL taskColumns
+func (r *MemoryTaskRepository) withComputedColumns(task Task) Task {
+	task = r.withTagNames(r.withChecklistCounts(task))
+	task.CommentCount = uint(len(r.comments[task.Id]))
+	task.Recurrence = r.series[task.SeriesId].Rule
+
+	return task
+}
+
+// CompareTaskColumn compares two tasks by one of the sortable columns, by id when the column is unknown
+func CompareTaskColumn(a Task, b Task, column string) int {
+	switch strings.ToLower(column) {
+	case "title":
+		return strings.Compare(a.Title, b.Title)
+	case "description":
+		return strings.Compare(a.Description, b.Description)
+	case "status":
+		return strings.Compare(a.Status, b.Status)
+	case "priority":
+		return cmp.Compare(a.Priority, b.Priority)
+	case "created_at":
+		return a.CreatedAt.Compare(b.CreatedAt)
+	case "due_date":
+		return a.DueDate.Compare(b.DueDate)
+	default:
+		return cmp.Compare(a.Id, b.Id)
+	}
+}
@@ -0,0 +1,188 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestMemoryRepository() *MemoryTaskRepository {
+	repository := NewMemoryTaskRepository()
+	for _, task := range getTestTasksList() {
+		repository.AddTask(context.Background(), task)
+	}
+	return repository
+}
+
+// Memory Single Tasks Tests ///////////////////////////////////
+func TestMemoryAddAndQueryTask(t *testing.T) {
+	repository := NewMemoryTaskRepository()
+	testTask := getTestTasksList()[0]
+
+	taskId, err := repository.AddTask(context.Background(), testTask)
+	assert.NoError(t, err, "Unexpected error adding task")
+	assert.Equal(t, uint(1), taskId, "First task should have Id 1")
+
+	queriedTask, err := repository.QueryTask(context.Background(), testOwnerId, taskId)
+	assert.NoError(t, err, "Unexpected error querying task")
+	assert.Equal(t, testTask.Title, queriedTask.Title, "Returned Title should match added task")
+	assert.Equal(t, testTask.DueDate, queriedTask.DueDate, "Returned DueDate should match added task")
+}
+
+func TestMemoryQueryTaskNotFound(t *testing.T) {
+	repository := NewMemoryTaskRepository()
+
+	_, err := repository.QueryTask(context.Background(), testOwnerId, 10)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Should return ErrNoRows for inexistent task")
+}
+
+func TestMemoryUpdateTask(t *testing.T) {
+	repository := getTestMemoryRepository()
+
+	updatedTask := getTestTasksList()[0]
+	updatedTask.Title = "Updated Title"
+	updatedTask.CreatedAt = updatedTask.CreatedAt.AddDate(1, 0, 0)
+	updatedTask.Version = 1
+
+	err := repository.UpdateTask(context.Background(), testOwnerId, updatedTask)
+	assert.NoError(t, err, "Unexpected error updating task")
+
+	queriedTask, _ := repository.QueryTask(context.Background(), testOwnerId, updatedTask.Id)
+	assert.Equal(t, "Updated Title", queriedTask.Title, "Title should be updated")
+	assert.Equal(t, getTestTasksList()[0].CreatedAt, queriedTask.CreatedAt, "CreatedAt should not be updated")
+	assert.Equal(t, uint(2), queriedTask.Version, "Version should be incremented")
+}
+
+func TestMemoryUpdateTaskStaleVersion(t *testing.T) {
+	repository := getTestMemoryRepository()
+
+	updatedTask := getTestTasksList()[0]
+	updatedTask.Version = 1
+	repository.UpdateTask(context.Background(), testOwnerId, updatedTask)
+
+	updatedTask.Title = "Stale Title"
+	err := repository.UpdateTask(context.Background(), testOwnerId, updatedTask)
+	assert.ErrorIs(t, err, ErrVersionConflict, "Update based on a stale version should be rejected")
+
+	err = repository.DeleteTask(context.Background(), testOwnerId, 1, 1)
+	assert.ErrorIs(t, err, ErrVersionConflict, "Deletion based on a stale version should be rejected")
+
+	queriedTask, _ := repository.QueryTask(context.Background(), testOwnerId, 1)
+	assert.Equal(t, getTestTasksList()[0].Title, queriedTask.Title, "Stale update should not be applied")
+}
+
+func TestMemoryDeleteTask(t *testing.T) {
+	repository := getTestMemoryRepository()
+
+	err := repository.DeleteTask(context.Background(), testOwnerId, 1, 1)
+	assert.NoError(t, err, "Unexpected error deleting task")
+
+	exists, err := repository.CheckExistence(context.Background(), testOwnerId, 1)
+	assert.NoError(t, err, "Unexpected error checking existence")
+	assert.False(t, exists, "Task should not exist after deletion")
+
+	exists, _ = repository.CheckExistence(context.Background(), testOwnerId, 2)
+	assert.True(t, exists, "Other tasks should not be deleted")
+}
+
+// Memory Multi Tasks Tests ///////////////////////////////////
+func TestMemoryQueryTasksStatusFilterPrioSorted(t *testing.T) {
+	repository := getTestMemoryRepository()
+
+	pagConfig := TasksPaginationQuery{Offset: 0, SortBy: "priority", SortOrder: "ASC", Limit: 10}
+	filterConfig := []TasksFilterQuery{
+		{Query: "status = $1", Value: "pending", Column: "status", Match: MatchExact},
+	}
+
+	queriedTasks, err := repository.QueryTasks(context.Background(), testOwnerId, filterConfig, pagConfig)
+
+	assert.NoError(t, err, "Unexpected error querying tasks")
+	assert.Equal(t, 2, len(queriedTasks), "Returned list should have 2 elements")
+	assert.Equal(t, uint(1), queriedTasks[0].Id, "First Id should be from Task 1")
+	assert.Equal(t, uint(3), queriedTasks[1].Id, "Second Id should be from Task 3")
+}
+
+func TestMemoryQueryTasksTitleFilterDueDateSortedDESC(t *testing.T) {
+	repository := getTestMemoryRepository()
+
+	pagConfig := TasksPaginationQuery{Offset: 0, SortBy: "due_date", SortOrder: "desc", Limit: 10}
+	filterConfig := []TasksFilterQuery{
+		{Query: "title LIKE $1", Value: "%Task%", Column: "title", Match: MatchContains},
+	}
+
+	queriedTasks, err := repository.QueryTasks(context.Background(), testOwnerId, filterConfig, pagConfig)
+
+	assert.NoError(t, err, "Unexpected error querying tasks")
+	assert.Equal(t, 3, len(queriedTasks), "Returned list should have 3 elements")
+	assert.Equal(t, uint(2), queriedTasks[0].Id, "First Id should be from Task 2")
+	assert.Equal(t, uint(1), queriedTasks[1].Id, "Second Id should be from Task 1")
+	assert.Equal(t, uint(3), queriedTasks[2].Id, "Third Id should be from Task 3")
+}
+
+func TestMemoryQueryTasksPagination(t *testing.T) {
+	repository := getTestMemoryRepository()
+
+	pagConfig := TasksPaginationQuery{Offset: 1, SortBy: "id", SortOrder: "ASC", Limit: 1}
+	queriedTasks, err := repository.QueryTasks(context.Background(), testOwnerId, []TasksFilterQuery{}, pagConfig)
+
+	assert.NoError(t, err, "Unexpected error querying tasks")
+	assert.Equal(t, 1, len(queriedTasks), "Returned list should have 1 element")
+	assert.Equal(t, uint(2), queriedTasks[0].Id, "Returned Id should be from Task 2")
+
+	pagConfig.Offset = 5
+	queriedTasks, err = repository.QueryTasks(context.Background(), testOwnerId, []TasksFilterQuery{}, pagConfig)
+
+	assert.NoError(t, err, "Unexpected error querying tasks")
+	assert.Empty(t, queriedTasks, "Offset beyond the tasks amount should return empty list")
+}
+
+func TestMemoryGetAmountOfTasks(t *testing.T) {
+	repository := getTestMemoryRepository()
+
+	tasksAmount, err := repository.GetAmountOfTasks(context.Background(), testOwnerId)
+
+	assert.NoError(t, err, "Unexpected error counting tasks")
+	assert.Equal(t, uint(3), tasksAmount, "Returned wrong tasks amount")
+}
+
+func TestMemoryCanceledContext(t *testing.T) {
+	repository := getTestMemoryRepository()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := repository.QueryTask(ctx, testOwnerId, 1)
+	assert.ErrorIs(t, err, context.Canceled, "Should return context error when canceled")
+
+	_, err = repository.QueryTasks(ctx, testOwnerId, []TasksFilterQuery{}, TasksPaginationQuery{SortBy: "id", Limit: 10})
+	assert.ErrorIs(t, err, context.Canceled, "Should return context error when canceled")
+}
+
+func TestMemoryOtherUserTasks(t *testing.T) {
+	repository := getTestMemoryRepository()
+	otherUserId := testOwnerId + 1
+
+	_, err := repository.QueryTask(context.Background(), otherUserId, 1)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Tasks of other users should not be found")
+
+	exists, _ := repository.CheckExistence(context.Background(), otherUserId, 1)
+	assert.False(t, exists, "Tasks of other users should not exist")
+
+	updatedTask := getTestTasksList()[0]
+	updatedTask.Title = "Updated Title"
+	updatedTask.Version = 1
+	repository.UpdateTask(context.Background(), otherUserId, updatedTask)
+	repository.DeleteTask(context.Background(), otherUserId, 2, 1)
+
+	queriedTask, _ := repository.QueryTask(context.Background(), testOwnerId, 1)
+	assert.Equal(t, getTestTasksList()[0].Title, queriedTask.Title, "Other users should not update the task")
+	exists, _ = repository.CheckExistence(context.Background(), testOwnerId, 2)
+	assert.True(t, exists, "Other users should not delete the task")
+
+	tasks, _ := repository.QueryTasks(context.Background(), otherUserId, []TasksFilterQuery{}, TasksPaginationQuery{SortBy: "id", Limit: 10})
+	assert.Empty(t, tasks, "Tasks of other users should not be listed")
+	tasksAmount, _ := repository.GetAmountOfTasks(context.Background(), otherUserId)
+	assert.Equal(t, uint(0), tasksAmount, "Tasks of other users should not be counted")
+}
@@ -0,0 +1,127 @@
+package models
+
+import (
+	"cmp"
+	"context"
+	"database/sql"
+	"slices"
+	"time"
+)
+
+func (r *MemoryTaskRepository) QueryDeletedTask(ctx context.Context, userId uint, taskId uint) (Task, error) {
+	if err := ctx.Err(); err != nil {
+		return Task{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	task, found := r.tasks[taskId]
+	if !found || !r.isDeleted(task, userId) {
+		return Task{}, sql.ErrNoRows
+	}
+
+	return r.withComputedColumns(task), nil
+}
+
+func (r *MemoryTaskRepository) QueryDeletedTasks(ctx context.Context, userId uint) ([]Task, error) {
+	if err := ctx.Err(); err != nil {
+		return []Task{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	tasks := []Task{}
+	for _, task := range r.tasks {
+		if r.isDeleted(task, userId) {
+			tasks = append(tasks, r.withComputedColumns(task))
+		}
+	}
+
+	slices.SortFunc(tasks, func(a, b Task) int {
+		if result := b.DeletedAt.Compare(a.DeletedAt); result != 0 {
+			return result
+		}
+		return cmp.Compare(a.Id, b.Id)
+	})
+
+	return tasks, nil
+}
+
+func (r *MemoryTaskRepository) RestoreTask(ctx context.Context, userId uint, taskId uint) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	task, found := r.tasks[taskId]
+	if !found || !r.isDeleted(task, userId) {
+		return sql.ErrNoRows
+	}
+	task.DeletedAt = time.Time{}
+	task.Version++
+	r.tasks[taskId] = task
+
+	return nil
+}
+
+func (r *MemoryTaskRepository) PurgeDeletedTasks(ctx context.Context, deletedBefore time.Time) (uint, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	purged := uint(0)
+	for taskId, task := range r.tasks {
+		if task.DeletedAt.IsZero() || !task.DeletedAt.Before(deletedBefore) {
+			continue
+		}
+		delete(r.tasks, taskId)
+
+		// Dependencies, checklist, tags, comments and attachments are removed together with the task, same as the SQL cascade
+		delete(r.dependencies, taskId)
+		delete(r.checklists, taskId)
+		delete(r.taskTags, taskId)
+		delete(r.comments, taskId)
+		delete(r.attachments, taskId)
+		for _, dependsOn := range r.dependencies {
+			delete(dependsOn, taskId)
+		}
+		purged++
+	}
+
+	// Reminders are removed together with their task, same as the SQL cascade
+	for reminderId, reminder := range r.reminders {
+		if _, found := r.tasks[reminder.TaskId]; !found {
+			delete(r.reminders, reminderId)
+		}
+	}
+
+	// Series of the purged tasks no longer generate occurrences, same as the SQL ON DELETE SET NULL
+	for seriesId, series := range r.series {
+		if _, found := r.tasks[series.LatestTaskId]; series.LatestTaskId != 0 && !found {
+			series.LatestTaskId = 0
+			r.series[seriesId] = series
+		}
+	}
+
+	// Subtasks of the purged tasks become top level tasks, same as the SQL ON DELETE SET NULL
+	for taskId, task := range r.tasks {
+		if _, found := r.tasks[task.ParentId]; task.ParentId != 0 && !found {
+			task.ParentId = 0
+			r.tasks[taskId] = task
+		}
+	}
+
+	return purged, nil
+}
+
+// Same rule as the SQL deletedTaskCondition: the task is in the trash and the user can access it
+func (r *MemoryTaskRepository) isDeleted(task Task, userId uint) bool {
+	return !task.DeletedAt.IsZero() && r.canAccess(task, userId)
+}
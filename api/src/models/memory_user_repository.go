@@ -0,0 +1,72 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+)
+
+// MemoryUserRepository keeps users in memory, all data is lost when the process stops
+type MemoryUserRepository struct {
+	mu     sync.RWMutex
+	users  map[uint]User
+	nextId uint
+}
+
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{users: map[uint]User{}, nextId: 1}
+}
+
+func (r *MemoryUserRepository) AddUser(ctx context.Context, newUser User) (uint, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, user := range r.users {
+		if user.Username == newUser.Username {
+			return 0, ErrUserExists
+		}
+	}
+
+	newUser.Id = r.nextId
+	r.users[newUser.Id] = newUser
+	r.nextId++
+
+	return newUser.Id, nil
+}
+
+func (r *MemoryUserRepository) QueryUser(ctx context.Context, userId uint) (User, error) {
+	if err := ctx.Err(); err != nil {
+		return User{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	user, found := r.users[userId]
+	if !found {
+		return User{}, sql.ErrNoRows
+	}
+
+	return user, nil
+}
+
+func (r *MemoryUserRepository) QueryUserByUsername(ctx context.Context, username string) (User, error) {
+	if err := ctx.Err(); err != nil {
+		return User{}, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, user := range r.users {
+		if user.Username == username {
+			return user, nil
+		}
+	}
+
+	return User{}, sql.ErrNoRows
+}
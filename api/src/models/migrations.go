@@ -0,0 +1,274 @@
+package models
+
+import (
+	"cmp"
+	"context"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Key of the advisory lock held while the migrations are applied, so several
+// API instances starting together do not migrate the database concurrently
+const migrationLockKey = 707370
+
+const createMigrationsTableQuery = `
+	CREATE TABLE IF NOT EXISTS schema_migrations (
+		version BIGINT PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	);
+`
+
+// Migration files are named <version>_<name>.up.sql and <version>_<name>.down.sql
+var migrationFileRegex = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+type Migration struct {
+	Version uint
+	Name    string
+	Up      string
+	Down    string
+}
+
+type MigrationStatus struct {
+	Version   uint
+	Name      string
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// LoadMigrations returns the embedded migrations sorted by version
+func LoadMigrations() ([]Migration, error) {
+	return loadMigrations(migrationFiles, "migrations")
+}
+
+func loadMigrations(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	migrationsByVersion := map[uint]*Migration{}
+	for _, entry := range entries {
+		match := migrationFileRegex.FindStringSubmatch(entry.Name())
+		if entry.IsDir() || match == nil {
+			return nil, fmt.Errorf("invalid migration file name '%s'", entry.Name())
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil || version == 0 {
+			return nil, fmt.Errorf("invalid migration version on '%s'", entry.Name())
+		}
+
+		content, err := fs.ReadFile(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+
+		migration, exist := migrationsByVersion[uint(version)]
+		if !exist {
+			migration = &Migration{Version: uint(version), Name: match[2]}
+			migrationsByVersion[uint(version)] = migration
+		} else if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration version %d used by '%s' and '%s'", version, migration.Name, match[2])
+		}
+
+		if match[3] == "up" {
+			migration.Up = string(content)
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	migrations := []Migration{}
+	for _, migration := range migrationsByVersion {
+		if migration.Up == "" {
+			return nil, fmt.Errorf("missing up script for migration %d_%s", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+	slices.SortFunc(migrations, func(a, b Migration) int { return cmp.Compare(a.Version, b.Version) })
+
+	return migrations, nil
+}
+
+// MigrateUp applies all the pending migrations, returning the applied ones
+func MigrateUp(db Database) ([]Migration, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	return applyMigrations(db, migrations)
+}
+
+// MigrateDown reverts the last applied migrations, returning the reverted ones
+func MigrateDown(db Database, steps uint) ([]Migration, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	return revertMigrations(db, migrations, steps)
+}
+
+// GetMigrationsStatus lists all the known migrations and whether they were applied
+func GetMigrationsStatus(db Database) ([]MigrationStatus, error) {
+	migrations, err := LoadMigrations()
+	if err != nil {
+		return nil, err
+	}
+
+	return migrationsStatus(db, migrations)
+}
+
+func applyMigrations(db Database, migrations []Migration) ([]Migration, error) {
+	appliedNow := []Migration{}
+
+	err := runLockedMigration(db, func(ctx context.Context, tx pgx.Tx) error {
+		applied, err := queryAppliedMigrations(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			if _, done := applied[migration.Version]; done {
+				continue
+			}
+
+			if _, err = tx.Exec(ctx, migration.Up); err != nil {
+				return fmt.Errorf("migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			if _, err = tx.Exec(ctx, "INSERT INTO schema_migrations (version, name) VALUES ($1, $2);", migration.Version, migration.Name); err != nil {
+				return err
+			}
+			appliedNow = append(appliedNow, migration)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return appliedNow, nil
+}
+
+func revertMigrations(db Database, migrations []Migration, steps uint) ([]Migration, error) {
+	revertedNow := []Migration{}
+
+	err := runLockedMigration(db, func(ctx context.Context, tx pgx.Tx) error {
+		applied, err := queryAppliedMigrations(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range slices.Backward(migrations) {
+			if uint(len(revertedNow)) >= steps {
+				break
+			}
+			if _, done := applied[migration.Version]; !done {
+				continue
+			}
+
+			if migration.Down == "" {
+				return fmt.Errorf("migration %d_%s can not be reverted: missing down script", migration.Version, migration.Name)
+			}
+			if _, err = tx.Exec(ctx, migration.Down); err != nil {
+				return fmt.Errorf("revert of migration %d_%s failed: %w", migration.Version, migration.Name, err)
+			}
+			if _, err = tx.Exec(ctx, "DELETE FROM schema_migrations WHERE version = $1;", migration.Version); err != nil {
+				return err
+			}
+			revertedNow = append(revertedNow, migration)
+		}
+
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+	return revertedNow, nil
+}
+
+func migrationsStatus(db Database, migrations []Migration) ([]MigrationStatus, error) {
+	status := []MigrationStatus{}
+
+	err := runLockedMigration(db, func(ctx context.Context, tx pgx.Tx) error {
+		applied, err := queryAppliedMigrations(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		for _, migration := range migrations {
+			appliedAt, done := applied[migration.Version]
+			status = append(status, MigrationStatus{
+				Version:   migration.Version,
+				Name:      migration.Name,
+				Applied:   done,
+				AppliedAt: appliedAt,
+			})
+		}
+
+		return nil
+	})
+
+	return status, err
+}
+
+// Runs the given function inside a transaction holding the migrations lock.
+// All the changes are committed together, or none when the function fails.
+func runLockedMigration(db Database, run func(ctx context.Context, tx pgx.Tx) error) error {
+	ctx := context.Background()
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	// Transaction level lock, released on commit or rollback
+	if _, err = tx.Exec(ctx, "SELECT pg_advisory_xact_lock($1);", migrationLockKey); err != nil {
+		return err
+	}
+	if _, err = tx.Exec(ctx, createMigrationsTableQuery); err != nil {
+		return err
+	}
+
+	if err = run(ctx, tx); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func queryAppliedMigrations(ctx context.Context, tx pgx.Tx) (map[uint]time.Time, error) {
+	rows, err := tx.Query(ctx, "SELECT version, applied_at FROM schema_migrations;")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := map[uint]time.Time{}
+	for rows.Next() {
+		var version uint
+		var appliedAt time.Time
+		if err = rows.Scan(&version, &appliedAt); err != nil {
+			return nil, err
+		}
+		applied[version] = appliedAt
+	}
+
+	return applied, rows.Err()
+}
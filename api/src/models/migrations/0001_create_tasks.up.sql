@@ -1,4 +1,4 @@
-
+-- Databases created before the migrations were introduced already have this table
 CREATE TABLE IF NOT EXISTS tasks (
   id SERIAL PRIMARY KEY,
   title TEXT NOT NULL,
@@ -7,4 +7,4 @@ CREATE TABLE IF NOT EXISTS tasks (
   priority INT,
   created_at DATE,
   due_date DATE
-)
\ No newline at end of file
+);
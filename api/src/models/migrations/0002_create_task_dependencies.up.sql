@@ -0,0 +1,8 @@
+CREATE TABLE task_dependencies (
+  task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  depends_on_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  PRIMARY KEY (task_id, depends_on_id),
+  CHECK (task_id <> depends_on_id)
+);
+
+CREATE INDEX task_dependencies_depends_on_idx ON task_dependencies (depends_on_id);
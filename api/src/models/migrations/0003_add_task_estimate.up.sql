@@ -0,0 +1,2 @@
+-- Effort needed to complete the task, used to compute the schedule
+ALTER TABLE tasks ADD COLUMN estimate_hours INT NOT NULL DEFAULT 0 CHECK (estimate_hours >= 0);
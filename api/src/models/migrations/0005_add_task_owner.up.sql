@@ -0,0 +1,4 @@
+-- Tasks created before the users existed have no owner and are not visible to anyone
+ALTER TABLE tasks ADD COLUMN owner_id INT REFERENCES users(id) ON DELETE CASCADE;
+
+CREATE INDEX tasks_owner_idx ON tasks (owner_id);
@@ -0,0 +1,15 @@
+CREATE TABLE projects (
+  id SERIAL PRIMARY KEY,
+  name TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  archived BOOLEAN NOT NULL DEFAULT false,
+  owner_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX projects_owner_idx ON projects (owner_id);
+
+-- Tasks of a deleted project are kept, without project
+ALTER TABLE tasks ADD COLUMN project_id INT REFERENCES projects(id) ON DELETE SET NULL;
+
+CREATE INDEX tasks_project_idx ON tasks (project_id);
@@ -0,0 +1,9 @@
+-- Users the project is shared with, the user who created the project is always its owner
+CREATE TABLE project_members (
+  project_id INT NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+  user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  role TEXT NOT NULL CHECK (role IN ('viewer', 'editor', 'owner')),
+  PRIMARY KEY (project_id, user_id)
+);
+
+CREATE INDEX project_members_user_idx ON project_members (user_id);
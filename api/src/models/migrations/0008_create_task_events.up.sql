@@ -0,0 +1,11 @@
+-- History of the changes on each task, kept after the task is deleted
+CREATE TABLE task_events (
+  id SERIAL PRIMARY KEY,
+  task_id INT NOT NULL,
+  actor_id INT REFERENCES users(id) ON DELETE SET NULL,
+  action TEXT NOT NULL CHECK (action IN ('create', 'update', 'delete')),
+  changes JSONB NOT NULL DEFAULT '[]',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX task_events_task_idx ON task_events (task_id, id);
@@ -0,0 +1,7 @@
+DELETE FROM task_events WHERE action = 'restore';
+ALTER TABLE task_events DROP CONSTRAINT task_events_action_check;
+ALTER TABLE task_events ADD CONSTRAINT task_events_action_check CHECK (action IN ('create', 'update', 'delete'));
+
+-- Tasks still in the trash are deleted for good
+DELETE FROM tasks WHERE deleted_at IS NOT NULL;
+ALTER TABLE tasks DROP COLUMN IF EXISTS deleted_at;
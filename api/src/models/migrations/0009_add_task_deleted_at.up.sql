@@ -0,0 +1,7 @@
+-- Deleted tasks stay in the trash until they are restored or purged
+ALTER TABLE tasks ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX tasks_deleted_idx ON tasks (deleted_at) WHERE deleted_at IS NOT NULL;
+
+ALTER TABLE task_events DROP CONSTRAINT task_events_action_check;
+ALTER TABLE task_events ADD CONSTRAINT task_events_action_check CHECK (action IN ('create', 'update', 'delete', 'restore'));
@@ -0,0 +1,2 @@
+-- Incremented on every change, so concurrent updates can detect they read a stale task
+ALTER TABLE tasks ADD COLUMN version INT NOT NULL DEFAULT 1;
@@ -0,0 +1,5 @@
+-- Subtasks point to the task they break down, the top level tasks have no parent.
+-- Purging a parent keeps the subtasks the user deleting it could not see.
+ALTER TABLE tasks ADD COLUMN parent_id INT REFERENCES tasks(id) ON DELETE SET NULL;
+
+CREATE INDEX tasks_parent_idx ON tasks (parent_id) WHERE parent_id IS NOT NULL;
@@ -0,0 +1,10 @@
+-- Steps of a task too small to be tasks on their own, ordered by position
+CREATE TABLE checklist_items (
+  id SERIAL PRIMARY KEY,
+  task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  text VARCHAR(500) NOT NULL,
+  checked BOOLEAN NOT NULL DEFAULT FALSE,
+  position INT NOT NULL
+);
+
+CREATE INDEX checklist_items_task_idx ON checklist_items (task_id, position);
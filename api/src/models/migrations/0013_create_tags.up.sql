@@ -0,0 +1,16 @@
+-- Labels of the tasks, each user having its own set of tags
+CREATE TABLE tags (
+  id SERIAL PRIMARY KEY,
+  owner_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  name VARCHAR(50) NOT NULL,
+  color CHAR(7) NOT NULL DEFAULT '#808080',
+  UNIQUE (owner_id, name)
+);
+
+CREATE TABLE task_tags (
+  task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  tag_id INT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+  PRIMARY KEY (task_id, tag_id)
+);
+
+CREATE INDEX task_tags_tag_idx ON task_tags (tag_id);
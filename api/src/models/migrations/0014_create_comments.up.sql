@@ -0,0 +1,21 @@
+-- Discussion on the tasks, hidden while the task is in the trash and removed when it is purged
+CREATE TABLE comments (
+  id SERIAL PRIMARY KEY,
+  task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  author_id INT REFERENCES users(id) ON DELETE SET NULL,
+  body TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  updated_at TIMESTAMPTZ
+);
+
+CREATE INDEX comments_task_idx ON comments (task_id, id);
+
+-- Bodies of the comments before each edit
+CREATE TABLE comment_edits (
+  id SERIAL PRIMARY KEY,
+  comment_id INT NOT NULL REFERENCES comments(id) ON DELETE CASCADE,
+  body TEXT NOT NULL,
+  edited_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX comment_edits_comment_idx ON comment_edits (comment_id, id);
@@ -0,0 +1,14 @@
+-- Files attached to the tasks. The contents are kept in the blob store under blob_key,
+-- removed by the application when the task is purged.
+CREATE TABLE attachments (
+  id SERIAL PRIMARY KEY,
+  task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  uploader_id INT REFERENCES users(id) ON DELETE SET NULL,
+  filename TEXT NOT NULL,
+  content_type TEXT NOT NULL,
+  size BIGINT NOT NULL,
+  blob_key TEXT NOT NULL UNIQUE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX attachments_task_idx ON attachments (task_id, id);
@@ -0,0 +1,2 @@
+ALTER TABLE tasks DROP COLUMN IF EXISTS series_id;
+DROP TABLE IF EXISTS task_series;
@@ -0,0 +1,17 @@
+-- Recurring tasks: the RRULE of the series and the template of its next occurrence, generated
+-- from the latest one when it is done. The due date of the first occurrence is the DTSTART of the rule.
+CREATE TABLE task_series (
+  id SERIAL PRIMARY KEY,
+  rrule TEXT NOT NULL,
+  start_at TIMESTAMPTZ NOT NULL,
+  title TEXT NOT NULL,
+  description TEXT NOT NULL DEFAULT '',
+  priority INT NOT NULL DEFAULT 0,
+  estimate_hours INT NOT NULL DEFAULT 0,
+  latest_task_id INT REFERENCES tasks(id) ON DELETE SET NULL,
+  latest_due TIMESTAMPTZ NOT NULL
+);
+
+ALTER TABLE tasks ADD COLUMN series_id INT REFERENCES task_series(id) ON DELETE SET NULL;
+
+CREATE INDEX tasks_series_idx ON tasks (series_id) WHERE series_id IS NOT NULL;
@@ -0,0 +1 @@
+ALTER TABLE tasks ALTER COLUMN due_date TYPE DATE USING (due_date AT TIME ZONE 'UTC')::date;
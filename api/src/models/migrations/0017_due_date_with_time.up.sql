@@ -0,0 +1,3 @@
+-- Due dates keep their time of day, which recurrence rules and reminders before the due date
+-- are computed from. The existing dates become midnight UTC, as they were read.
+ALTER TABLE tasks ALTER COLUMN due_date TYPE TIMESTAMPTZ USING due_date::timestamp AT TIME ZONE 'UTC';
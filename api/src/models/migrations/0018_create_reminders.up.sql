@@ -0,0 +1,21 @@
+-- Reminders of the users on the tasks, at a fixed time (remind_at) or some time before the due date
+-- of the task (before_due_seconds). sent_at is set once the reminder is delivered, and retry_at skips it
+-- while a delivery is in progress and until the failed deliveries are retried.
+CREATE TABLE reminders (
+  id SERIAL PRIMARY KEY,
+  task_id INT NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+  user_id INT NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  remind_at TIMESTAMPTZ,
+  before_due_seconds BIGINT,
+  channel TEXT NOT NULL,
+  recipient TEXT NOT NULL DEFAULT '',
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  sent_at TIMESTAMPTZ,
+  attempts INT NOT NULL DEFAULT 0,
+  retry_at TIMESTAMPTZ,
+  last_error TEXT NOT NULL DEFAULT '',
+  CHECK ((remind_at IS NULL) <> (before_due_seconds IS NULL))
+);
+
+CREATE INDEX reminders_task_idx ON reminders (task_id, user_id, id);
+CREATE INDEX reminders_pending_idx ON reminders (task_id) WHERE sent_at IS NULL;
@@ -0,0 +1,194 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestMigrations() []Migration {
+	return []Migration{
+		{Version: 1, Name: "create_tasks", Up: "CREATE TABLE tasks;", Down: "DROP TABLE tasks;"},
+		{Version: 2, Name: "add_owner", Up: "ALTER TABLE tasks ADD owner;", Down: "ALTER TABLE tasks DROP owner;"},
+	}
+}
+
+// Set the expectations for locking and reading the applied migrations
+func expectMigrationLock(mockConn pgxmock.PgxPoolIface, appliedVersions ...uint) {
+	mockConn.ExpectBegin()
+	mockConn.ExpectExec("SELECT pg_advisory_xact_lock\\(\\$1\\);").
+		WithArgs(migrationLockKey).
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+	mockConn.ExpectExec("CREATE TABLE IF NOT EXISTS schema_migrations").
+		WillReturnResult(pgxmock.NewResult("CREATE", 0))
+
+	appliedRows := pgxmock.NewRows([]string{"version", "applied_at"})
+	for _, version := range appliedVersions {
+		appliedRows.AddRow(version, time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC))
+	}
+	mockConn.ExpectQuery("SELECT version, applied_at FROM schema_migrations;").
+		WillReturnRows(appliedRows)
+}
+
+// Load Migrations Tests ///////////////////////////////////
+func TestLoadEmbeddedMigrations(t *testing.T) {
+	migrations, err := LoadMigrations()
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.NotEmpty(t, migrations, "Embedded migrations should not be empty")
+	assert.Equal(t, uint(1), migrations[0].Version, "First migration should have version 1")
+	for _, migration := range migrations {
+		assert.NotEmpty(t, migration.Down, fmt.Sprintf("Migration %d should have a down script", migration.Version))
+	}
+}
+
+func TestLoadMigrationsSorted(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/0010_second.up.sql":  {Data: []byte("second up")},
+		"sql/0002_first.up.sql":   {Data: []byte("first up")},
+		"sql/0002_first.down.sql": {Data: []byte("first down")},
+	}
+
+	migrations, err := loadMigrations(fsys, "sql")
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, []Migration{
+		{Version: 2, Name: "first", Up: "first up", Down: "first down"},
+		{Version: 10, Name: "second", Up: "second up"},
+	}, migrations, "Migrations should be sorted by version")
+}
+
+func TestLoadMigrationsInvalidFiles(t *testing.T) {
+	_, err := loadMigrations(fstest.MapFS{"sql/first.up.sql": {Data: []byte("up")}}, "sql")
+	assert.EqualError(t, err, "invalid migration file name 'first.up.sql'")
+
+	_, err = loadMigrations(fstest.MapFS{"sql/0001_first.down.sql": {Data: []byte("down")}}, "sql")
+	assert.EqualError(t, err, "missing up script for migration 1_first")
+
+	_, err = loadMigrations(fstest.MapFS{
+		"sql/0001_first.up.sql": {Data: []byte("up")},
+		"sql/0001_other.up.sql": {Data: []byte("up")},
+	}, "sql")
+	assert.EqualError(t, err, "migration version 1 used by 'first' and 'other'")
+}
+
+// Apply Migrations Tests ///////////////////////////////////
+func TestApplyPendingMigrations(t *testing.T) {
+	mockConn, _ := setMockConnection()
+	defer mockConn.Close()
+
+	expectMigrationLock(mockConn, 1)
+	mockConn.ExpectExec("ALTER TABLE tasks ADD owner;").
+		WillReturnResult(pgxmock.NewResult("ALTER", 0))
+	mockConn.ExpectExec("INSERT INTO schema_migrations \\(version, name\\) VALUES \\(\\$1, \\$2\\);").
+		WithArgs(uint(2), "add_owner").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mockConn.ExpectCommit()
+	mockConn.ExpectRollback()
+
+	applied, err := applyMigrations(mockConn, getTestMigrations())
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Len(t, applied, 1, "Only the pending migration should be applied")
+	assert.Equal(t, uint(2), applied[0].Version, "Applied migration should be version 2")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestApplyMigrationsFailureRollsBack(t *testing.T) {
+	mockConn, _ := setMockConnection()
+	defer mockConn.Close()
+
+	expectMigrationLock(mockConn)
+	mockConn.ExpectExec("CREATE TABLE tasks;").
+		WillReturnResult(pgxmock.NewResult("CREATE", 0))
+	mockConn.ExpectExec("INSERT INTO schema_migrations").
+		WithArgs(uint(1), "create_tasks").
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+	mockConn.ExpectExec("ALTER TABLE tasks ADD owner;").
+		WillReturnError(errors.New("syntax error"))
+	mockConn.ExpectRollback()
+
+	applied, err := applyMigrations(mockConn, getTestMigrations())
+
+	assert.EqualError(t, err, "migration 2_add_owner failed: syntax error")
+	assert.Nil(t, applied, "No migration should be reported as applied")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestApplyMigrationsLockError(t *testing.T) {
+	mockConn, _ := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectBegin()
+	mockConn.ExpectExec("SELECT pg_advisory_xact_lock\\(\\$1\\);").
+		WithArgs(migrationLockKey).
+		WillReturnError(errors.New("connection error"))
+	mockConn.ExpectRollback()
+
+	_, err := applyMigrations(mockConn, getTestMigrations())
+
+	assert.Error(t, err, "Expected error from DB failure")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+// Revert Migrations Tests ///////////////////////////////////
+func TestRevertLastMigration(t *testing.T) {
+	mockConn, _ := setMockConnection()
+	defer mockConn.Close()
+
+	expectMigrationLock(mockConn, 1, 2)
+	mockConn.ExpectExec("ALTER TABLE tasks DROP owner;").
+		WillReturnResult(pgxmock.NewResult("ALTER", 0))
+	mockConn.ExpectExec("DELETE FROM schema_migrations WHERE version = \\$1;").
+		WithArgs(uint(2)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+	mockConn.ExpectCommit()
+	mockConn.ExpectRollback()
+
+	reverted, err := revertMigrations(mockConn, getTestMigrations(), 1)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Len(t, reverted, 1, "Only one migration should be reverted")
+	assert.Equal(t, uint(2), reverted[0].Version, "Reverted migration should be version 2")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestRevertMigrationWithoutDownScript(t *testing.T) {
+	mockConn, _ := setMockConnection()
+	defer mockConn.Close()
+
+	migrations := getTestMigrations()
+	migrations[1].Down = ""
+
+	expectMigrationLock(mockConn, 1, 2)
+	mockConn.ExpectRollback()
+
+	_, err := revertMigrations(mockConn, migrations, 2)
+
+	assert.EqualError(t, err, "migration 2_add_owner can not be reverted: missing down script")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+// Migrations Status Tests ///////////////////////////////////
+func TestMigrationsStatus(t *testing.T) {
+	mockConn, _ := setMockConnection()
+	defer mockConn.Close()
+
+	expectMigrationLock(mockConn, 1)
+	mockConn.ExpectCommit()
+	mockConn.ExpectRollback()
+
+	status, err := migrationsStatus(mockConn, getTestMigrations())
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Len(t, status, 2, "Status should list every migration")
+	assert.True(t, status[0].Applied, "Migration 1 should be applied")
+	assert.Equal(t, time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC), status[0].AppliedAt, "Wrong applied date for migration 1")
+	assert.False(t, status[1].Applied, "Migration 2 should be pending")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
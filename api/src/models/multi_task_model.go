@@ -3,7 +3,6 @@ package models
 import (
 	"context"
 	"fmt"
-	"log"
 	"strings"
 )
 
@@ -14,73 +13,82 @@ type TasksPaginationQuery struct {
 	Limit     uint
 }
 
+type FilterMatch string
+
+const (
+	MatchContains FilterMatch = "contains"
+	MatchExact    FilterMatch = "exact"
+	MatchAny      FilterMatch = "any" // Value is a comma separated list, one of them is in Column
+	MatchAll      FilterMatch = "all" // Value is a comma separated list, all of them are in Column
+)
+
 type TasksFilterQuery struct {
-	Query string // columns
-	Value string // searched value
+	Query  string      // columns
+	Value  string      // searched value
+	Column string      // filtered column, used by non-SQL repositories
+	Match  FilterMatch // how Value is compared against Column
 }
 
-func QueryTasks(filterConfig []TasksFilterQuery, pageConfig TasksPaginationQuery) ([]Task, error) {
-	conn := getDatabaseConnection()
-	defer conn.Close()
+func (r *PostgresTaskRepository) QueryTasks(ctx context.Context, userId uint, filterConfig []TasksFilterQuery, pageConfig TasksPaginationQuery) ([]Task, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
 
 	var queryBuilder strings.Builder
 	var queryParams []interface{} // Slice to store query values
 
-	queryBuilder.WriteString("SELECT * FROM tasks")
+	queryBuilder.WriteString("SELECT " + taskColumns + " FROM tasks")
 
 	// Add filter queries
 	filterElements := len(filterConfig)
-	if filterElements > 0 {
-		queryBuilder.WriteString(" WHERE ")
-	}
+	queryBuilder.WriteString(" WHERE ")
 
-	for filter_idx, filter := range filterConfig {
-		if filter_idx > 0 {
-			queryBuilder.WriteString(" AND ")
-		}
+	for _, filter := range filterConfig {
 		queryBuilder.WriteString(filter.Query)
+		queryBuilder.WriteString(" AND ")
 		queryParams = append(queryParams, filter.Value)
 	}
 
+	// Only the tasks of the user, placed after the filters as their parameters are already numbered
+	queryBuilder.WriteString(activeTaskCondition(filterElements + 1))
+	queryParams = append(queryParams, userId)
+
 	// Add pagination query
-	paginationQuery := fmt.Sprintf(" ORDER BY %s %s LIMIT $%d OFFSET $%d;", pageConfig.SortBy, pageConfig.SortOrder, filterElements+1, filterElements+2)
+	paginationQuery := fmt.Sprintf(" ORDER BY %s %s LIMIT $%d OFFSET $%d;", pageConfig.SortBy, pageConfig.SortOrder, filterElements+2, filterElements+3)
 	queryBuilder.WriteString(paginationQuery)
 	queryParams = append(queryParams, pageConfig.Limit, pageConfig.Offset) // Add limit and offset
 
 	taskQuery := queryBuilder.String()
 
-	rows, err := conn.Query(context.Background(), taskQuery, queryParams...)
+	rows, err := r.conn(ctx).Query(ctx, taskQuery, queryParams...)
 	tasks := []Task{}
 
 	if err != nil {
 		return tasks, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
-		task := Task{}
-		err := rows.Scan(&task.Id,
-			&task.Title,
-			&task.Description,
-			&task.Status,
-			&task.Priority,
-			&task.CreatedAt,
-			&task.DueDate)
-
+		task, err := scanTask(rows)
 		if err != nil {
-			log.Fatalln(err)
+			return []Task{}, err
 		}
 		tasks = append(tasks, task)
 	}
 
-	return tasks, err
+	// Iteration stops early when the query is canceled or times out
+	if err = rows.Err(); err != nil {
+		return []Task{}, err
+	}
+
+	return tasks, nil
 }
 
-func GetAmountOfTasks() (uint, error) {
-	conn := getDatabaseConnection()
-	defer conn.Close()
+func (r *PostgresTaskRepository) GetAmountOfTasks(ctx context.Context, userId uint) (uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
 
 	var tableSize uint
-	err := conn.QueryRow(context.Background(), "SELECT COUNT(*) FROM tasks;").Scan(&tableSize)
+	err := r.conn(ctx).QueryRow(ctx, "SELECT COUNT(*) FROM tasks WHERE "+activeTaskCondition(1)+";", userId).Scan(&tableSize)
 
 	return tableSize, err
 }
@@ -1,8 +1,10 @@
 package models
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
@@ -12,6 +14,9 @@ import (
 
 // Multi Tasks Tests ///////////////////////////////////
 
+// Owner of the test tasks
+const testOwnerId = uint(7)
+
 func getTestTasksList() []Task {
 	layout := "2006-01-02"
 	testCreatedAt, _ := time.Parse(layout, "2025-02-03")
@@ -27,6 +32,7 @@ func getTestTasksList() []Task {
 			Priority:    uint16(1),
 			CreatedAt:   testCreatedAt,
 			DueDate:     testDueDate,
+			OwnerId:     testOwnerId,
 		},
 		Task{
 			Id:          2,
@@ -36,6 +42,7 @@ func getTestTasksList() []Task {
 			Priority:    uint16(5),
 			CreatedAt:   testCreatedAt,
 			DueDate:     laterDueDate,
+			OwnerId:     testOwnerId,
 		},
 		Task{
 			Id:          3,
@@ -45,13 +52,14 @@ func getTestTasksList() []Task {
 			Priority:    uint16(2),
 			CreatedAt:   testCreatedAt,
 			DueDate:     testDueDate,
+			OwnerId:     testOwnerId,
 		},
 	}
 	return testTasks
 }
 
 func TestQueryTasksListPrioFilterIdSorted(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	testTasks := getTestTasksList()
@@ -62,16 +70,16 @@ func TestQueryTasksListPrioFilterIdSorted(t *testing.T) {
 	}
 
 	// Set SQL mock expectation
-	expectedQuery := "SELECT \\* FROM tasks WHERE priority= \\$1 ORDER BY id ASC LIMIT \\$2 OFFSET \\$3;"
-	expectedReturn := pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date"})
-	expectedReturn.AddRow(testTasks[0].Id, testTasks[0].Title, testTasks[0].Description, testTasks[0].Status, testTasks[0].Priority, testTasks[0].CreatedAt, testTasks[0].DueDate)
+	expectedQuery := "SELECT id, title, description, status, priority, created_at, due_date, estimate_hours, owner_id, COALESCE\\(project_id, 0\\), deleted_at, version, COALESCE\\(parent_id, 0\\), " + checklistCountPattern + ", " + tagNamesPattern + ", " + commentCountPattern + ", COALESCE\\(series_id, 0\\), " + recurrencePattern + " FROM tasks WHERE priority= \\$1 AND deleted_at IS NULL AND " + taskAccessPattern(2) + " ORDER BY id ASC LIMIT \\$3 OFFSET \\$4;"
+	expectedReturn := pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date", "estimate_hours", "owner_id", "project_id", "deleted_at", "version", "parent_id", "checklist_total", "checklist_checked", "tags", "comment_count", "series_id", "recurrence"})
+	expectedReturn.AddRow(testTasks[0].Id, testTasks[0].Title, testTasks[0].Description, testTasks[0].Status, testTasks[0].Priority, testTasks[0].CreatedAt, testTasks[0].DueDate, testTasks[0].EstimateHours, testTasks[0].OwnerId, testTasks[0].ProjectId, nil, uint(1), uint(0), uint(0), uint(0), []string{}, uint(0), uint(0), "")
 
 	mockConn.ExpectQuery(expectedQuery).
-		WithArgs(filterConfig[0].Value, pagConfig.Limit, pagConfig.Offset).
+		WithArgs(filterConfig[0].Value, testOwnerId, pagConfig.Limit, pagConfig.Offset).
 		WillReturnRows(expectedReturn)
 
 	// Run function
-	queriedTask, err := QueryTasks(filterConfig, pagConfig)
+	queriedTask, err := repository.QueryTasks(context.Background(), testOwnerId, filterConfig, pagConfig)
 
 	// Assertions
 	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
@@ -82,7 +90,7 @@ func TestQueryTasksListPrioFilterIdSorted(t *testing.T) {
 }
 
 func TestQueryTasksListStatusFilterPrioSorted(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	testTasks := getTestTasksList()
@@ -93,18 +101,18 @@ func TestQueryTasksListStatusFilterPrioSorted(t *testing.T) {
 	}
 
 	// Set SQL mock expectation
-	expectedQuery := "SELECT \\* FROM tasks WHERE status= \\$1 ORDER BY priority ASC LIMIT \\$2 OFFSET \\$3;"
+	expectedQuery := "SELECT id, title, description, status, priority, created_at, due_date, estimate_hours, owner_id, COALESCE\\(project_id, 0\\), deleted_at, version, COALESCE\\(parent_id, 0\\), " + checklistCountPattern + ", " + tagNamesPattern + ", " + commentCountPattern + ", COALESCE\\(series_id, 0\\), " + recurrencePattern + " FROM tasks WHERE status= \\$1 AND deleted_at IS NULL AND " + taskAccessPattern(2) + " ORDER BY priority ASC LIMIT \\$3 OFFSET \\$4;"
 
-	expectedReturn := pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date"})
-	expectedReturn.AddRow(testTasks[2].Id, testTasks[2].Title, testTasks[2].Description, testTasks[2].Status, testTasks[2].Priority, testTasks[2].CreatedAt, testTasks[2].DueDate)
-	expectedReturn.AddRow(testTasks[0].Id, testTasks[0].Title, testTasks[0].Description, testTasks[0].Status, testTasks[0].Priority, testTasks[0].CreatedAt, testTasks[0].DueDate)
+	expectedReturn := pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date", "estimate_hours", "owner_id", "project_id", "deleted_at", "version", "parent_id", "checklist_total", "checklist_checked", "tags", "comment_count", "series_id", "recurrence"})
+	expectedReturn.AddRow(testTasks[2].Id, testTasks[2].Title, testTasks[2].Description, testTasks[2].Status, testTasks[2].Priority, testTasks[2].CreatedAt, testTasks[2].DueDate, testTasks[2].EstimateHours, testTasks[2].OwnerId, testTasks[2].ProjectId, nil, uint(1), uint(0), uint(0), uint(0), []string{}, uint(0), uint(0), "")
+	expectedReturn.AddRow(testTasks[0].Id, testTasks[0].Title, testTasks[0].Description, testTasks[0].Status, testTasks[0].Priority, testTasks[0].CreatedAt, testTasks[0].DueDate, testTasks[0].EstimateHours, testTasks[0].OwnerId, testTasks[0].ProjectId, nil, uint(1), uint(0), uint(0), uint(0), []string{}, uint(0), uint(0), "")
 
 	mockConn.ExpectQuery(expectedQuery).
-		WithArgs(filterConfig[0].Value, pagConfig.Limit, pagConfig.Offset).
+		WithArgs(filterConfig[0].Value, testOwnerId, pagConfig.Limit, pagConfig.Offset).
 		WillReturnRows(expectedReturn)
 
 	// Run function
-	queriedTask, err := QueryTasks(filterConfig, pagConfig)
+	queriedTask, err := repository.QueryTasks(context.Background(), testOwnerId, filterConfig, pagConfig)
 
 	// Assertions
 	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
@@ -117,7 +125,7 @@ func TestQueryTasksListStatusFilterPrioSorted(t *testing.T) {
 }
 
 func TestQueryTasksListTitleFilterDueDateSortedDESC(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	testTasks := getTestTasksList()
@@ -128,19 +136,19 @@ func TestQueryTasksListTitleFilterDueDateSortedDESC(t *testing.T) {
 	}
 
 	// Set SQL mock expectation
-	expectedQuery := "SELECT \\* FROM tasks WHERE title= \\$1 ORDER BY due_date DESC LIMIT \\$2 OFFSET \\$3;"
+	expectedQuery := "SELECT id, title, description, status, priority, created_at, due_date, estimate_hours, owner_id, COALESCE\\(project_id, 0\\), deleted_at, version, COALESCE\\(parent_id, 0\\), " + checklistCountPattern + ", " + tagNamesPattern + ", " + commentCountPattern + ", COALESCE\\(series_id, 0\\), " + recurrencePattern + " FROM tasks WHERE title= \\$1 AND deleted_at IS NULL AND " + taskAccessPattern(2) + " ORDER BY due_date DESC LIMIT \\$3 OFFSET \\$4;"
 
-	expectedReturn := pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date"})
-	expectedReturn.AddRow(testTasks[1].Id, testTasks[1].Title, testTasks[1].Description, testTasks[1].Status, testTasks[1].Priority, testTasks[1].CreatedAt, testTasks[1].DueDate)
-	expectedReturn.AddRow(testTasks[0].Id, testTasks[0].Title, testTasks[0].Description, testTasks[0].Status, testTasks[0].Priority, testTasks[0].CreatedAt, testTasks[0].DueDate)
-	expectedReturn.AddRow(testTasks[2].Id, testTasks[2].Title, testTasks[2].Description, testTasks[2].Status, testTasks[2].Priority, testTasks[2].CreatedAt, testTasks[2].DueDate)
+	expectedReturn := pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date", "estimate_hours", "owner_id", "project_id", "deleted_at", "version", "parent_id", "checklist_total", "checklist_checked", "tags", "comment_count", "series_id", "recurrence"})
+	expectedReturn.AddRow(testTasks[1].Id, testTasks[1].Title, testTasks[1].Description, testTasks[1].Status, testTasks[1].Priority, testTasks[1].CreatedAt, testTasks[1].DueDate, testTasks[1].EstimateHours, testTasks[1].OwnerId, testTasks[1].ProjectId, nil, uint(1), uint(0), uint(0), uint(0), []string{}, uint(0), uint(0), "")
+	expectedReturn.AddRow(testTasks[0].Id, testTasks[0].Title, testTasks[0].Description, testTasks[0].Status, testTasks[0].Priority, testTasks[0].CreatedAt, testTasks[0].DueDate, testTasks[0].EstimateHours, testTasks[0].OwnerId, testTasks[0].ProjectId, nil, uint(1), uint(0), uint(0), uint(0), []string{}, uint(0), uint(0), "")
+	expectedReturn.AddRow(testTasks[2].Id, testTasks[2].Title, testTasks[2].Description, testTasks[2].Status, testTasks[2].Priority, testTasks[2].CreatedAt, testTasks[2].DueDate, testTasks[2].EstimateHours, testTasks[2].OwnerId, testTasks[2].ProjectId, nil, uint(1), uint(0), uint(0), uint(0), []string{}, uint(0), uint(0), "")
 
 	mockConn.ExpectQuery(expectedQuery).
-		WithArgs(filterConfig[0].Value, pagConfig.Limit, pagConfig.Offset).
+		WithArgs(filterConfig[0].Value, testOwnerId, pagConfig.Limit, pagConfig.Offset).
 		WillReturnRows(expectedReturn)
 
 	// Run function
-	queriedTask, err := QueryTasks(filterConfig, pagConfig)
+	queriedTask, err := repository.QueryTasks(context.Background(), testOwnerId, filterConfig, pagConfig)
 
 	// Assertions
 	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
@@ -155,7 +163,7 @@ func TestQueryTasksListTitleFilterDueDateSortedDESC(t *testing.T) {
 }
 
 func TestQueryTasksListTitleFilterDueDateSortedLimited(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	testTasks := getTestTasksList()
@@ -166,18 +174,18 @@ func TestQueryTasksListTitleFilterDueDateSortedLimited(t *testing.T) {
 	}
 
 	// Set SQL mock expectation
-	expectedQuery := "SELECT \\* FROM tasks WHERE title= \\$1 ORDER BY due_date ASC LIMIT \\$2 OFFSET \\$3;"
+	expectedQuery := "SELECT id, title, description, status, priority, created_at, due_date, estimate_hours, owner_id, COALESCE\\(project_id, 0\\), deleted_at, version, COALESCE\\(parent_id, 0\\), " + checklistCountPattern + ", " + tagNamesPattern + ", " + commentCountPattern + ", COALESCE\\(series_id, 0\\), " + recurrencePattern + " FROM tasks WHERE title= \\$1 AND deleted_at IS NULL AND " + taskAccessPattern(2) + " ORDER BY due_date ASC LIMIT \\$3 OFFSET \\$4;"
 
-	expectedReturn := pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date"})
-	expectedReturn.AddRow(testTasks[1].Id, testTasks[1].Title, testTasks[1].Description, testTasks[1].Status, testTasks[1].Priority, testTasks[1].CreatedAt, testTasks[1].DueDate)
-	expectedReturn.AddRow(testTasks[0].Id, testTasks[0].Title, testTasks[0].Description, testTasks[0].Status, testTasks[0].Priority, testTasks[0].CreatedAt, testTasks[0].DueDate)
+	expectedReturn := pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date", "estimate_hours", "owner_id", "project_id", "deleted_at", "version", "parent_id", "checklist_total", "checklist_checked", "tags", "comment_count", "series_id", "recurrence"})
+	expectedReturn.AddRow(testTasks[1].Id, testTasks[1].Title, testTasks[1].Description, testTasks[1].Status, testTasks[1].Priority, testTasks[1].CreatedAt, testTasks[1].DueDate, testTasks[1].EstimateHours, testTasks[1].OwnerId, testTasks[1].ProjectId, nil, uint(1), uint(0), uint(0), uint(0), []string{}, uint(0), uint(0), "")
+	expectedReturn.AddRow(testTasks[0].Id, testTasks[0].Title, testTasks[0].Description, testTasks[0].Status, testTasks[0].Priority, testTasks[0].CreatedAt, testTasks[0].DueDate, testTasks[0].EstimateHours, testTasks[0].OwnerId, testTasks[0].ProjectId, nil, uint(1), uint(0), uint(0), uint(0), []string{}, uint(0), uint(0), "")
 
 	mockConn.ExpectQuery(expectedQuery).
-		WithArgs(filterConfig[0].Value, pagConfig.Limit, pagConfig.Offset).
+		WithArgs(filterConfig[0].Value, testOwnerId, pagConfig.Limit, pagConfig.Offset).
 		WillReturnRows(expectedReturn)
 
 	// Run function
-	queriedTask, err := QueryTasks(filterConfig, pagConfig)
+	queriedTask, err := repository.QueryTasks(context.Background(), testOwnerId, filterConfig, pagConfig)
 
 	// Assertions
 	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
@@ -190,7 +198,7 @@ func TestQueryTasksListTitleFilterDueDateSortedLimited(t *testing.T) {
 }
 
 func TestQueryTasksListDescFilterIdSorted(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	testTasks := getTestTasksList()
@@ -201,18 +209,18 @@ func TestQueryTasksListDescFilterIdSorted(t *testing.T) {
 	}
 
 	// Set SQL mock expectation
-	expectedQuery := "SELECT \\* FROM tasks WHERE description= \\$1 ORDER BY id ASC LIMIT \\$2 OFFSET \\$3;"
+	expectedQuery := "SELECT id, title, description, status, priority, created_at, due_date, estimate_hours, owner_id, COALESCE\\(project_id, 0\\), deleted_at, version, COALESCE\\(parent_id, 0\\), " + checklistCountPattern + ", " + tagNamesPattern + ", " + commentCountPattern + ", COALESCE\\(series_id, 0\\), " + recurrencePattern + " FROM tasks WHERE description= \\$1 AND deleted_at IS NULL AND " + taskAccessPattern(2) + " ORDER BY id ASC LIMIT \\$3 OFFSET \\$4;"
 
-	expectedReturn := pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date"})
-	expectedReturn.AddRow(testTasks[0].Id, testTasks[0].Title, testTasks[0].Description, testTasks[0].Status, testTasks[0].Priority, testTasks[0].CreatedAt, testTasks[0].DueDate)
-	expectedReturn.AddRow(testTasks[2].Id, testTasks[2].Title, testTasks[2].Description, testTasks[2].Status, testTasks[2].Priority, testTasks[2].CreatedAt, testTasks[2].DueDate)
+	expectedReturn := pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date", "estimate_hours", "owner_id", "project_id", "deleted_at", "version", "parent_id", "checklist_total", "checklist_checked", "tags", "comment_count", "series_id", "recurrence"})
+	expectedReturn.AddRow(testTasks[0].Id, testTasks[0].Title, testTasks[0].Description, testTasks[0].Status, testTasks[0].Priority, testTasks[0].CreatedAt, testTasks[0].DueDate, testTasks[0].EstimateHours, testTasks[0].OwnerId, testTasks[0].ProjectId, nil, uint(1), uint(0), uint(0), uint(0), []string{}, uint(0), uint(0), "")
+	expectedReturn.AddRow(testTasks[2].Id, testTasks[2].Title, testTasks[2].Description, testTasks[2].Status, testTasks[2].Priority, testTasks[2].CreatedAt, testTasks[2].DueDate, testTasks[2].EstimateHours, testTasks[2].OwnerId, testTasks[2].ProjectId, nil, uint(1), uint(0), uint(0), uint(0), []string{}, uint(0), uint(0), "")
 
 	mockConn.ExpectQuery(expectedQuery).
-		WithArgs(filterConfig[0].Value, pagConfig.Limit, pagConfig.Offset).
+		WithArgs(filterConfig[0].Value, testOwnerId, pagConfig.Limit, pagConfig.Offset).
 		WillReturnRows(expectedReturn)
 
 	// Run function
-	queriedTask, err := QueryTasks(filterConfig, pagConfig)
+	queriedTask, err := repository.QueryTasks(context.Background(), testOwnerId, filterConfig, pagConfig)
 
 	// Assertions
 	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
@@ -225,7 +233,7 @@ func TestQueryTasksListDescFilterIdSorted(t *testing.T) {
 }
 
 func TestQueryTasksListDescFilterIdSortedOffset(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	testTasks := getTestTasksList()
@@ -236,17 +244,17 @@ func TestQueryTasksListDescFilterIdSortedOffset(t *testing.T) {
 	}
 
 	// Set SQL mock expectation
-	expectedQuery := "SELECT \\* FROM tasks WHERE description= \\$1 ORDER BY id ASC LIMIT \\$2 OFFSET \\$3;"
+	expectedQuery := "SELECT id, title, description, status, priority, created_at, due_date, estimate_hours, owner_id, COALESCE\\(project_id, 0\\), deleted_at, version, COALESCE\\(parent_id, 0\\), " + checklistCountPattern + ", " + tagNamesPattern + ", " + commentCountPattern + ", COALESCE\\(series_id, 0\\), " + recurrencePattern + " FROM tasks WHERE description= \\$1 AND deleted_at IS NULL AND " + taskAccessPattern(2) + " ORDER BY id ASC LIMIT \\$3 OFFSET \\$4;"
 
-	expectedReturn := pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date"})
-	expectedReturn.AddRow(testTasks[2].Id, testTasks[2].Title, testTasks[2].Description, testTasks[2].Status, testTasks[2].Priority, testTasks[2].CreatedAt, testTasks[2].DueDate)
+	expectedReturn := pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date", "estimate_hours", "owner_id", "project_id", "deleted_at", "version", "parent_id", "checklist_total", "checklist_checked", "tags", "comment_count", "series_id", "recurrence"})
+	expectedReturn.AddRow(testTasks[2].Id, testTasks[2].Title, testTasks[2].Description, testTasks[2].Status, testTasks[2].Priority, testTasks[2].CreatedAt, testTasks[2].DueDate, testTasks[2].EstimateHours, testTasks[2].OwnerId, testTasks[2].ProjectId, nil, uint(1), uint(0), uint(0), uint(0), []string{}, uint(0), uint(0), "")
 
 	mockConn.ExpectQuery(expectedQuery).
-		WithArgs(filterConfig[0].Value, pagConfig.Limit, pagConfig.Offset).
+		WithArgs(filterConfig[0].Value, testOwnerId, pagConfig.Limit, pagConfig.Offset).
 		WillReturnRows(expectedReturn)
 
 	// Run function
-	queriedTask, err := QueryTasks(filterConfig, pagConfig)
+	queriedTask, err := repository.QueryTasks(context.Background(), testOwnerId, filterConfig, pagConfig)
 
 	// Assertions
 	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
@@ -257,7 +265,7 @@ func TestQueryTasksListDescFilterIdSortedOffset(t *testing.T) {
 }
 
 func TestQueryTasksListTitleFilterStatusFilter(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	testTasks := getTestTasksList()
@@ -269,17 +277,17 @@ func TestQueryTasksListTitleFilterStatusFilter(t *testing.T) {
 	}
 
 	// Set SQL mock expectation
-	expectedQuery := "SELECT \\* FROM tasks WHERE title= \\$1 AND status= \\$2 ORDER BY id ASC LIMIT \\$3 OFFSET \\$4;"
+	expectedQuery := "SELECT id, title, description, status, priority, created_at, due_date, estimate_hours, owner_id, COALESCE\\(project_id, 0\\), deleted_at, version, COALESCE\\(parent_id, 0\\), " + checklistCountPattern + ", " + tagNamesPattern + ", " + commentCountPattern + ", COALESCE\\(series_id, 0\\), " + recurrencePattern + " FROM tasks WHERE title= \\$1 AND status= \\$2 AND deleted_at IS NULL AND " + taskAccessPattern(3) + " ORDER BY id ASC LIMIT \\$4 OFFSET \\$5;"
 
-	expectedReturn := pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date"})
-	expectedReturn.AddRow(testTasks[1].Id, testTasks[1].Title, testTasks[1].Description, testTasks[1].Status, testTasks[1].Priority, testTasks[1].CreatedAt, testTasks[1].DueDate)
+	expectedReturn := pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date", "estimate_hours", "owner_id", "project_id", "deleted_at", "version", "parent_id", "checklist_total", "checklist_checked", "tags", "comment_count", "series_id", "recurrence"})
+	expectedReturn.AddRow(testTasks[1].Id, testTasks[1].Title, testTasks[1].Description, testTasks[1].Status, testTasks[1].Priority, testTasks[1].CreatedAt, testTasks[1].DueDate, testTasks[1].EstimateHours, testTasks[1].OwnerId, testTasks[1].ProjectId, nil, uint(1), uint(0), uint(0), uint(0), []string{}, uint(0), uint(0), "")
 
 	mockConn.ExpectQuery(expectedQuery).
-		WithArgs(filterConfig[0].Value, filterConfig[1].Value, pagConfig.Limit, pagConfig.Offset).
+		WithArgs(filterConfig[0].Value, filterConfig[1].Value, testOwnerId, pagConfig.Limit, pagConfig.Offset).
 		WillReturnRows(expectedReturn)
 
 	// Run function
-	queriedTask, err := QueryTasks(filterConfig, pagConfig)
+	queriedTask, err := repository.QueryTasks(context.Background(), testOwnerId, filterConfig, pagConfig)
 
 	// Assertions
 	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
@@ -290,17 +298,18 @@ func TestQueryTasksListTitleFilterStatusFilter(t *testing.T) {
 }
 
 func TestGetAmountOfTasks(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	// Set SQL mock expectation
-	expectedQuery := "SELECT COUNT\\(\\*\\) FROM tasks;"
+	expectedQuery := "SELECT COUNT\\(\\*\\) FROM tasks WHERE deleted_at IS NULL AND " + taskAccessPattern(1) + ";"
 
 	mockConn.ExpectQuery(expectedQuery).
+		WithArgs(testOwnerId).
 		WillReturnRows(pgxmock.NewRows([]string{"count"}).AddRow(uint(3)))
 
 	// Run function
-	tasksAmount, err := GetAmountOfTasks()
+	tasksAmount, err := repository.GetAmountOfTasks(context.Background(), testOwnerId)
 
 	// Assertions
 	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
@@ -309,21 +318,32 @@ func TestGetAmountOfTasks(t *testing.T) {
 }
 
 func TestQueryTasksDBError(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	pagConfig := TasksPaginationQuery{Offset: 0, SortBy: "id", SortOrder: "ASC", Limit: 10}
 	filterConfig := []TasksFilterQuery{}
 
-	expectedQuery := "SELECT \\* FROM tasks ORDER BY id ASC LIMIT \\$1 OFFSET \\$2;"
+	expectedQuery := "SELECT id, title, description, status, priority, created_at, due_date, estimate_hours, owner_id, COALESCE\\(project_id, 0\\), deleted_at, version, COALESCE\\(parent_id, 0\\), " + checklistCountPattern + ", " + tagNamesPattern + ", " + commentCountPattern + ", COALESCE\\(series_id, 0\\), " + recurrencePattern + " FROM tasks WHERE deleted_at IS NULL AND " + taskAccessPattern(1) + " ORDER BY id ASC LIMIT \\$2 OFFSET \\$3;"
 
 	mockConn.ExpectQuery(expectedQuery).
-		WithArgs(pagConfig.Limit, pagConfig.Offset).
+		WithArgs(testOwnerId, pagConfig.Limit, pagConfig.Offset).
 		WillReturnError(errors.New("connection error"))
 
-	tasks, err := QueryTasks(filterConfig, pagConfig)
+	tasks, err := repository.QueryTasks(context.Background(), testOwnerId, filterConfig, pagConfig)
 
 	assert.Error(t, err, "Expected error from DB failure")
 	assert.Empty(t, tasks, "Should return empty list on DB error")
 	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
 }
+
+// Regexp matching the access condition of the tasks for the user query parameter
+// Pattern of the checklistCountColumns on the task queries
+var checklistCountPattern = regexp.QuoteMeta(checklistCountColumns)
+var tagNamesPattern = regexp.QuoteMeta(tagNamesColumn)
+var commentCountPattern = regexp.QuoteMeta(commentCountColumn)
+var recurrencePattern = regexp.QuoteMeta(recurrenceColumn)
+
+func taskAccessPattern(param int) string {
+	return regexp.QuoteMeta(taskAccessCondition(param))
+}
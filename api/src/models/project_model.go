@@ -0,0 +1,173 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+// Columns read for each project, in the order expected by scanProject, with the role of the user $1
+const projectColumns = "p.id, p.name, p.description, p.archived, p.owner_id, p.created_at, CASE WHEN p.owner_id = $1 THEN 'owner' ELSE m.role END"
+
+// Projects created by the user $1 or shared with it
+const userProjects = "projects p LEFT JOIN project_members m ON m.project_id = p.id AND m.user_id = $1 WHERE (p.owner_id = $1 OR m.user_id IS NOT NULL)"
+
+// Condition matching the projects created by or shared with the user (query parameter number param)
+func projectAccessCondition(param int) string {
+	return fmt.Sprintf("(owner_id = $%[1]d OR id IN (SELECT project_id FROM project_members WHERE user_id = $%[1]d))", param)
+}
+
+func scanProject(row rowScanner) (Project, error) {
+	var project Project
+	err := row.Scan(
+		&project.Id,
+		&project.Name,
+		&project.Description,
+		&project.Archived,
+		&project.OwnerId,
+		&project.CreatedAt,
+		&project.Role)
+
+	return project, err
+}
+
+func (r *PostgresTaskRepository) AddProject(ctx context.Context, newProject Project) (uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	newProjectQuery := `
+		INSERT INTO projects (name, description, archived, owner_id, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id;
+	`
+
+	var projectId uint
+	err := r.conn(ctx).QueryRow(ctx, newProjectQuery,
+		newProject.Name,
+		newProject.Description,
+		newProject.Archived,
+		newProject.OwnerId,
+		newProject.CreatedAt,
+	).Scan(&projectId)
+
+	return projectId, err
+}
+
+func (r *PostgresTaskRepository) QueryProject(ctx context.Context, userId uint, projectId uint) (Project, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	return scanProject(r.conn(ctx).QueryRow(ctx, "SELECT "+projectColumns+" FROM "+userProjects+" AND p.id = $2;", userId, projectId))
+}
+
+func (r *PostgresTaskRepository) QueryProjects(ctx context.Context, userId uint, includeArchived bool) ([]Project, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	projects := []Project{}
+	rows, err := r.conn(ctx).Query(ctx, "SELECT "+projectColumns+" FROM "+userProjects+" AND ($2 OR NOT p.archived) ORDER BY p.id;", userId, includeArchived)
+	if err != nil {
+		return projects, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		project, err := scanProject(rows)
+		if err != nil {
+			return []Project{}, err
+		}
+		projects = append(projects, project)
+	}
+
+	if err = rows.Err(); err != nil {
+		return []Project{}, err
+	}
+
+	return projects, nil
+}
+
+func (r *PostgresTaskRepository) UpdateProject(ctx context.Context, userId uint, updatedProject Project) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := r.conn(ctx).Exec(ctx, "UPDATE projects SET name = $1, description = $2, archived = $3 WHERE id = $4 AND "+projectAccessCondition(5)+";",
+		updatedProject.Name,
+		updatedProject.Description,
+		updatedProject.Archived,
+		updatedProject.Id,
+		userId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PostgresTaskRepository) DeleteProject(ctx context.Context, userId uint, projectId uint) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := r.conn(ctx).Exec(ctx, "DELETE FROM projects WHERE id = $1 AND "+projectAccessCondition(2)+";", projectId, userId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PostgresTaskRepository) SetProjectMember(ctx context.Context, projectId uint, member ProjectMember) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	_, err := r.conn(ctx).Exec(ctx, "INSERT INTO project_members (project_id, user_id, role) VALUES ($1, $2, $3) ON CONFLICT (project_id, user_id) DO UPDATE SET role = EXCLUDED.role;",
+		projectId, member.UserId, member.Role)
+
+	return err
+}
+
+func (r *PostgresTaskRepository) DeleteProjectMember(ctx context.Context, projectId uint, userId uint) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := r.conn(ctx).Exec(ctx, "DELETE FROM project_members WHERE project_id = $1 AND user_id = $2;", projectId, userId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PostgresTaskRepository) QueryProjectMembers(ctx context.Context, projectId uint) ([]ProjectMember, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	members := []ProjectMember{}
+	rows, err := r.conn(ctx).Query(ctx, "SELECT user_id, role FROM project_members WHERE project_id = $1 ORDER BY user_id;", projectId)
+	if err != nil {
+		return members, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var member ProjectMember
+		if err := rows.Scan(&member.UserId, &member.Role); err != nil {
+			return []ProjectMember{}, err
+		}
+		members = append(members, member)
+	}
+
+	if err = rows.Err(); err != nil {
+		return []ProjectMember{}, err
+	}
+
+	return members, nil
+}
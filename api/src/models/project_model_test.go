@@ -0,0 +1,165 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Projects Tests ///////////////////////////////////
+func TestAddProject(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	newProject := Project{Name: "Backend", Description: "API work", OwnerId: testOwnerId, CreatedAt: time.Now()}
+
+	mockConn.ExpectQuery("INSERT INTO projects \\(name, description, archived, owner_id, created_at\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5\\) RETURNING id;").
+		WithArgs(newProject.Name, newProject.Description, false, testOwnerId, newProject.CreatedAt).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(1)))
+
+	projectId, err := repository.AddProject(context.Background(), newProject)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, uint(1), projectId, "Returned wrong project id")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestQueryProjects(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	createdAt := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	mockConn.ExpectQuery(regexp.QuoteMeta("SELECT "+projectColumns+" FROM "+userProjects+" AND ($2 OR NOT p.archived) ORDER BY p.id;")).
+		WithArgs(testOwnerId, false).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "name", "description", "archived", "owner_id", "created_at", "role"}).
+			AddRow(uint(1), "Backend", "", false, testOwnerId, createdAt, RoleOwner).
+			AddRow(uint(3), "Frontend", "", false, testOwnerId+1, createdAt, RoleViewer))
+
+	projects, err := repository.QueryProjects(context.Background(), testOwnerId, false)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Len(t, projects, 2, "Should return both projects")
+	assert.Equal(t, "Frontend", projects[1].Name, "Returned wrong project name")
+	assert.Equal(t, RoleViewer, projects[1].Role, "Returned wrong role on shared project")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestUpdateProjectNotFound(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectExec("UPDATE projects SET name = \\$1, description = \\$2, archived = \\$3 WHERE id = \\$4 AND "+regexp.QuoteMeta(projectAccessCondition(5))+";").
+		WithArgs("Backend", "", true, uint(9), testOwnerId).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err := repository.UpdateProject(context.Background(), testOwnerId, Project{Id: 9, Name: "Backend", Archived: true})
+
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Should return ErrNoRows for inexistent project")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestDeleteProject(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectExec("DELETE FROM projects WHERE id = \\$1 AND "+regexp.QuoteMeta(projectAccessCondition(2))+";").
+		WithArgs(uint(1), testOwnerId).
+		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+
+	err := repository.DeleteProject(context.Background(), testOwnerId, 1)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestMemoryQueryProjectsArchived(t *testing.T) {
+	repository := NewMemoryTaskRepository()
+	repository.AddProject(context.Background(), Project{Name: "Backend", OwnerId: testOwnerId})
+	repository.AddProject(context.Background(), Project{Name: "Old", Archived: true, OwnerId: testOwnerId})
+	repository.AddProject(context.Background(), Project{Name: "Other user", OwnerId: testOwnerId + 1})
+
+	projects, err := repository.QueryProjects(context.Background(), testOwnerId, false)
+	assert.NoError(t, err, "Unexpected error querying projects")
+	assert.Len(t, projects, 1, "Archived and other users projects should not be listed")
+
+	projects, _ = repository.QueryProjects(context.Background(), testOwnerId, true)
+	assert.Len(t, projects, 2, "Archived projects should be listed when requested")
+
+	_, err = repository.QueryProject(context.Background(), testOwnerId, 3)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Projects of other users should not be found")
+}
+
+func TestMemoryDeleteProjectKeepsTasks(t *testing.T) {
+	repository := NewMemoryTaskRepository()
+	projectId, _ := repository.AddProject(context.Background(), Project{Name: "Backend", OwnerId: testOwnerId})
+	taskId, _ := repository.AddTask(context.Background(), Task{Title: "task", OwnerId: testOwnerId, ProjectId: projectId})
+
+	err := repository.DeleteProject(context.Background(), testOwnerId, projectId)
+	assert.NoError(t, err, "Unexpected error deleting project")
+
+	task, err := repository.QueryTask(context.Background(), testOwnerId, taskId)
+	assert.NoError(t, err, "Task should be kept after deleting its project")
+	assert.Equal(t, uint(0), task.ProjectId, "Task should be left without project")
+
+	err = repository.DeleteProject(context.Background(), testOwnerId, projectId)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Should return ErrNoRows for inexistent project")
+}
+
+func TestSetProjectMember(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectExec("INSERT INTO project_members \\(project_id, user_id, role\\) VALUES \\(\\$1, \\$2, \\$3\\) ON CONFLICT \\(project_id, user_id\\) DO UPDATE SET role = EXCLUDED.role;").
+		WithArgs(uint(1), uint(2), RoleEditor).
+		WillReturnResult(pgxmock.NewResult("INSERT", 1))
+
+	err := repository.SetProjectMember(context.Background(), 1, ProjectMember{UserId: 2, Role: RoleEditor})
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestDeleteProjectMemberNotFound(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectExec("DELETE FROM project_members WHERE project_id = \\$1 AND user_id = \\$2;").
+		WithArgs(uint(1), uint(2)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err := repository.DeleteProjectMember(context.Background(), 1, 2)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Should return ErrNoRows for inexistent member")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestMemorySharedProjectTasks(t *testing.T) {
+	repository := NewMemoryTaskRepository()
+	memberId := testOwnerId + 1
+	projectId, _ := repository.AddProject(context.Background(), Project{Name: "Backend", OwnerId: testOwnerId})
+	taskId, _ := repository.AddTask(context.Background(), Task{Title: "task", OwnerId: testOwnerId, ProjectId: projectId})
+	repository.AddTask(context.Background(), Task{Title: "private", OwnerId: testOwnerId})
+
+	_, err := repository.QueryTask(context.Background(), memberId, taskId)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Tasks should not be visible before sharing the project")
+
+	repository.SetProjectMember(context.Background(), projectId, ProjectMember{UserId: memberId, Role: RoleViewer})
+
+	_, err = repository.QueryTask(context.Background(), memberId, taskId)
+	assert.NoError(t, err, "Tasks of shared projects should be visible")
+	amount, _ := repository.GetAmountOfTasks(context.Background(), memberId)
+	assert.Equal(t, uint(1), amount, "Only the tasks of the shared project should be counted")
+
+	project, _ := repository.QueryProject(context.Background(), memberId, projectId)
+	assert.Equal(t, RoleViewer, project.Role, "Returned wrong role on shared project")
+
+	repository.DeleteProjectMember(context.Background(), projectId, memberId)
+	_, err = repository.QueryProject(context.Background(), memberId, projectId)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Project should not be visible after removing the member")
+}
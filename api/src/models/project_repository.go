@@ -0,0 +1,51 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// Roles of the users on a project, from the least to the most privileged
+const (
+	RoleViewer = "viewer"
+	RoleEditor = "editor"
+	RoleOwner  = "owner"
+)
+
+// Project groups tasks of the same stream of work
+type Project struct {
+	Id          uint
+	Name        string
+	Description string
+	Archived    bool
+	OwnerId     uint // user who created the project
+	CreatedAt   time.Time
+	Role        string // role on the project of the user who queried it
+}
+
+// ProjectMember is a user the project is shared with
+type ProjectMember struct {
+	UserId uint
+	Role   string
+}
+
+// ProjectRepository stores the projects and the users they are shared with.
+// Projects the user neither created nor is member of are handled as if they did not exist.
+type ProjectRepository interface {
+	AddProject(ctx context.Context, newProject Project) (uint, error)
+	// QueryProject fails with sql.ErrNoRows for unknown projects
+	QueryProject(ctx context.Context, userId uint, projectId uint) (Project, error)
+	// QueryProjects lists the projects ordered by id, archived ones only when includeArchived is set
+	QueryProjects(ctx context.Context, userId uint, includeArchived bool) ([]Project, error)
+	// UpdateProject and DeleteProject fail with sql.ErrNoRows for unknown projects.
+	// The tasks of a deleted project are kept, without project.
+	UpdateProject(ctx context.Context, userId uint, updatedProject Project) error
+	DeleteProject(ctx context.Context, userId uint, projectId uint) error
+
+	// SetProjectMember shares the project with the user, or changes its role when already shared
+	SetProjectMember(ctx context.Context, projectId uint, member ProjectMember) error
+	// DeleteProjectMember fails with sql.ErrNoRows when the project is not shared with the user
+	DeleteProjectMember(ctx context.Context, projectId uint, userId uint) error
+	// QueryProjectMembers lists the users the project is shared with, ordered by user id
+	QueryProjectMembers(ctx context.Context, projectId uint) ([]ProjectMember, error)
+}
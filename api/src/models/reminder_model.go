@@ -0,0 +1,144 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// When the reminder is due: its fixed time, or the due date of its task less the time before it.
+// NULL for the relative reminders of the tasks without due date (stored as year 1).
+const reminderFireAtColumn = "COALESCE(r.remind_at, CASE WHEN t.due_date > TIMESTAMPTZ '0001-01-01 00:00:00+00' THEN t.due_date - make_interval(secs => r.before_due_seconds) END)"
+
+const reminderColumns = "r.id, r.task_id, r.user_id, r.remind_at, COALESCE(r.before_due_seconds, 0), r.channel, r.recipient, r.created_at, " +
+	reminderFireAtColumn + ", r.sent_at, r.attempts, r.retry_at, r.last_error"
+
+func (r *PostgresTaskRepository) AddReminder(ctx context.Context, newReminder Reminder) (uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	newReminderQuery := `
+		INSERT INTO reminders (task_id, user_id, remind_at, before_due_seconds, channel, recipient, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id;
+	`
+
+	// Only one of the fixed time and the time before the due date is stored
+	var remindAt, beforeDue any
+	if newReminder.RemindAt.IsZero() {
+		beforeDue = int64(newReminder.BeforeDue / time.Second)
+	} else {
+		remindAt = newReminder.RemindAt
+	}
+
+	var reminderId uint
+	err := r.conn(ctx).QueryRow(ctx, newReminderQuery, newReminder.TaskId, newReminder.UserId, remindAt, beforeDue,
+		newReminder.Channel, newReminder.Recipient, newReminder.CreatedAt).Scan(&reminderId)
+
+	return reminderId, err
+}
+
+func (r *PostgresTaskRepository) QueryReminders(ctx context.Context, userId uint, taskId uint) ([]Reminder, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).Query(ctx, "SELECT "+reminderColumns+" FROM reminders r JOIN tasks t ON t.id = r.task_id WHERE r.task_id = $1 AND r.user_id = $2 ORDER BY r.id;", taskId, userId)
+	if err != nil {
+		return []Reminder{}, err
+	}
+	defer rows.Close()
+
+	reminders := []Reminder{}
+	for rows.Next() {
+		reminder, err := scanReminder(rows)
+		if err != nil {
+			return []Reminder{}, err
+		}
+		reminders = append(reminders, reminder)
+	}
+	if err := rows.Err(); err != nil {
+		return []Reminder{}, err
+	}
+
+	return reminders, nil
+}
+
+func (r *PostgresTaskRepository) DeleteReminder(ctx context.Context, userId uint, taskId uint, reminderId uint) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := r.conn(ctx).Exec(ctx, "DELETE FROM reminders WHERE id = $1 AND task_id = $2 AND user_id = $3;", reminderId, taskId, userId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PostgresTaskRepository) ClaimDueReminder(ctx context.Context, now time.Time, maxAttempts uint) (Reminder, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	claimQuery := "SELECT " + reminderColumns + `
+		FROM reminders r JOIN tasks t ON t.id = r.task_id
+		WHERE r.sent_at IS NULL AND r.attempts < $2 AND (r.retry_at IS NULL OR r.retry_at <= $1)
+		AND t.deleted_at IS NULL AND ` + reminderFireAtColumn + ` <= $1
+		ORDER BY ` + reminderFireAtColumn + `, r.id
+		LIMIT 1
+		FOR UPDATE OF r SKIP LOCKED;
+	`
+
+	return scanReminder(r.conn(ctx).QueryRow(ctx, claimQuery, now, maxAttempts))
+}
+
+func (r *PostgresTaskRepository) LeaseReminder(ctx context.Context, reminderId uint, until time.Time) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	_, err := r.conn(ctx).Exec(ctx, "UPDATE reminders SET retry_at = $1 WHERE id = $2;", until, reminderId)
+	return err
+}
+
+func (r *PostgresTaskRepository) MarkReminderSent(ctx context.Context, reminderId uint, sentAt time.Time) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	_, err := r.conn(ctx).Exec(ctx, "UPDATE reminders SET sent_at = $1 WHERE id = $2;", sentAt, reminderId)
+	return err
+}
+
+func (r *PostgresTaskRepository) MarkReminderFailed(ctx context.Context, reminderId uint, lastError string, retryAt time.Time) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	_, err := r.conn(ctx).Exec(ctx, "UPDATE reminders SET attempts = attempts + 1, last_error = $1, retry_at = $2 WHERE id = $3;", lastError, retryAt, reminderId)
+	return err
+}
+
+func scanReminder(row pgx.Row) (Reminder, error) {
+	var reminder Reminder
+	var beforeDueSeconds int64
+	var remindAt, fireAt, sentAt, retryAt *time.Time
+	err := row.Scan(&reminder.Id, &reminder.TaskId, &reminder.UserId, &remindAt, &beforeDueSeconds, &reminder.Channel, &reminder.Recipient,
+		&reminder.CreatedAt, &fireAt, &sentAt, &reminder.Attempts, &retryAt, &reminder.LastError)
+	reminder.BeforeDue = time.Duration(beforeDueSeconds) * time.Second
+	reminder.RemindAt = timeOrZero(remindAt)
+	reminder.FireAt = timeOrZero(fireAt)
+	reminder.SentAt = timeOrZero(sentAt)
+	reminder.RetryAt = timeOrZero(retryAt)
+
+	return reminder, err
+}
+
+// Value of a nullable timestamp, zero for NULL
+func timeOrZero(value *time.Time) time.Time {
+	if value == nil {
+		return time.Time{}
+	}
+	return *value
+}
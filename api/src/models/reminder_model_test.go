@@ -0,0 +1,95 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+var reminderFireAtPattern = regexp.QuoteMeta(reminderFireAtColumn)
+
+var reminderColumnNames = []string{"id", "task_id", "user_id", "remind_at", "before_due_seconds", "channel", "recipient", "created_at", "fire_at", "sent_at", "attempts", "retry_at", "last_error"}
+
+// Reminders Tests ///////////////////////////////////
+func TestAddReminderBeforeDue(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	createdAt := time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC)
+	mockConn.ExpectQuery("INSERT INTO reminders \\(task_id, user_id, remind_at, before_due_seconds, channel, recipient, created_at\\)\\s+VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7\\)\\s+RETURNING id;").
+		WithArgs(uint(1), testOwnerId, nil, int64(5400), "email", "ada@example.com", createdAt).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(2)))
+
+	reminderId, err := repository.AddReminder(context.Background(), Reminder{
+		TaskId: 1, UserId: testOwnerId, BeforeDue: 90 * time.Minute, Channel: "email", Recipient: "ada@example.com", CreatedAt: createdAt,
+	})
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, uint(2), reminderId, "Returned value should be 2")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestClaimDueReminder(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	now := time.Date(2025, 2, 10, 8, 0, 0, 0, time.UTC)
+	fireAt := now.Add(-time.Minute)
+	expectedQuery := "SELECT r.id, .* FROM reminders r JOIN tasks t ON t.id = r.task_id\\s+WHERE r.sent_at IS NULL AND r.attempts < \\$2 AND \\(r.retry_at IS NULL OR r.retry_at <= \\$1\\)\\s+" +
+		"AND t.deleted_at IS NULL AND " + reminderFireAtPattern + " <= \\$1\\s+ORDER BY " + reminderFireAtPattern + ", r.id\\s+LIMIT 1\\s+FOR UPDATE OF r SKIP LOCKED;"
+	mockConn.ExpectQuery(expectedQuery).
+		WithArgs(now, uint(5)).
+		WillReturnRows(pgxmock.NewRows(reminderColumnNames).
+			AddRow(uint(2), uint(1), testOwnerId, nil, int64(60), "webhook", "", now.Add(-time.Hour), &fireAt, nil, uint(1), &fireAt, "timeout"))
+	mockConn.ExpectQuery(expectedQuery).
+		WithArgs(now, uint(5)).
+		WillReturnRows(pgxmock.NewRows(reminderColumnNames))
+
+	reminder, err := repository.ClaimDueReminder(context.Background(), now, 5)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, Reminder{
+		Id: 2, TaskId: 1, UserId: testOwnerId, BeforeDue: time.Minute, Channel: "webhook", CreatedAt: now.Add(-time.Hour),
+		FireAt: fireAt, Attempts: 1, RetryAt: fireAt, LastError: "timeout",
+	}, reminder, "Returned wrong reminder")
+
+	_, err = repository.ClaimDueReminder(context.Background(), now, 5)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "No reminder should be due")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestLeaseReminder(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	until := time.Date(2025, 2, 10, 8, 5, 0, 0, time.UTC)
+	mockConn.ExpectExec("UPDATE reminders SET retry_at = \\$1 WHERE id = \\$2;").
+		WithArgs(until, uint(2)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	err := repository.LeaseReminder(context.Background(), 2, until)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestMarkReminderFailed(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	retryAt := time.Date(2025, 2, 10, 8, 2, 0, 0, time.UTC)
+	mockConn.ExpectExec("UPDATE reminders SET attempts = attempts \\+ 1, last_error = \\$1, retry_at = \\$2 WHERE id = \\$3;").
+		WithArgs("timeout", retryAt, uint(2)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+
+	err := repository.MarkReminderFailed(context.Background(), 2, "timeout", retryAt)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
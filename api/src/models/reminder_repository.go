@@ -0,0 +1,44 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// Reminder notifies a user about a task, at a fixed time or some time before its due date
+type Reminder struct {
+	Id        uint
+	TaskId    uint
+	UserId    uint          // user reminded, who created the reminder
+	RemindAt  time.Time     // fixed time, zero for the reminders relative to the due date
+	BeforeDue time.Duration // time before the due date, for the relative reminders
+	Channel   string        // notifier delivering the reminder
+	Recipient string        // address on the channel, like an email address; empty when the channel has a fixed one
+	CreatedAt time.Time
+
+	FireAt    time.Time // when the reminder is due, zero while the task of a relative reminder has no due date
+	SentAt    time.Time // zero until the reminder fired
+	Attempts  uint      // failed deliveries
+	RetryAt   time.Time // no delivery before, set while a delivery is in progress and after a failed one
+	LastError string    // error of the last failed delivery
+}
+
+// ReminderRepository stores the reminders on the tasks. Access to the task is checked by the
+// caller, the reminders being left while the task is in the trash and removed when it is purged.
+type ReminderRepository interface {
+	AddReminder(ctx context.Context, newReminder Reminder) (uint, error)
+	// QueryReminders lists the reminders of the user on the task, by id
+	QueryReminders(ctx context.Context, userId uint, taskId uint) ([]Reminder, error)
+	// DeleteReminder fails with sql.ErrNoRows when the user has no such reminder on the task
+	DeleteReminder(ctx context.Context, userId uint, taskId uint, reminderId uint) error
+	// ClaimDueReminder returns the reminder not sent yet that is due first at now, of a task out of
+	// the trash, skipping the ones that failed maxAttempts times or retry later. With Postgres the
+	// reminder is locked until the end of the transaction and skipped by the concurrent claims.
+	// It fails with sql.ErrNoRows when no reminder is due.
+	ClaimDueReminder(ctx context.Context, now time.Time, maxAttempts uint) (Reminder, error)
+	// LeaseReminder skips the reminder on the claims before until, without counting an attempt
+	LeaseReminder(ctx context.Context, reminderId uint, until time.Time) error
+	MarkReminderSent(ctx context.Context, reminderId uint, sentAt time.Time) error
+	// MarkReminderFailed counts a failed delivery, the reminder being retried from retryAt
+	MarkReminderFailed(ctx context.Context, reminderId uint, lastError string, retryAt time.Time) error
+}
@@ -0,0 +1,54 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// RRULE of the series of the task, empty when it does not recur
+const recurrenceColumn = "COALESCE((SELECT s.rrule FROM task_series s WHERE s.id = tasks.series_id), '')"
+
+func (r *PostgresTaskRepository) AddSeries(ctx context.Context, newSeries TaskSeries) (uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	newSeriesQuery := `
+		INSERT INTO task_series (rrule, start_at, title, description, priority, estimate_hours, latest_task_id, latest_due)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id;
+	`
+
+	var seriesId uint
+	err := r.conn(ctx).QueryRow(ctx, newSeriesQuery, newSeries.Rule, newSeries.StartAt, newSeries.Title, newSeries.Description,
+		newSeries.Priority, newSeries.EstimateHours, nullableId(newSeries.LatestTaskId), newSeries.LatestDue).Scan(&seriesId)
+
+	return seriesId, err
+}
+
+func (r *PostgresTaskRepository) QuerySeries(ctx context.Context, seriesId uint) (TaskSeries, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	var series TaskSeries
+	err := r.conn(ctx).QueryRow(ctx, "SELECT id, rrule, start_at, title, description, priority, estimate_hours, COALESCE(latest_task_id, 0), latest_due FROM task_series WHERE id = $1;", seriesId).
+		Scan(&series.Id, &series.Rule, &series.StartAt, &series.Title, &series.Description, &series.Priority, &series.EstimateHours, &series.LatestTaskId, &series.LatestDue)
+
+	return series, err
+}
+
+func (r *PostgresTaskRepository) QuerySeriesTasks(ctx context.Context, userId uint, seriesId uint) ([]Task, error) {
+	return r.queryTaskList(ctx, "SELECT "+taskColumns+" FROM tasks WHERE series_id=$1 AND "+activeTaskCondition(2)+" ORDER BY id;", seriesId, userId)
+}
+
+func (r *PostgresTaskRepository) AdvanceSeries(ctx context.Context, seriesId uint, latestTaskId uint, nextTaskId uint, nextDue time.Time) (bool, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := r.conn(ctx).Exec(ctx, "UPDATE task_series SET latest_task_id = $1, latest_due = $2 WHERE id = $3 AND latest_task_id IS NOT DISTINCT FROM $4;",
+		nextTaskId, nextDue, seriesId, nullableId(latestTaskId))
+	if err != nil {
+		return false, err
+	}
+
+	return result.RowsAffected() == 1, nil
+}
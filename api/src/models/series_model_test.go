@@ -0,0 +1,53 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Task Series Tests ///////////////////////////////////
+func TestAddSeries(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	startAt := time.Date(2025, 2, 3, 10, 0, 0, 0, time.UTC)
+	mockConn.ExpectQuery("INSERT INTO task_series \\(rrule, start_at, title, description, priority, estimate_hours, latest_task_id, latest_due\\)\\s+VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7, \\$8\\)\\s+RETURNING id;").
+		WithArgs("FREQ=WEEKLY", startAt, "Standup notes", "", uint16(2), uint(1), nil, startAt).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(3)))
+
+	seriesId, err := repository.AddSeries(context.Background(), TaskSeries{
+		Rule: "FREQ=WEEKLY", StartAt: startAt, Title: "Standup notes", Priority: 2, EstimateHours: 1, LatestDue: startAt,
+	})
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, uint(3), seriesId, "Returned value should be 3")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestAdvanceSeries(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	nextDue := time.Date(2025, 2, 10, 10, 0, 0, 0, time.UTC)
+	expectedQuery := "UPDATE task_series SET latest_task_id = \\$1, latest_due = \\$2 WHERE id = \\$3 AND latest_task_id IS NOT DISTINCT FROM \\$4;"
+	mockConn.ExpectExec(expectedQuery).
+		WithArgs(uint(8), nextDue, uint(3), uint(5)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mockConn.ExpectExec(expectedQuery).
+		WithArgs(uint(9), nextDue, uint(3), uint(5)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	advanced, err := repository.AdvanceSeries(context.Background(), 3, 5, 8, nextDue)
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.True(t, advanced, "Series should advance from its latest occurrence")
+
+	advanced, err = repository.AdvanceSeries(context.Background(), 3, 5, 9, nextDue)
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.False(t, advanced, "Series should not advance twice from the same occurrence")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
@@ -0,0 +1,35 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// TaskSeries holds the recurrence of a recurring task. Its occurrences are tasks generated one at
+// a time: when the latest one is done, the next one is created from the template of the series.
+type TaskSeries struct {
+	Id      uint
+	Rule    string    // RFC 5545 RRULE, without the "RRULE:" prefix
+	StartAt time.Time // DTSTART of the rule, due date of the first occurrence
+
+	// Template of the next occurrences
+	Title         string
+	Description   string
+	Priority      uint16
+	EstimateHours uint
+
+	LatestTaskId uint      // latest occurrence, the only one generating the next; 0 when it was purged
+	LatestDue    time.Time // due date the rule gave to the latest occurrence
+}
+
+// SeriesRepository stores the series of the recurring tasks. Access to the tasks is checked by the caller.
+type SeriesRepository interface {
+	AddSeries(ctx context.Context, newSeries TaskSeries) (uint, error)
+	// QuerySeries fails with sql.ErrNoRows when there is no such series
+	QuerySeries(ctx context.Context, seriesId uint) (TaskSeries, error)
+	// QuerySeriesTasks lists the occurrences of the series out of the trash the user can access, by id
+	QuerySeriesTasks(ctx context.Context, userId uint, seriesId uint) ([]Task, error)
+	// AdvanceSeries makes nextTaskId, due on nextDue, the latest occurrence when it still is latestTaskId,
+	// returning false otherwise, so each occurrence generates the next one once
+	AdvanceSeries(ctx context.Context, seriesId uint, latestTaskId uint, nextTaskId uint, nextDue time.Time) (bool, error)
+}
@@ -2,94 +2,181 @@ package models
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
 type Task struct {
-	Id          uint
-	Title       string
-	Description string
-	Status      string
-	Priority    uint16
-	CreatedAt   time.Time
-	DueDate     time.Time
+	Id            uint
+	Title         string
+	Description   string
+	Status        string
+	Priority      uint16
+	CreatedAt     time.Time
+	DueDate       time.Time
+	EstimateHours uint
+	OwnerId       uint
+	ProjectId     uint      // 0 when the task is not in a project
+	DeletedAt     time.Time // zero when the task is not in the trash
+	Version       uint      // incremented on every change, starting at 1
+	ParentId      uint      // 0 when the task is not a subtask
+
+	// Counted from the checklist items, ignored when storing the task
+	ChecklistTotal   uint
+	ChecklistChecked uint
+
+	Tags         []string // names of the tags labeling the task, ignored when storing the task
+	CommentCount uint     // counted from the comments, ignored when storing the task
+
+	SeriesId   uint   // series of the recurring task, 0 when it does not recur
+	Recurrence string // RRULE of the series, ignored when storing the task
 }
 
-func AddTask(newTask Task) (uint, error) {
-	conn := getDatabaseConnection()
-	defer conn.Close()
+// Columns read for each task, in the order expected by scanTask
+const taskColumns = "id, title, description, status, priority, created_at, due_date, estimate_hours, owner_id, COALESCE(project_id, 0), deleted_at, version, COALESCE(parent_id, 0), " + checklistCountColumns + ", " + tagNamesColumn + ", " + commentCountColumn + ", COALESCE(series_id, 0), " + recurrenceColumn
+
+// Amount of checklist items of the task, and of the checked ones
+const checklistCountColumns = "(SELECT COUNT(*) FROM checklist_items c WHERE c.task_id = tasks.id), (SELECT COUNT(*) FROM checklist_items c WHERE c.task_id = tasks.id AND c.checked)"
+
+// Condition matching the tasks out of the trash the user (query parameter number param) can access
+func activeTaskCondition(param int) string {
+	return "deleted_at IS NULL AND " + taskAccessCondition(param)
+}
+
+// Condition matching the tasks the user (query parameter number param) can access:
+// the tasks it created and the tasks of the projects it owns or that are shared with it
+func taskAccessCondition(param int) string {
+	return fmt.Sprintf("(owner_id = $%[1]d OR project_id IN (SELECT id FROM projects WHERE owner_id = $%[1]d UNION SELECT project_id FROM project_members WHERE user_id = $%[1]d))", param)
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanTask(row rowScanner) (Task, error) {
+	var task Task
+	var deletedAt *time.Time
+	err := row.Scan(
+		&task.Id,
+		&task.Title,
+		&task.Description,
+		&task.Status,
+		&task.Priority,
+		&task.CreatedAt,
+		&task.DueDate,
+		&task.EstimateHours,
+		&task.OwnerId,
+		&task.ProjectId,
+		&deletedAt,
+		&task.Version,
+		&task.ParentId,
+		&task.ChecklistTotal,
+		&task.ChecklistChecked,
+		&task.Tags,
+		&task.CommentCount,
+		&task.SeriesId,
+		&task.Recurrence)
+	if deletedAt != nil {
+		task.DeletedAt = *deletedAt
+	}
+
+	return task, err
+}
+
+func (r *PostgresTaskRepository) AddTask(ctx context.Context, newTask Task) (uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
 
 	newTaskQuery := `
-		INSERT INTO tasks (title, description, status, priority, created_at, due_date) 
-		VALUES ($1, $2, $3, $4, $5, $6) 
+		INSERT INTO tasks (title, description, status, priority, created_at, due_date, estimate_hours, owner_id, project_id, parent_id, series_id) 
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) 
 		RETURNING id;
 	`
 
 	var taskId uint
-	err := conn.QueryRow(context.Background(), newTaskQuery,
+	err := r.conn(ctx).QueryRow(ctx, newTaskQuery,
 		newTask.Title,
 		newTask.Description,
 		newTask.Status,
 		newTask.Priority,
 		newTask.CreatedAt,
 		newTask.DueDate,
+		newTask.EstimateHours,
+		newTask.OwnerId,
+		nullableId(newTask.ProjectId),
+		nullableId(newTask.ParentId),
+		nullableId(newTask.SeriesId),
 	).Scan(&taskId)
 
 	return taskId, err
 }
 
-func QueryTask(taskId uint) (Task, error) {
-	conn := getDatabaseConnection()
-	defer conn.Close()
-
-	var queriedTask Task
-	err := conn.QueryRow(context.Background(), "SELECT * FROM tasks WHERE id=$1;", taskId).Scan(
-		&queriedTask.Id,
-		&queriedTask.Title,
-		&queriedTask.Description,
-		&queriedTask.Status,
-		&queriedTask.Priority,
-		&queriedTask.CreatedAt,
-		&queriedTask.DueDate)
-
-	return queriedTask, err
+func (r *PostgresTaskRepository) QueryTask(ctx context.Context, userId uint, taskId uint) (Task, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	return scanTask(r.conn(ctx).QueryRow(ctx, "SELECT "+taskColumns+" FROM tasks WHERE id=$1 AND "+activeTaskCondition(2)+";", taskId, userId))
 }
 
-func UpdateTask(updatedTask Task) error {
-	conn := getDatabaseConnection()
-	defer conn.Close()
+func (r *PostgresTaskRepository) UpdateTask(ctx context.Context, userId uint, updatedTask Task) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
 
-	// Update task from DB
-	newTaskQuery := "UPDATE tasks SET title = $1, description= $2, status= $3, priority= $4, due_date= $5 WHERE id = $6;"
-	_, err := conn.Exec(context.Background(), newTaskQuery,
+	// Update task from DB, unless it changed since it was read
+	newTaskQuery := "UPDATE tasks SET title = $1, description= $2, status= $3, priority= $4, due_date= $5, estimate_hours= $6, project_id= $7, parent_id= $8, series_id= $9, version = version + 1 WHERE id = $10 AND version = $11 AND " + activeTaskCondition(12) + ";"
+	result, err := r.conn(ctx).Exec(ctx, newTaskQuery,
 		updatedTask.Title,
 		updatedTask.Description,
 		updatedTask.Status,
 		updatedTask.Priority,
 		updatedTask.DueDate,
-		updatedTask.Id)
-
-	return err
+		updatedTask.EstimateHours,
+		nullableId(updatedTask.ProjectId),
+		nullableId(updatedTask.ParentId),
+		nullableId(updatedTask.SeriesId),
+		updatedTask.Id,
+		updatedTask.Version,
+		userId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrVersionConflict
+	}
+
+	return nil
 }
 
-func DeleteTask(taskId uint) error {
-	conn := getDatabaseConnection()
-	defer conn.Close()
+func (r *PostgresTaskRepository) DeleteTask(ctx context.Context, userId uint, taskId uint, version uint) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
 
-	// Delete task from DB
-	_, err := conn.Exec(context.Background(), "DELETE FROM tasks WHERE id=$1;", taskId)
+	// Move the task to the trash, unless it changed since it was read
+	result, err := r.conn(ctx).Exec(ctx, "UPDATE tasks SET deleted_at = now(), version = version + 1 WHERE id=$1 AND version = $3 AND "+activeTaskCondition(2)+";", taskId, userId, version)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return ErrVersionConflict
+	}
 
-	return err
+	return nil
 }
 
-func CheckExistence(taskId uint) (bool, error) {
-	conn := getDatabaseConnection()
-	defer conn.Close()
-
-	// Delete task from DB
+func (r *PostgresTaskRepository) CheckExistence(ctx context.Context, userId uint, taskId uint) (bool, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
 
 	var idExist bool
-	err := conn.QueryRow(context.Background(), "SELECT EXISTS(SELECT * from tasks WHERE id=$1);", taskId).Scan(&idExist)
+	err := r.conn(ctx).QueryRow(ctx, "SELECT EXISTS(SELECT * from tasks WHERE id=$1 AND "+activeTaskCondition(2)+");", taskId, userId).Scan(&idExist)
 
 	return idExist, err
 }
+
+// Stores the id 0 (no referenced row) as NULL
+func nullableId(id uint) any {
+	if id == 0 {
+		return nil
+	}
+	return id
+}
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"testing"
@@ -11,16 +12,15 @@ import (
 )
 
 // Inject mock database connection
-func setMockConnection() pgxmock.PgxPoolIface {
+func setMockConnection() (pgxmock.PgxPoolIface, *PostgresTaskRepository) {
 	mockConn, _ := pgxmock.NewPool()
 
-	dbConn = mockConn
-	return mockConn
+	return mockConn, NewPostgresTaskRepository(mockConn, 0)
 }
 
 // Single Tasks Tests ///////////////////////////////////
 func TestAddTask(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	// Define test task
@@ -31,17 +31,18 @@ func TestAddTask(t *testing.T) {
 		Priority:    1,
 		CreatedAt:   time.Now(),
 		DueDate:     time.Now().Add(24 * time.Hour),
+		ProjectId:   2,
 	}
 
-	expectedQuery := "INSERT INTO tasks \\(title, description, status, priority, created_at, due_date\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6\\) RETURNING id; "
+	expectedQuery := "INSERT INTO tasks \\(title, description, status, priority, created_at, due_date, estimate_hours, owner_id, project_id, parent_id, series_id\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5, \\$6, \\$7, \\$8, \\$9, \\$10, \\$11\\) RETURNING id; "
 
 	// Set SQL mock expectation
 	mockConn.ExpectQuery(expectedQuery).
-		WithArgs(newTask.Title, newTask.Description, newTask.Status, newTask.Priority, newTask.CreatedAt, newTask.DueDate).
+		WithArgs(newTask.Title, newTask.Description, newTask.Status, newTask.Priority, newTask.CreatedAt, newTask.DueDate, newTask.EstimateHours, newTask.OwnerId, newTask.ProjectId, nil, nil).
 		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(1)))
 
 	// Run function
-	taskID, err := AddTask(newTask)
+	taskID, err := repository.AddTask(context.Background(), newTask)
 
 	// Assertions
 	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
@@ -50,7 +51,7 @@ func TestAddTask(t *testing.T) {
 }
 
 func TestQueryTask(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	// Define test task
@@ -65,14 +66,14 @@ func TestQueryTask(t *testing.T) {
 	testDueDate, _ := time.Parse(layout, "2025-02-10")
 
 	// Set SQL mock expectation
-	expectedQuery := "SELECT \\* FROM tasks WHERE id=\\$1;"
+	expectedQuery := "SELECT id, title, description, status, priority, created_at, due_date, estimate_hours, owner_id, COALESCE\\(project_id, 0\\), deleted_at, version, COALESCE\\(parent_id, 0\\), " + checklistCountPattern + ", " + tagNamesPattern + ", " + commentCountPattern + ", COALESCE\\(series_id, 0\\), " + recurrencePattern + " FROM tasks WHERE id=\\$1 AND deleted_at IS NULL AND " + taskAccessPattern(2) + ";"
 	mockConn.ExpectQuery(expectedQuery).
-		WithArgs(testId).
-		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date"}).AddRow(
-			testId, testTitle, testDescription, testStatus, testPriority, testCreatedAt, testDueDate))
+		WithArgs(testId, testOwnerId).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date", "estimate_hours", "owner_id", "project_id", "deleted_at", "version", "parent_id", "checklist_total", "checklist_checked", "tags", "comment_count", "series_id", "recurrence"}).AddRow(
+			testId, testTitle, testDescription, testStatus, testPriority, testCreatedAt, testDueDate, uint(8), testOwnerId, uint(3), nil, uint(4), uint(5), uint(3), uint(1), []string{"bug", "urgent"}, uint(2), uint(6), "FREQ=WEEKLY"))
 
 	// Run function
-	queriedTask, err := QueryTask(testId)
+	queriedTask, err := repository.QueryTask(context.Background(), testOwnerId, testId)
 
 	// Assertions
 	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
@@ -83,11 +84,20 @@ func TestQueryTask(t *testing.T) {
 	assert.Equal(t, testPriority, queriedTask.Priority, "Returned Priority should be 5")
 	assert.Equal(t, testCreatedAt, queriedTask.CreatedAt, "Returned createdAT should be '2025-02-03'")
 	assert.Equal(t, testDueDate, queriedTask.DueDate, "Returned dueDate should be '2025-02-10'")
+	assert.Equal(t, uint(8), queriedTask.EstimateHours, "Returned estimate should be 8 hours")
+	assert.Equal(t, uint(3), queriedTask.ProjectId, "Returned project should be 3")
+	assert.Equal(t, uint(5), queriedTask.ParentId, "Returned parent should be 5")
+	assert.Equal(t, uint(3), queriedTask.ChecklistTotal, "Returned checklist should have 3 items")
+	assert.Equal(t, uint(1), queriedTask.ChecklistChecked, "Returned checklist should have 1 checked item")
+	assert.Equal(t, []string{"bug", "urgent"}, queriedTask.Tags, "Returned task should have 2 tags")
+	assert.Equal(t, uint(2), queriedTask.CommentCount, "Returned task should have 2 comments")
+	assert.Equal(t, uint(6), queriedTask.SeriesId, "Returned series should be 6")
+	assert.Equal(t, "FREQ=WEEKLY", queriedTask.Recurrence, "Returned recurrence should be the rule of the series")
 	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
 }
 
 func TestUpdateValidTask(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	// Define test task
@@ -98,17 +108,18 @@ func TestUpdateValidTask(t *testing.T) {
 		Status:      "pending",
 		Priority:    1,
 		DueDate:     time.Now().Add(24 * time.Hour),
+		Version:     2,
 	}
 
-	expectedQuery := "UPDATE tasks SET title = \\$1, description= \\$2, status= \\$3, priority= \\$4, due_date= \\$5 WHERE id = \\$6;"
+	expectedQuery := "UPDATE tasks SET title = \\$1, description= \\$2, status= \\$3, priority= \\$4, due_date= \\$5, estimate_hours= \\$6, project_id= \\$7, parent_id= \\$8, series_id= \\$9, version = version \\+ 1 WHERE id = \\$10 AND version = \\$11 AND deleted_at IS NULL AND " + taskAccessPattern(12) + ";"
 
 	// Set SQL mock expectation
 	mockConn.ExpectExec(expectedQuery).
-		WithArgs(updatedTask.Title, updatedTask.Description, updatedTask.Status, updatedTask.Priority, updatedTask.DueDate, updatedTask.Id).
+		WithArgs(updatedTask.Title, updatedTask.Description, updatedTask.Status, updatedTask.Priority, updatedTask.DueDate, updatedTask.EstimateHours, nil, nil, nil, updatedTask.Id, updatedTask.Version, testOwnerId).
 		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 
 	// Run function
-	err := UpdateTask(updatedTask)
+	err := repository.UpdateTask(context.Background(), testOwnerId, updatedTask)
 
 	// Assertions
 	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
@@ -116,7 +127,7 @@ func TestUpdateValidTask(t *testing.T) {
 }
 
 func TestUpdateInvalidTask(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	// Define test task
@@ -127,38 +138,39 @@ func TestUpdateInvalidTask(t *testing.T) {
 		Status:      "pending",
 		Priority:    1,
 		DueDate:     time.Now().Add(24 * time.Hour),
+		Version:     2,
 	}
 
-	expectedQuery := "UPDATE tasks SET title = \\$1, description= \\$2, status= \\$3, priority= \\$4, due_date= \\$5 WHERE id = \\$6;"
+	expectedQuery := "UPDATE tasks SET title = \\$1, description= \\$2, status= \\$3, priority= \\$4, due_date= \\$5, estimate_hours= \\$6, project_id= \\$7, parent_id= \\$8, series_id= \\$9, version = version \\+ 1 WHERE id = \\$10 AND version = \\$11 AND deleted_at IS NULL AND " + taskAccessPattern(12) + ";"
 
 	// Set SQL mock expectation
 	mockConn.ExpectExec(expectedQuery).
-		WithArgs(updatedTask.Title, updatedTask.Description, updatedTask.Status, updatedTask.Priority, updatedTask.DueDate, updatedTask.Id).
+		WithArgs(updatedTask.Title, updatedTask.Description, updatedTask.Status, updatedTask.Priority, updatedTask.DueDate, updatedTask.EstimateHours, nil, nil, nil, updatedTask.Id, updatedTask.Version, testOwnerId).
 		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
 
 	// Run function
-	err := UpdateTask(updatedTask)
+	err := repository.UpdateTask(context.Background(), testOwnerId, updatedTask)
 
 	// Assertions
-	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.ErrorIs(t, err, ErrVersionConflict, "Should return ErrVersionConflict when the task changed or does not exist")
 	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
 }
 
 func TestDeleteValidTask(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	taskId := uint(1)
 
-	expectedQuery := "DELETE FROM tasks WHERE id=\\$1; "
+	expectedQuery := "UPDATE tasks SET deleted_at = now\\(\\), version = version \\+ 1 WHERE id=\\$1 AND version = \\$3 AND deleted_at IS NULL AND " + taskAccessPattern(2) + ";"
 
 	// Set SQL mock expectation
 	mockConn.ExpectExec(expectedQuery).
-		WithArgs(taskId).
-		WillReturnResult(pgxmock.NewResult("DELETE", 1))
+		WithArgs(taskId, testOwnerId, uint(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
 
 	// Run function
-	err := DeleteTask(taskId)
+	err := repository.DeleteTask(context.Background(), testOwnerId, taskId, 1)
 
 	// Assertions
 	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
@@ -166,38 +178,38 @@ func TestDeleteValidTask(t *testing.T) {
 }
 
 func TestDeleteInvalidTask(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	taskId := uint(1)
 
-	expectedQuery := "DELETE FROM tasks WHERE id=\\$1; "
+	expectedQuery := "UPDATE tasks SET deleted_at = now\\(\\), version = version \\+ 1 WHERE id=\\$1 AND version = \\$3 AND deleted_at IS NULL AND " + taskAccessPattern(2) + ";"
 
 	// Set SQL mock expectation
 	mockConn.ExpectExec(expectedQuery).
-		WithArgs(taskId).
-		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+		WithArgs(taskId, testOwnerId, uint(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
 
 	// Run function
-	err := DeleteTask(taskId)
+	err := repository.DeleteTask(context.Background(), testOwnerId, taskId, 1)
 
 	// Assertions
-	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.ErrorIs(t, err, ErrVersionConflict, "Should return ErrVersionConflict when the task changed or does not exist")
 	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
 }
 
 func TestCheckExistenceFound(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	taskId := uint(1)
-	expectedQuery := "SELECT EXISTS\\(SELECT \\* from tasks WHERE id=\\$1\\);"
+	expectedQuery := "SELECT EXISTS\\(SELECT \\* from tasks WHERE id=\\$1 AND deleted_at IS NULL AND " + taskAccessPattern(2) + "\\);"
 
 	mockConn.ExpectQuery(expectedQuery).
-		WithArgs(taskId).
+		WithArgs(taskId, testOwnerId).
 		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(true))
 
-	exists, err := CheckExistence(taskId)
+	exists, err := repository.CheckExistence(context.Background(), testOwnerId, taskId)
 
 	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
 	assert.True(t, exists, "Task should exist")
@@ -205,17 +217,17 @@ func TestCheckExistenceFound(t *testing.T) {
 }
 
 func TestCheckExistenceNotFound(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	taskId := uint(99)
-	expectedQuery := "SELECT EXISTS\\(SELECT \\* from tasks WHERE id=\\$1\\);"
+	expectedQuery := "SELECT EXISTS\\(SELECT \\* from tasks WHERE id=\\$1 AND deleted_at IS NULL AND " + taskAccessPattern(2) + "\\);"
 
 	mockConn.ExpectQuery(expectedQuery).
-		WithArgs(taskId).
+		WithArgs(taskId, testOwnerId).
 		WillReturnRows(pgxmock.NewRows([]string{"exists"}).AddRow(false))
 
-	exists, err := CheckExistence(taskId)
+	exists, err := repository.CheckExistence(context.Background(), testOwnerId, taskId)
 
 	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
 	assert.False(t, exists, "Task should not exist")
@@ -223,19 +235,35 @@ func TestCheckExistenceNotFound(t *testing.T) {
 }
 
 func TestCheckExistenceDBError(t *testing.T) {
-	mockConn := setMockConnection()
+	mockConn, repository := setMockConnection()
 	defer mockConn.Close()
 
 	taskId := uint(1)
-	expectedQuery := "SELECT EXISTS\\(SELECT \\* from tasks WHERE id=\\$1\\);"
+	expectedQuery := "SELECT EXISTS\\(SELECT \\* from tasks WHERE id=\\$1 AND deleted_at IS NULL AND " + taskAccessPattern(2) + "\\);"
 
 	mockConn.ExpectQuery(expectedQuery).
-		WithArgs(taskId).
+		WithArgs(taskId, testOwnerId).
 		WillReturnError(errors.New("connection error"))
 
-	exists, err := CheckExistence(taskId)
+	exists, err := repository.CheckExistence(context.Background(), testOwnerId, taskId)
 
 	assert.Error(t, err, "Expected error from DB failure")
 	assert.False(t, exists, "Should return false on DB error")
 	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
 }
+
+func TestQueryTaskTimeout(t *testing.T) {
+	mockConn, _ := setMockConnection()
+	defer mockConn.Close()
+	repository := NewPostgresTaskRepository(mockConn, time.Millisecond)
+
+	expectedQuery := "SELECT id, title, description, status, priority, created_at, due_date, estimate_hours, owner_id, COALESCE\\(project_id, 0\\), deleted_at, version, COALESCE\\(parent_id, 0\\), " + checklistCountPattern + ", " + tagNamesPattern + ", " + commentCountPattern + ", COALESCE\\(series_id, 0\\), " + recurrencePattern + " FROM tasks WHERE id=\\$1 AND deleted_at IS NULL AND " + taskAccessPattern(2) + ";"
+	mockConn.ExpectQuery(expectedQuery).
+		WithArgs(uint(1), testOwnerId).
+		WillReturnRows(pgxmock.NewRows([]string{"id"})).
+		WillDelayFor(time.Second)
+
+	_, err := repository.QueryTask(context.Background(), testOwnerId, 1)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Expected deadline error when query exceeds the timeout")
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"context"
+)
+
+// Key of the advisory lock serializing the parent changes
+const parentsLockKey = 707372
+
+// Ids of the subtasks out of the trash of $1, and of their subtasks recursively
+const descendantsQuery = `
+	WITH RECURSIVE descendants(id) AS (
+		SELECT id FROM tasks WHERE parent_id = $1 AND deleted_at IS NULL
+		UNION
+		SELECT t.id FROM tasks t JOIN descendants d ON t.parent_id = d.id WHERE t.deleted_at IS NULL
+	)
+	SELECT id FROM descendants
+`
+
+// Ids of the parents of $1 recursively, UNION ending the walk if the parents already loop
+const ancestorsQuery = `
+	WITH RECURSIVE ancestors(id, parent_id) AS (
+		SELECT id, parent_id FROM tasks WHERE id = $1
+		UNION
+		SELECT t.id, t.parent_id FROM tasks t JOIN ancestors a ON t.id = a.parent_id
+	)
+	SELECT id FROM ancestors WHERE id <> $1;
+`
+
+func (r *PostgresTaskRepository) QuerySubtasks(ctx context.Context, userId uint, taskId uint) ([]Task, error) {
+	return r.queryTaskList(ctx, "SELECT "+taskColumns+" FROM tasks WHERE parent_id=$1 AND "+activeTaskCondition(2)+" ORDER BY id;", taskId, userId)
+}
+
+func (r *PostgresTaskRepository) QueryDescendants(ctx context.Context, userId uint, taskId uint) ([]Task, error) {
+	return r.queryTaskList(ctx, "SELECT "+taskColumns+" FROM tasks WHERE id IN ("+descendantsQuery+") AND "+activeTaskCondition(2)+" ORDER BY id;", taskId, userId)
+}
+
+func (r *PostgresTaskRepository) QueryAncestorIds(ctx context.Context, taskId uint) ([]uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).Query(ctx, ancestorsQuery, taskId)
+	if err != nil {
+		return []uint{}, err
+	}
+
+	defer rows.Close()
+
+	ancestorIds := []uint{}
+	for rows.Next() {
+		var ancestorId uint
+		if err = rows.Scan(&ancestorId); err != nil {
+			return []uint{}, err
+		}
+		ancestorIds = append(ancestorIds, ancestorId)
+	}
+
+	if err = rows.Err(); err != nil {
+		return []uint{}, err
+	}
+
+	return ancestorIds, nil
+}
+
+func (r *PostgresTaskRepository) LockParents(ctx context.Context) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	_, err := r.conn(ctx).Exec(ctx, "SELECT pg_advisory_xact_lock($1);", parentsLockKey)
+	return err
+}
+
+// Runs a query selecting the taskColumns of many tasks
+func (r *PostgresTaskRepository) queryTaskList(ctx context.Context, query string, args ...any) ([]Task, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).Query(ctx, query, args...)
+	if err != nil {
+		return []Task{}, err
+	}
+	defer rows.Close()
+
+	tasks := []Task{}
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return []Task{}, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return []Task{}, err
+	}
+
+	return tasks, nil
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Subtasks Tests ///////////////////////////////////
+func TestQuerySubtasks(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	subtask := getTestTasksList()[1]
+	expectedQuery := "SELECT " + regexp.QuoteMeta(taskColumns) + " FROM tasks WHERE parent_id=\\$1 AND deleted_at IS NULL AND " + taskAccessPattern(2) + " ORDER BY id;"
+	mockConn.ExpectQuery(expectedQuery).
+		WithArgs(uint(1), testOwnerId).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date", "estimate_hours", "owner_id", "project_id", "deleted_at", "version", "parent_id", "checklist_total", "checklist_checked", "tags", "comment_count", "series_id", "recurrence"}).AddRow(
+			subtask.Id, subtask.Title, subtask.Description, subtask.Status, subtask.Priority, subtask.CreatedAt, subtask.DueDate, subtask.EstimateHours, subtask.OwnerId, subtask.ProjectId, nil, uint(1), uint(1), uint(0), uint(0), []string{}, uint(0), uint(0), ""))
+
+	subtasks, err := repository.QuerySubtasks(context.Background(), testOwnerId, 1)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, 1, len(subtasks), "Returned list should have 1 element")
+	assert.Equal(t, uint(1), subtasks[0].ParentId, "Returned ParentId should match the row")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestQueryDescendants(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	expectedQuery := "FROM tasks WHERE id IN \\(\\s+WITH RECURSIVE descendants\\(id\\) AS .+\\) AND deleted_at IS NULL AND " + taskAccessPattern(2) + " ORDER BY id;"
+	mockConn.ExpectQuery(expectedQuery).
+		WithArgs(uint(1), testOwnerId).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date", "estimate_hours", "owner_id", "project_id", "deleted_at", "version", "parent_id", "checklist_total", "checklist_checked", "tags", "comment_count", "series_id", "recurrence"}))
+
+	subtasks, err := repository.QueryDescendants(context.Background(), testOwnerId, 1)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Empty(t, subtasks, "Returned list should be empty")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+// Memory Subtasks Tests ///////////////////////////////////
+func getTestSubtasksRepository() *MemoryTaskRepository {
+	repository := getTestMemoryRepository()
+	for _, parentId := range []uint{1, 4, 1} {
+		repository.AddTask(context.Background(), Task{Title: "Subtask", OwnerId: testOwnerId, ParentId: parentId})
+	}
+	return repository
+}
+
+func TestMemoryQuerySubtasks(t *testing.T) {
+	repository := getTestSubtasksRepository()
+
+	subtasks, err := repository.QuerySubtasks(context.Background(), testOwnerId, 1)
+	assert.NoError(t, err, "Unexpected error querying subtasks")
+	assert.Equal(t, []uint{4, 6}, []uint{subtasks[0].Id, subtasks[1].Id}, "Only the direct subtasks should be listed")
+
+	descendants, err := repository.QueryDescendants(context.Background(), testOwnerId, 1)
+	assert.NoError(t, err, "Unexpected error querying descendants")
+	assert.Len(t, descendants, 3, "Subtasks of the subtasks should be listed")
+
+	repository.DeleteTask(context.Background(), testOwnerId, 4, 1)
+	descendants, _ = repository.QueryDescendants(context.Background(), testOwnerId, 1)
+	assert.Equal(t, uint(6), descendants[0].Id, "Deleted subtask and its subtasks should not be listed")
+
+	descendants, _ = repository.QueryDescendants(context.Background(), testOwnerId+1, 1)
+	assert.Empty(t, descendants, "Subtasks of other users should not be listed")
+}
+
+func TestQueryAncestorIds(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectQuery("WITH RECURSIVE ancestors\\(id, parent_id\\) AS .+ SELECT id FROM ancestors WHERE id <> \\$1;").
+		WithArgs(uint(5)).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(4)).AddRow(uint(1)))
+
+	ancestorIds, err := repository.QueryAncestorIds(context.Background(), 5)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, []uint{4, 1}, ancestorIds)
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestLockParents(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectExec("SELECT pg_advisory_xact_lock\\(\\$1\\);").
+		WithArgs(parentsLockKey).
+		WillReturnResult(pgxmock.NewResult("SELECT", 1))
+
+	err := repository.LockParents(context.Background())
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestMemoryQueryAncestorIds(t *testing.T) {
+	repository := getTestSubtasksRepository()
+	repository.DeleteTask(context.Background(), testOwnerId, 4, 1)
+
+	ancestorIds, err := repository.QueryAncestorIds(context.Background(), 5)
+
+	assert.NoError(t, err, "Unexpected error querying ancestors")
+	assert.Equal(t, []uint{4, 1}, ancestorIds, "Parents in the trash should be listed")
+}
+
+func TestMemoryDeleteInTransaction(t *testing.T) {
+	repository := getTestSubtasksRepository()
+
+	repository.RunInTransaction(context.Background(), func(ctx context.Context) error {
+		repository.DeleteTask(ctx, testOwnerId, 4, 1)
+		time.Sleep(time.Millisecond)
+		return repository.DeleteTask(ctx, testOwnerId, 5, 1)
+	})
+
+	trash, _ := repository.QueryDeletedTasks(context.Background(), testOwnerId)
+	assert.Len(t, trash, 2)
+	assert.Equal(t, trash[0].DeletedAt, trash[1].DeletedAt, "Tasks deleted in one transaction should share the deletion time")
+}
+
+func TestMemoryPurgeParent(t *testing.T) {
+	repository := getTestSubtasksRepository()
+
+	repository.DeleteTask(context.Background(), testOwnerId, 4, 1)
+	repository.PurgeDeletedTasks(context.Background(), time.Now().Add(time.Minute))
+
+	subtask, _ := repository.QueryTask(context.Background(), testOwnerId, 5)
+	assert.Equal(t, uint(0), subtask.ParentId, "Subtasks of a purged task should become top level tasks")
+}
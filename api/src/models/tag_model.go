@@ -0,0 +1,133 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+)
+
+// Names of the tags of the task, in alphabetical order
+const tagNamesColumn = "ARRAY(SELECT DISTINCT g.name FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE tt.task_id = tasks.id ORDER BY g.name)"
+
+func (r *PostgresTaskRepository) AddTag(ctx context.Context, newTag Tag) (uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	newTagQuery := `
+		INSERT INTO tags (owner_id, name, color)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (owner_id, name) DO NOTHING
+		RETURNING id;
+	`
+
+	var tagId uint
+	err := r.conn(ctx).QueryRow(ctx, newTagQuery, newTag.OwnerId, newTag.Name, newTag.Color).Scan(&tagId)
+	// Nothing is returned when the name is already taken
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrTagExists
+	}
+
+	return tagId, err
+}
+
+func (r *PostgresTaskRepository) QueryTag(ctx context.Context, userId uint, tagId uint) (Tag, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	var tag Tag
+	err := r.conn(ctx).QueryRow(ctx, "SELECT id, owner_id, name, color FROM tags WHERE id = $1 AND owner_id = $2;", tagId, userId).
+		Scan(&tag.Id, &tag.OwnerId, &tag.Name, &tag.Color)
+
+	return tag, err
+}
+
+func (r *PostgresTaskRepository) QueryTags(ctx context.Context, userId uint) ([]Tag, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).Query(ctx, "SELECT id, owner_id, name, color FROM tags WHERE owner_id = $1 ORDER BY name;", userId)
+	if err != nil {
+		return []Tag{}, err
+	}
+	defer rows.Close()
+
+	tags := []Tag{}
+	for rows.Next() {
+		var tag Tag
+		if err = rows.Scan(&tag.Id, &tag.OwnerId, &tag.Name, &tag.Color); err != nil {
+			return []Tag{}, err
+		}
+		tags = append(tags, tag)
+	}
+
+	if err = rows.Err(); err != nil {
+		return []Tag{}, err
+	}
+
+	return tags, nil
+}
+
+func (r *PostgresTaskRepository) UpdateTag(ctx context.Context, updatedTag Tag) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	updateTagQuery := `
+		UPDATE tags SET name = $1, color = $2
+		WHERE id = $3 AND owner_id = $4
+		AND NOT EXISTS (SELECT 1 FROM tags t WHERE t.owner_id = $4 AND t.name = $1 AND t.id <> $3);
+	`
+
+	result, err := r.conn(ctx).Exec(ctx, updateTagQuery, updatedTag.Name, updatedTag.Color, updatedTag.Id, updatedTag.OwnerId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		// Either the tag is unknown or another tag has the name
+		if _, err = r.QueryTag(ctx, updatedTag.OwnerId, updatedTag.Id); err != nil {
+			return err
+		}
+		return ErrTagExists
+	}
+
+	return nil
+}
+
+func (r *PostgresTaskRepository) DeleteTag(ctx context.Context, userId uint, tagId uint) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	// The labels of the tasks are removed by the foreign key cascade
+	result, err := r.conn(ctx).Exec(ctx, "DELETE FROM tags WHERE id = $1 AND owner_id = $2;", tagId, userId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PostgresTaskRepository) AddTaskTag(ctx context.Context, taskId uint, tagId uint) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	_, err := r.conn(ctx).Exec(ctx, "INSERT INTO task_tags (task_id, tag_id) VALUES ($1, $2) ON CONFLICT DO NOTHING;", taskId, tagId)
+
+	return err
+}
+
+func (r *PostgresTaskRepository) DeleteTaskTag(ctx context.Context, taskId uint, tagId uint) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := r.conn(ctx).Exec(ctx, "DELETE FROM task_tags WHERE task_id = $1 AND tag_id = $2;", taskId, tagId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
@@ -0,0 +1,99 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Tags Tests ///////////////////////////////////
+func TestAddTag(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectQuery("INSERT INTO tags \\(owner_id, name, color\\) VALUES \\(\\$1, \\$2, \\$3\\) ON CONFLICT \\(owner_id, name\\) DO NOTHING RETURNING id;").
+		WithArgs(testOwnerId, "bug", "#ff0000").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(2)))
+
+	tagId, err := repository.AddTag(context.Background(), Tag{OwnerId: testOwnerId, Name: "bug", Color: "#ff0000"})
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, uint(2), tagId, "Returned value should be 2")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestAddTagNameTaken(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectQuery("INSERT INTO tags").
+		WithArgs(testOwnerId, "bug", "#ff0000").
+		WillReturnRows(pgxmock.NewRows([]string{"id"}))
+
+	_, err := repository.AddTag(context.Background(), Tag{OwnerId: testOwnerId, Name: "bug", Color: "#ff0000"})
+
+	assert.ErrorIs(t, err, ErrTagExists, "Should reject a name already taken")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestUpdateTagNameTaken(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectExec("UPDATE tags SET name = \\$1, color = \\$2 WHERE id = \\$3 AND owner_id = \\$4 AND NOT EXISTS").
+		WithArgs("bug", "#ff0000", uint(2), testOwnerId).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+	mockConn.ExpectQuery("SELECT id, owner_id, name, color FROM tags WHERE id = \\$1 AND owner_id = \\$2;").
+		WithArgs(uint(2), testOwnerId).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "owner_id", "name", "color"}).AddRow(uint(2), testOwnerId, "urgent", "#808080"))
+
+	err := repository.UpdateTag(context.Background(), Tag{Id: 2, OwnerId: testOwnerId, Name: "bug", Color: "#ff0000"})
+
+	assert.ErrorIs(t, err, ErrTagExists, "Should reject a name taken by another tag")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestDeleteTaskTagNotFound(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectExec("DELETE FROM task_tags WHERE task_id = \\$1 AND tag_id = \\$2;").
+		WithArgs(uint(1), uint(2)).
+		WillReturnResult(pgxmock.NewResult("DELETE", 0))
+
+	err := repository.DeleteTaskTag(context.Background(), 1, 2)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Should return ErrNoRows for a task not labeled with the tag")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+// Memory Tags Tests ///////////////////////////////////
+func TestMemoryTaskTags(t *testing.T) {
+	repository := getTestMemoryRepository()
+	bugId, _ := repository.AddTag(context.Background(), Tag{OwnerId: testOwnerId, Name: "bug"})
+	urgentId, _ := repository.AddTag(context.Background(), Tag{OwnerId: testOwnerId, Name: "urgent"})
+	repository.AddTaskTag(context.Background(), 1, urgentId)
+	repository.AddTaskTag(context.Background(), 1, bugId)
+	repository.AddTaskTag(context.Background(), 2, bugId)
+
+	_, err := repository.AddTag(context.Background(), Tag{OwnerId: testOwnerId, Name: "bug"})
+	assert.ErrorIs(t, err, ErrTagExists, "Duplicated name should be rejected")
+
+	task, _ := repository.QueryTask(context.Background(), testOwnerId, 1)
+	assert.Equal(t, []string{"bug", "urgent"}, task.Tags, "Task should list its tags by name")
+
+	pageConfig := TasksPaginationQuery{SortBy: "id", SortOrder: "ASC", Limit: 10}
+	tasks, _ := repository.QueryTasks(context.Background(), testOwnerId, []TasksFilterQuery{{Column: "tags", Value: "bug,urgent", Match: MatchAny}}, pageConfig)
+	assert.Len(t, tasks, 2, "Tasks with any of the tags should match")
+	tasks, _ = repository.QueryTasks(context.Background(), testOwnerId, []TasksFilterQuery{{Column: "tags", Value: "bug,urgent", Match: MatchAll}}, pageConfig)
+	assert.Len(t, tasks, 1, "Only the task with all the tags should match")
+
+	assert.NoError(t, repository.DeleteTag(context.Background(), testOwnerId, bugId))
+	task, _ = repository.QueryTask(context.Background(), testOwnerId, 2)
+	assert.Empty(t, task.Tags, "Deleted tag should be removed from the tasks")
+	assert.ErrorIs(t, repository.DeleteTaskTag(context.Background(), 2, bugId), sql.ErrNoRows)
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"context"
+	"errors"
+)
+
+var ErrTagExists = errors.New("tag name already taken")
+
+// Tag labels tasks. Each user has its own tags, the tasks showing the tags of every user who labeled them.
+type Tag struct {
+	Id      uint
+	OwnerId uint
+	Name    string // unique among the tags of the owner
+	Color   string // hexadecimal RGB, as #rrggbb
+}
+
+// TagRepository stores the tags and the tasks they label. Tags of other users are handled
+// as if they did not exist, access to the tasks being checked by the caller.
+type TagRepository interface {
+	// AddTag fails with ErrTagExists when the owner already has a tag with the name
+	AddTag(ctx context.Context, newTag Tag) (uint, error)
+	// QueryTag fails with sql.ErrNoRows for unknown tags
+	QueryTag(ctx context.Context, userId uint, tagId uint) (Tag, error)
+	// QueryTags lists the tags of the user ordered by name
+	QueryTags(ctx context.Context, userId uint) ([]Tag, error)
+	// UpdateTag fails with sql.ErrNoRows for unknown tags, and with ErrTagExists when the name is taken
+	UpdateTag(ctx context.Context, updatedTag Tag) error
+	// DeleteTag removes the tag from the tasks, failing with sql.ErrNoRows for unknown tags
+	DeleteTag(ctx context.Context, userId uint, tagId uint) error
+
+	// AddTaskTag labels the task with the tag, doing nothing when it already is
+	AddTaskTag(ctx context.Context, taskId uint, tagId uint) error
+	// DeleteTaskTag fails with sql.ErrNoRows when the task is not labeled with the tag
+	DeleteTaskTag(ctx context.Context, taskId uint, tagId uint) error
+}
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"context"
+	"encoding/json"
+)
+
+func (r *PostgresTaskRepository) AddTaskEvent(ctx context.Context, event TaskEvent) (uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	changes, err := json.Marshal(event.Changes)
+	if err != nil {
+		return 0, err
+	}
+
+	newEventQuery := `
+		INSERT INTO task_events (task_id, actor_id, action, changes, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id;
+	`
+
+	var eventId uint
+	err = r.conn(ctx).QueryRow(ctx, newEventQuery, event.TaskId, nullableId(event.ActorId), event.Action, changes, event.CreatedAt).Scan(&eventId)
+
+	return eventId, err
+}
+
+func (r *PostgresTaskRepository) QueryTaskEvents(ctx context.Context, taskId uint) ([]TaskEvent, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	events := []TaskEvent{}
+	rows, err := r.conn(ctx).Query(ctx, "SELECT id, task_id, COALESCE(actor_id, 0), action, changes, created_at FROM task_events WHERE task_id = $1 ORDER BY id;", taskId)
+	if err != nil {
+		return events, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var event TaskEvent
+		var changes []byte
+		if err = rows.Scan(&event.Id, &event.TaskId, &event.ActorId, &event.Action, &changes, &event.CreatedAt); err != nil {
+			return []TaskEvent{}, err
+		}
+		if err = json.Unmarshal(changes, &event.Changes); err != nil {
+			return []TaskEvent{}, err
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return []TaskEvent{}, err
+	}
+
+	return events, nil
+}
@@ -0,0 +1,72 @@
+package models
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Task Events Tests ///////////////////////////////////
+func TestAddTaskEvent(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	event := TaskEvent{
+		TaskId:    1,
+		Action:    ActionUpdate,
+		Changes:   []FieldChange{{Field: "status", Before: "backlog", After: "in-progress"}},
+		CreatedAt: time.Now(),
+	}
+
+	mockConn.ExpectQuery("INSERT INTO task_events \\(task_id, actor_id, action, changes, created_at\\) VALUES \\(\\$1, \\$2, \\$3, \\$4, \\$5\\) RETURNING id;").
+		WithArgs(uint(1), nil, ActionUpdate, []byte(`[{"field":"status","before":"backlog","after":"in-progress"}]`), event.CreatedAt).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(3)))
+
+	eventId, err := repository.AddTaskEvent(context.Background(), event)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, uint(3), eventId, "Returned wrong event id")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestQueryTaskEvents(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	createdAt := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	mockConn.ExpectQuery("SELECT id, task_id, COALESCE\\(actor_id, 0\\), action, changes, created_at FROM task_events WHERE task_id = \\$1 ORDER BY id;").
+		WithArgs(uint(1)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "task_id", "actor_id", "action", "changes", "created_at"}).
+			AddRow(uint(1), uint(1), testOwnerId, ActionCreate, []byte(`[{"field":"title","before":null,"after":"task"}]`), createdAt))
+
+	events, err := repository.QueryTaskEvents(context.Background(), 1)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, []TaskEvent{{
+		Id:        1,
+		TaskId:    1,
+		ActorId:   testOwnerId,
+		Action:    ActionCreate,
+		Changes:   []FieldChange{{Field: "title", Before: nil, After: "task"}},
+		CreatedAt: createdAt,
+	}}, events, "Returned wrong events")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestMemoryTaskEventsKeptAfterDelete(t *testing.T) {
+	repository := getTestMemoryRepository()
+	repository.AddTaskEvent(context.Background(), TaskEvent{TaskId: 1, Action: ActionCreate})
+	repository.AddTaskEvent(context.Background(), TaskEvent{TaskId: 2, Action: ActionCreate})
+	repository.AddTaskEvent(context.Background(), TaskEvent{TaskId: 1, Action: ActionDelete})
+	repository.DeleteTask(context.Background(), testOwnerId, 1, 1)
+
+	events, err := repository.QueryTaskEvents(context.Background(), 1)
+
+	assert.NoError(t, err, "Unexpected error querying events")
+	assert.Len(t, events, 2, "Only the events of the task should be returned")
+	assert.Equal(t, ActionDelete, events[1].Action, "Events should be ordered oldest first")
+}
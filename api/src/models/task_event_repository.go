@@ -0,0 +1,39 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// Actions recorded on the history of the tasks
+const (
+	ActionCreate  = "create"
+	ActionUpdate  = "update"
+	ActionDelete  = "delete"
+	ActionRestore = "restore"
+)
+
+// TaskEvent is a change done on a task
+type TaskEvent struct {
+	Id        uint
+	TaskId    uint
+	ActorId   uint // user who made the change, 0 when unknown
+	Action    string
+	Changes   []FieldChange
+	CreatedAt time.Time
+}
+
+// FieldChange holds the value of a task field before and after a change,
+// nil before the task creation and after its deletion
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before any    `json:"before"`
+	After  any    `json:"after"`
+}
+
+// TaskEventRepository stores the history of the tasks
+type TaskEventRepository interface {
+	AddTaskEvent(ctx context.Context, event TaskEvent) (uint, error)
+	// QueryTaskEvents lists the events of the task, oldest first
+	QueryTaskEvents(ctx context.Context, taskId uint) ([]TaskEvent, error)
+}
@@ -0,0 +1,62 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrVersionConflict is returned when the task changed since the version passed to the update
+var ErrVersionConflict = errors.New("task was modified concurrently")
+
+// TaskRepository defines the storage operations available for tasks.
+// The service layer only depends on this interface, so the storage backend
+// (Postgres, in-memory, ...) can be chosen at startup.
+// Every operation is scoped to the tasks of userId: tasks of other users
+// behave as if they did not exist. DeleteTask moves the task to the trash,
+// and the other operations handle tasks in the trash as deleted.
+type TaskRepository interface {
+	AddTask(ctx context.Context, newTask Task) (uint, error)
+	QueryTask(ctx context.Context, userId uint, taskId uint) (Task, error)
+	// UpdateTask only applies when the stored task still has updatedTask.Version,
+	// failing with ErrVersionConflict otherwise, and increments the version
+	UpdateTask(ctx context.Context, userId uint, updatedTask Task) error
+	// DeleteTask only applies when the stored task still has version, failing with ErrVersionConflict otherwise
+	DeleteTask(ctx context.Context, userId uint, taskId uint, version uint) error
+	CheckExistence(ctx context.Context, userId uint, taskId uint) (bool, error)
+	QueryTasks(ctx context.Context, userId uint, filterConfig []TasksFilterQuery, pageConfig TasksPaginationQuery) ([]Task, error)
+	GetAmountOfTasks(ctx context.Context, userId uint) (uint, error)
+	// QuerySubtasks lists the subtasks of the task, QueryDescendants also their own subtasks recursively
+	QuerySubtasks(ctx context.Context, userId uint, taskId uint) ([]Task, error)
+	QueryDescendants(ctx context.Context, userId uint, taskId uint) ([]Task, error)
+	// QueryAncestorIds lists the ids of the parents of the task up to its top level task,
+	// including the ones in the trash or the user can not access
+	QueryAncestorIds(ctx context.Context, taskId uint) ([]uint, error)
+	// LockParents serializes the parent changes until the surrounding transaction ends,
+	// so two concurrent moves can not create a cycle together
+	LockParents(ctx context.Context) error
+}
+
+// Shared by the Postgres repositories
+type postgresRepository struct {
+	db           Database
+	queryTimeout time.Duration
+}
+
+// PostgresTaskRepository stores tasks on a Postgres database
+type PostgresTaskRepository struct {
+	postgresRepository
+}
+
+// NewPostgresTaskRepository creates the repository over the shared database pool.
+// Each query is bounded by queryTimeout (no bound when 0) on top of the caller context.
+func NewPostgresTaskRepository(db Database, queryTimeout time.Duration) *PostgresTaskRepository {
+	return &PostgresTaskRepository{postgresRepository{db: db, queryTimeout: queryTimeout}}
+}
+
+func (r *postgresRepository) withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if r.queryTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, r.queryTimeout)
+}
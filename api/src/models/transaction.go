@@ -0,0 +1,86 @@
+package models
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Transactor runs several repository operations atomically
+type Transactor interface {
+	// RunInTransaction runs fn with a context sharing one transaction between the repository
+	// operations, committed when fn returns nil and rolled back otherwise. Nested calls run in
+	// a savepoint of the outer transaction.
+	RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type txContextKey struct{}
+
+// Operations shared by the connection pool and the transactions
+type dbConn interface {
+	Begin(context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+}
+
+// Transaction of the context when running in RunInTransaction, the pool otherwise
+func (r *postgresRepository) conn(ctx context.Context) dbConn {
+	if tx, found := ctx.Value(txContextKey{}).(pgx.Tx); found {
+		return tx
+	}
+	return r.db
+}
+
+func (r *postgresRepository) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	// Inside a transaction, Begin creates a savepoint
+	tx, err := r.conn(ctx).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err = fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *MemoryTaskRepository) RunInTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if _, found := ctx.Value(memoryTxTimeKey{}).(time.Time); !found {
+		ctx = context.WithValue(ctx, memoryTxTimeKey{}, time.Now())
+	}
+
+	// Changes are undone by restoring the state from before fn. Unlike the SQL transactions,
+	// this is not isolated from the concurrent requests, which is enough for demos and tests.
+	r.mu.RLock()
+	snapshot := r.copyState()
+	r.mu.RUnlock()
+
+	if err := fn(ctx); err != nil {
+		r.mu.Lock()
+		r.restoreState(snapshot)
+		r.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+type memoryTxTimeKey struct{}
+
+// Start time of the memory transaction of the context, now outside of one. Same as now() in SQL,
+// so the tasks deleted together share their deletion time.
+func transactionTime(ctx context.Context) time.Time {
+	if txTime, found := ctx.Value(memoryTxTimeKey{}).(time.Time); found {
+		return txTime
+	}
+	return time.Now()
+}
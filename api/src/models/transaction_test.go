@@ -0,0 +1,74 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Transaction Tests ///////////////////////////////////
+func TestRunInTransactionCommit(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectBegin()
+	mockConn.ExpectExec("UPDATE tasks SET deleted_at = now\\(\\)").
+		WithArgs(uint(1), testOwnerId, uint(1)).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 1))
+	mockConn.ExpectCommit()
+	mockConn.ExpectRollback()
+
+	err := repository.RunInTransaction(context.Background(), func(ctx context.Context) error {
+		return repository.DeleteTask(ctx, testOwnerId, 1, 1)
+	})
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestRunInTransactionRollback(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	failure := errors.New("operation failed")
+	mockConn.ExpectBegin()
+	mockConn.ExpectBegin() // savepoint of the nested transaction
+	mockConn.ExpectRollback()
+	mockConn.ExpectRollback()
+
+	err := repository.RunInTransaction(context.Background(), func(ctx context.Context) error {
+		return repository.RunInTransaction(ctx, func(ctx context.Context) error {
+			return failure
+		})
+	})
+
+	assert.ErrorIs(t, err, failure, "Should return the error of the operations")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+// Memory Transaction Tests ///////////////////////////////////
+func TestMemoryRunInTransactionRollback(t *testing.T) {
+	repository := getTestMemoryRepository()
+	repository.AddDependency(context.Background(), 1, 2)
+
+	failure := errors.New("operation failed")
+	err := repository.RunInTransaction(context.Background(), func(ctx context.Context) error {
+		repository.DeleteTask(ctx, testOwnerId, 1, 1)
+		repository.DeleteDependency(ctx, 1, 2)
+		repository.AddTask(ctx, Task{Title: "New Task", OwnerId: testOwnerId})
+		return failure
+	})
+
+	assert.ErrorIs(t, err, failure, "Should return the error of the operations")
+	_, err = repository.QueryTask(context.Background(), testOwnerId, 1)
+	assert.NoError(t, err, "Deleted task should be back after the rollback")
+	_, err = repository.QueryTask(context.Background(), testOwnerId, 4)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Created task should be removed by the rollback")
+	dependencies, _ := repository.QueryDependencies(context.Background(), 1)
+	assert.Equal(t, []uint{2}, dependencies, "Deleted dependency should be back after the rollback")
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// Condition matching the tasks in the trash the user (query parameter number param) can access
+func deletedTaskCondition(param int) string {
+	return "deleted_at IS NOT NULL AND " + taskAccessCondition(param)
+}
+
+func (r *PostgresTaskRepository) QueryDeletedTask(ctx context.Context, userId uint, taskId uint) (Task, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	return scanTask(r.conn(ctx).QueryRow(ctx, "SELECT "+taskColumns+" FROM tasks WHERE id=$1 AND "+deletedTaskCondition(2)+";", taskId, userId))
+}
+
+func (r *PostgresTaskRepository) QueryDeletedTasks(ctx context.Context, userId uint) ([]Task, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	rows, err := r.conn(ctx).Query(ctx, "SELECT "+taskColumns+" FROM tasks WHERE "+deletedTaskCondition(1)+" ORDER BY deleted_at DESC, id;", userId)
+	if err != nil {
+		return []Task{}, err
+	}
+	defer rows.Close()
+
+	tasks := []Task{}
+	for rows.Next() {
+		task, err := scanTask(rows)
+		if err != nil {
+			return []Task{}, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	if err = rows.Err(); err != nil {
+		return []Task{}, err
+	}
+
+	return tasks, nil
+}
+
+func (r *PostgresTaskRepository) RestoreTask(ctx context.Context, userId uint, taskId uint) error {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	result, err := r.conn(ctx).Exec(ctx, "UPDATE tasks SET deleted_at = NULL, version = version + 1 WHERE id=$1 AND "+deletedTaskCondition(2)+";", taskId, userId)
+	if err != nil {
+		return err
+	}
+	if result.RowsAffected() == 0 {
+		return sql.ErrNoRows
+	}
+
+	return nil
+}
+
+func (r *PostgresTaskRepository) PurgeDeletedTasks(ctx context.Context, deletedBefore time.Time) (uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	// Dependencies on the purged tasks are removed by the foreign key cascade
+	result, err := r.conn(ctx).Exec(ctx, "DELETE FROM tasks WHERE deleted_at < $1;", deletedBefore)
+	if err != nil {
+		return 0, err
+	}
+
+	return uint(result.RowsAffected()), nil
+}
@@ -0,0 +1,113 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// Trash Tests ///////////////////////////////////
+func TestQueryDeletedTasks(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	testTask := getTestTasksList()[0]
+	deletedAt := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	expectedQuery := "SELECT id, title, description, status, priority, created_at, due_date, estimate_hours, owner_id, COALESCE\\(project_id, 0\\), deleted_at, version, COALESCE\\(parent_id, 0\\), " + checklistCountPattern + ", " + tagNamesPattern + ", " + commentCountPattern + ", COALESCE\\(series_id, 0\\), " + recurrencePattern + " FROM tasks WHERE deleted_at IS NOT NULL AND " + taskAccessPattern(1) + " ORDER BY deleted_at DESC, id;"
+	mockConn.ExpectQuery(expectedQuery).
+		WithArgs(testOwnerId).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "title", "description", "status", "priority", "created_at", "due_date", "estimate_hours", "owner_id", "project_id", "deleted_at", "version", "parent_id", "checklist_total", "checklist_checked", "tags", "comment_count", "series_id", "recurrence"}).AddRow(
+			testTask.Id, testTask.Title, testTask.Description, testTask.Status, testTask.Priority, testTask.CreatedAt, testTask.DueDate, testTask.EstimateHours, testTask.OwnerId, testTask.ProjectId, &deletedAt, uint(2), uint(0), uint(0), uint(0), []string{}, uint(0), uint(0), ""))
+
+	tasks, err := repository.QueryDeletedTasks(context.Background(), testOwnerId)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, 1, len(tasks), "Returned list should have 1 element")
+	assert.Equal(t, deletedAt, tasks[0].DeletedAt, "Returned DeletedAt should match the row")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestRestoreTaskNotInTrash(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	expectedQuery := "UPDATE tasks SET deleted_at = NULL, version = version \\+ 1 WHERE id=\\$1 AND deleted_at IS NOT NULL AND " + taskAccessPattern(2) + ";"
+	mockConn.ExpectExec(expectedQuery).
+		WithArgs(uint(1), testOwnerId).
+		WillReturnResult(pgxmock.NewResult("UPDATE", 0))
+
+	err := repository.RestoreTask(context.Background(), testOwnerId, 1)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Should return ErrNoRows for task not in the trash")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestPurgeDeletedTasks(t *testing.T) {
+	mockConn, repository := setMockConnection()
+	defer mockConn.Close()
+
+	deletedBefore := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	mockConn.ExpectExec("DELETE FROM tasks WHERE deleted_at < \\$1;").
+		WithArgs(deletedBefore).
+		WillReturnResult(pgxmock.NewResult("DELETE", 2))
+
+	purged, err := repository.PurgeDeletedTasks(context.Background(), deletedBefore)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, uint(2), purged, "Returned wrong amount of purged tasks")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+// Memory Trash Tests ///////////////////////////////////
+func TestMemoryDeleteAndRestoreTask(t *testing.T) {
+	repository := getTestMemoryRepository()
+
+	repository.DeleteTask(context.Background(), testOwnerId, 1, 1)
+
+	_, err := repository.QueryTask(context.Background(), testOwnerId, 1)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Deleted task should not be found")
+	tasksAmount, _ := repository.GetAmountOfTasks(context.Background(), testOwnerId)
+	assert.Equal(t, uint(2), tasksAmount, "Deleted task should not be counted")
+
+	deletedTasks, err := repository.QueryDeletedTasks(context.Background(), testOwnerId)
+	assert.NoError(t, err, "Unexpected error querying the trash")
+	assert.Equal(t, 1, len(deletedTasks), "Trash should have 1 element")
+	assert.False(t, deletedTasks[0].DeletedAt.IsZero(), "Deleted task should have DeletedAt")
+
+	otherTrash, _ := repository.QueryDeletedTasks(context.Background(), testOwnerId+1)
+	assert.Empty(t, otherTrash, "Trash of other users should not be listed")
+	err = repository.RestoreTask(context.Background(), testOwnerId+1, 1)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Other users should not restore the task")
+
+	err = repository.RestoreTask(context.Background(), testOwnerId, 1)
+	assert.NoError(t, err, "Unexpected error restoring task")
+	queriedTask, err := repository.QueryTask(context.Background(), testOwnerId, 1)
+	assert.NoError(t, err, "Restored task should be found")
+	assert.True(t, queriedTask.DeletedAt.IsZero(), "Restored task should not have DeletedAt")
+
+	err = repository.RestoreTask(context.Background(), testOwnerId, 1)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Task not in the trash should not be restored")
+}
+
+func TestMemoryPurgeDeletedTasks(t *testing.T) {
+	repository := getTestMemoryRepository()
+	repository.DeleteTask(context.Background(), testOwnerId, 1, 1)
+
+	purged, err := repository.PurgeDeletedTasks(context.Background(), time.Now().Add(-time.Hour))
+	assert.NoError(t, err, "Unexpected error purging tasks")
+	assert.Equal(t, uint(0), purged, "Recently deleted tasks should be kept")
+
+	purged, _ = repository.PurgeDeletedTasks(context.Background(), time.Now().Add(time.Minute))
+	assert.Equal(t, uint(1), purged, "Only the deleted task should be purged")
+
+	_, err = repository.QueryDeletedTask(context.Background(), testOwnerId, 1)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Purged task should not be in the trash")
+	tasksAmount, _ := repository.GetAmountOfTasks(context.Background(), testOwnerId)
+	assert.Equal(t, uint(2), tasksAmount, "Other tasks should be kept")
+}
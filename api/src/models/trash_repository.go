@@ -0,0 +1,20 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+// TrashRepository handles the deleted tasks, kept until restored or purged.
+// Like TaskRepository, it is scoped to the tasks userId can access.
+type TrashRepository interface {
+	// QueryDeletedTask fails with sql.ErrNoRows when the task is not in the trash
+	QueryDeletedTask(ctx context.Context, userId uint, taskId uint) (Task, error)
+	// QueryDeletedTasks lists the tasks in the trash, the last deleted first
+	QueryDeletedTasks(ctx context.Context, userId uint) ([]Task, error)
+	// RestoreTask fails with sql.ErrNoRows when the task is not in the trash
+	RestoreTask(ctx context.Context, userId uint, taskId uint) error
+	// PurgeDeletedTasks removes for good the tasks of every user deleted before deletedBefore,
+	// returning how many were removed
+	PurgeDeletedTasks(ctx context.Context, deletedBefore time.Time) (uint, error)
+}
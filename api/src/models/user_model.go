@@ -0,0 +1,54 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type User struct {
+	Id           uint
+	Username     string
+	PasswordHash string
+	CreatedAt    time.Time
+}
+
+func (r *PostgresUserRepository) AddUser(ctx context.Context, newUser User) (uint, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	newUserQuery := `
+		INSERT INTO users (username, password_hash, created_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (username) DO NOTHING
+		RETURNING id;
+	`
+
+	var userId uint
+	err := r.conn(ctx).QueryRow(ctx, newUserQuery, newUser.Username, newUser.PasswordHash, newUser.CreatedAt).Scan(&userId)
+	// Nothing is returned when the username is already taken
+	if errors.Is(err, sql.ErrNoRows) {
+		return 0, ErrUserExists
+	}
+
+	return userId, err
+}
+
+func (r *PostgresUserRepository) QueryUser(ctx context.Context, userId uint) (User, error) {
+	return r.queryUser(ctx, "SELECT id, username, password_hash, created_at FROM users WHERE id = $1;", userId)
+}
+
+func (r *PostgresUserRepository) QueryUserByUsername(ctx context.Context, username string) (User, error) {
+	return r.queryUser(ctx, "SELECT id, username, password_hash, created_at FROM users WHERE username = $1;", username)
+}
+
+func (r *PostgresUserRepository) queryUser(ctx context.Context, query string, arg any) (User, error) {
+	ctx, cancel := r.withQueryTimeout(ctx)
+	defer cancel()
+
+	var user User
+	err := r.conn(ctx).QueryRow(ctx, query, arg).Scan(&user.Id, &user.Username, &user.PasswordHash, &user.CreatedAt)
+
+	return user, err
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/pashagolub/pgxmock/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func setMockUserConnection() (pgxmock.PgxPoolIface, *PostgresUserRepository) {
+	mockConn, _ := pgxmock.NewPool()
+
+	return mockConn, NewPostgresUserRepository(mockConn, 0)
+}
+
+// Users Tests ///////////////////////////////////
+func TestAddUser(t *testing.T) {
+	mockConn, repository := setMockUserConnection()
+	defer mockConn.Close()
+
+	newUser := User{Username: "alice", PasswordHash: "hash", CreatedAt: time.Now()}
+
+	mockConn.ExpectQuery("INSERT INTO users \\(username, password_hash, created_at\\) VALUES \\(\\$1, \\$2, \\$3\\) ON CONFLICT \\(username\\) DO NOTHING RETURNING id;").
+		WithArgs(newUser.Username, newUser.PasswordHash, newUser.CreatedAt).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}).AddRow(uint(1)))
+
+	userId, err := repository.AddUser(context.Background(), newUser)
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, uint(1), userId, "Returned wrong user id")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestAddUserTaken(t *testing.T) {
+	mockConn, repository := setMockUserConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectQuery("INSERT INTO users").
+		WithArgs("alice", "hash", pgxmock.AnyArg()).
+		WillReturnRows(pgxmock.NewRows([]string{"id"}))
+
+	_, err := repository.AddUser(context.Background(), User{Username: "alice", PasswordHash: "hash"})
+
+	assert.ErrorIs(t, err, ErrUserExists, "Should return ErrUserExists for taken username")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestQueryUserByUsername(t *testing.T) {
+	mockConn, repository := setMockUserConnection()
+	defer mockConn.Close()
+
+	createdAt := time.Date(2025, 2, 3, 0, 0, 0, 0, time.UTC)
+	mockConn.ExpectQuery("SELECT id, username, password_hash, created_at FROM users WHERE username = \\$1;").
+		WithArgs("alice").
+		WillReturnRows(pgxmock.NewRows([]string{"id", "username", "password_hash", "created_at"}).AddRow(uint(1), "alice", "hash", createdAt))
+
+	user, err := repository.QueryUserByUsername(context.Background(), "alice")
+
+	assert.NoError(t, err, fmt.Sprintf("Unexpected error from function - err: %v", err))
+	assert.Equal(t, User{Id: 1, Username: "alice", PasswordHash: "hash", CreatedAt: createdAt}, user, "Returned wrong user")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+func TestQueryUserNotFound(t *testing.T) {
+	mockConn, repository := setMockUserConnection()
+	defer mockConn.Close()
+
+	mockConn.ExpectQuery("SELECT id, username, password_hash, created_at FROM users WHERE id = \\$1;").
+		WithArgs(uint(1)).
+		WillReturnRows(pgxmock.NewRows([]string{"id", "username", "password_hash", "created_at"}))
+
+	_, err := repository.QueryUser(context.Background(), 1)
+
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Should return ErrNoRows for inexistent user")
+	assert.NoError(t, mockConn.ExpectationsWereMet(), "Expectations from SQL not met!")
+}
+
+// Memory Users Tests ///////////////////////////////////
+func TestMemoryAddUser(t *testing.T) {
+	repository := NewMemoryUserRepository()
+
+	userId, err := repository.AddUser(context.Background(), User{Username: "alice"})
+	assert.NoError(t, err, "Unexpected error adding user")
+
+	_, err = repository.AddUser(context.Background(), User{Username: "alice"})
+	assert.ErrorIs(t, err, ErrUserExists, "Should return ErrUserExists for taken username")
+
+	user, err := repository.QueryUserByUsername(context.Background(), "alice")
+	assert.NoError(t, err, "Unexpected error querying user")
+	assert.Equal(t, userId, user.Id, "Returned wrong user")
+
+	_, err = repository.QueryUser(context.Background(), 10)
+	assert.ErrorIs(t, err, sql.ErrNoRows, "Should return ErrNoRows for inexistent user")
+}
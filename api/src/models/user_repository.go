@@ -0,0 +1,27 @@
+package models
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var ErrUserExists = errors.New("username already taken")
+
+// UserRepository stores the API users
+type UserRepository interface {
+	// AddUser fails with ErrUserExists when the username is taken
+	AddUser(ctx context.Context, newUser User) (uint, error)
+	// QueryUser and QueryUserByUsername fail with sql.ErrNoRows for unknown users
+	QueryUser(ctx context.Context, userId uint) (User, error)
+	QueryUserByUsername(ctx context.Context, username string) (User, error)
+}
+
+// PostgresUserRepository stores users on a Postgres database
+type PostgresUserRepository struct {
+	postgresRepository
+}
+
+func NewPostgresUserRepository(db Database, queryTimeout time.Duration) *PostgresUserRepository {
+	return &PostgresUserRepository{postgresRepository{db: db, queryTimeout: queryTimeout}}
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"to-do-api/models"
+	"unicode"
+)
+
+// Largest attachment accepted when ATTACHMENT_MAX_SIZE_MB is not defined
+const defaultMaxAttachmentSizeMB = 10
+
+// Longest file name kept, longer names being truncated
+const maxAttachmentFilenameLength = 255
+
+// Content types accepted for the attachments: screenshots, logs and documents. The type is
+// detected from the content, so a file can not be served as another type than it holds.
+var allowedAttachmentTypes = []string{
+	"image/png", "image/jpeg", "image/gif", "image/webp", "image/bmp",
+	"text/plain", "application/pdf", "application/zip", "application/x-gzip",
+}
+
+var maxAttachmentSize int64 = defaultMaxAttachmentSizeMB << 20
+
+func SetMaxAttachmentSize(size int64) {
+	maxAttachmentSize = size
+}
+
+func MaxAttachmentSize() int64 {
+	return maxAttachmentSize
+}
+
+type AttachmentInfo struct {
+	Id          uint   `json:"id"`
+	Filename    string `json:"filename"`
+	ContentType string `json:"content_type"`
+	Size        int64  `json:"size"`
+	UploaderId  uint   `json:"uploader_id"`
+	Uploader    string `json:"uploader"` // username, empty when unknown
+	CreatedAt   int64  `json:"created_at"`
+}
+
+// GetAttachments lists the attachments of the task, oldest first
+func GetAttachments(ctx context.Context, taskId uint) ([]AttachmentInfo, error) {
+	if _, err := taskRepository.QueryTask(ctx, currentUser(ctx), taskId); err != nil {
+		return []AttachmentInfo{}, databaseError("Query Task", err)
+	}
+
+	attachments, err := attachmentRepository.QueryAttachments(ctx, taskId)
+	if err != nil {
+		return []AttachmentInfo{}, databaseError("Query Attachments", err)
+	}
+
+	attachmentsInfo := []AttachmentInfo{}
+	usernames := usernameCache{}
+	for _, attachment := range attachments {
+		info, err := newAttachmentInfo(ctx, usernames, attachment)
+		if err != nil {
+			return []AttachmentInfo{}, err
+		}
+		attachmentsInfo = append(attachmentsInfo, info)
+	}
+
+	return attachmentsInfo, nil
+}
+
+// AddAttachment stores the content as an attachment of the task uploaded by the current user,
+// requiring the editor role. The content must be of an allowed type and within the maximum size.
+func AddAttachment(ctx context.Context, taskId uint, filename string, content io.Reader) (uint, error) {
+	if _, err := queryTaskWithRole(ctx, taskId, models.RoleEditor); err != nil {
+		return 0, err
+	}
+
+	// The type is detected from the first 512 bytes, same as http.DetectContentType reads
+	head := make([]byte, 512)
+	headSize, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return 0, blobStoreError("Read Attachment", err)
+	}
+	head = head[:headSize]
+	if headSize == 0 {
+		return 0, fmt.Errorf("%w: attachment is empty", ErrInvalidInput)
+	}
+
+	contentType := http.DetectContentType(head)
+	if mediaType, _, _ := mime.ParseMediaType(contentType); !slices.Contains(allowedAttachmentTypes, mediaType) {
+		return 0, fmt.Errorf("%w: '%s', allowed types are %s", ErrAttachmentType, mediaType, strings.Join(allowedAttachmentTypes, ", "))
+	}
+
+	// The blob is stored before the metadata, so a failure can leave an unused blob but never metadata without contents
+	blobKey := rand.Text()
+	limited := &sizeLimitedReader{reader: io.MultiReader(bytes.NewReader(head), content), limit: maxAttachmentSize}
+	if err = blobStore.PutBlob(ctx, blobKey, limited); err != nil {
+		return 0, blobStoreError("Put Blob", err)
+	}
+
+	newAttachment := models.Attachment{
+		TaskId:      taskId,
+		UploaderId:  currentUser(ctx),
+		Filename:    attachmentFilename(filename),
+		ContentType: contentType,
+		Size:        limited.size,
+		BlobKey:     blobKey,
+		CreatedAt:   time.Now(),
+	}
+	attachmentId, err := attachmentRepository.AddAttachment(ctx, newAttachment)
+	if err != nil {
+		deleteBlobs(context.WithoutCancel(ctx), []string{blobKey})
+		return 0, databaseError("Add Attachment", err)
+	}
+
+	return attachmentId, nil
+}
+
+// OpenAttachment opens the contents of an attachment of the task for download.
+// The caller must close them.
+func OpenAttachment(ctx context.Context, taskId uint, attachmentId uint) (AttachmentInfo, io.ReadCloser, error) {
+	if _, err := taskRepository.QueryTask(ctx, currentUser(ctx), taskId); err != nil {
+		return AttachmentInfo{}, nil, databaseError("Query Task", err)
+	}
+
+	attachment, err := attachmentRepository.QueryAttachment(ctx, taskId, attachmentId)
+	if err != nil {
+		return AttachmentInfo{}, nil, databaseError("Query Attachment", err)
+	}
+
+	info, err := newAttachmentInfo(ctx, usernameCache{}, attachment)
+	if err != nil {
+		return AttachmentInfo{}, nil, err
+	}
+
+	contents, err := blobStore.OpenBlob(ctx, attachment.BlobKey)
+	if err != nil {
+		return AttachmentInfo{}, nil, blobStoreError("Open Blob", err)
+	}
+
+	return info, contents, nil
+}
+
+// DeleteAttachment removes an attachment of the task and its contents, requiring the editor role
+func DeleteAttachment(ctx context.Context, taskId uint, attachmentId uint) error {
+	if _, err := queryTaskWithRole(ctx, taskId, models.RoleEditor); err != nil {
+		return err
+	}
+
+	attachment, err := attachmentRepository.QueryAttachment(ctx, taskId, attachmentId)
+	if err != nil {
+		return databaseError("Query Attachment", err)
+	}
+
+	if err = attachmentRepository.DeleteAttachment(ctx, taskId, attachmentId); err != nil {
+		return databaseError("Delete Attachment", err)
+	}
+	deleteBlobs(ctx, []string{attachment.BlobKey})
+
+	return nil
+}
+
+// LoadMaxAttachmentSize reads the largest attachment accepted, in megabytes, from the
+// ATTACHMENT_MAX_SIZE_MB env variable
+func LoadMaxAttachmentSize() int64 {
+	value, exist := os.LookupEnv("ATTACHMENT_MAX_SIZE_MB")
+	if !exist || value == "" {
+		return defaultMaxAttachmentSizeMB << 20
+	}
+
+	size, err := strconv.ParseUint(value, 10, 16)
+	if err != nil || size == 0 {
+		log.Fatalf("Invalid ATTACHMENT_MAX_SIZE_MB value '%s', must be a number of megabytes > 0", value)
+	}
+
+	return int64(size) << 20
+}
+
+func newAttachmentInfo(ctx context.Context, usernames usernameCache, attachment models.Attachment) (AttachmentInfo, error) {
+	uploader, err := usernames.username(ctx, attachment.UploaderId)
+	if err != nil {
+		return AttachmentInfo{}, err
+	}
+
+	return AttachmentInfo{
+		Id:          attachment.Id,
+		Filename:    attachment.Filename,
+		ContentType: attachment.ContentType,
+		Size:        attachment.Size,
+		UploaderId:  attachment.UploaderId,
+		Uploader:    uploader,
+		CreatedAt:   attachment.CreatedAt.Unix(),
+	}, nil
+}
+
+// Removes the contents of removed attachments. Failures are only logged: the attachments
+// are already gone, leaving at worst unused blobs.
+func deleteBlobs(ctx context.Context, blobKeys []string) {
+	for _, blobKey := range blobKeys {
+		if err := blobStore.DeleteBlob(ctx, blobKey); err != nil {
+			fmt.Printf("Delete Blob %s failed: %v\n", blobKey, err)
+		}
+	}
+}
+
+// Base name of the uploaded file without control characters, "attachment" when there is none
+func attachmentFilename(filename string) string {
+	filename = filepath.Base(strings.ReplaceAll(filename, "\\", "/"))
+	filename = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, filename))
+
+	if filename == "" || filename == "." || filename == "/" {
+		return "attachment"
+	}
+	if runes := []rune(filename); len(runes) > maxAttachmentFilenameLength {
+		filename = string(runes[:maxAttachmentFilenameLength])
+	}
+
+	return filename
+}
+
+// Reader failing with ErrAttachmentTooLarge once more than limit bytes are read
+type sizeLimitedReader struct {
+	reader io.Reader
+	limit  int64
+	size   int64
+}
+
+func (l *sizeLimitedReader) Read(p []byte) (int, error) {
+	n, err := l.reader.Read(p)
+	l.size += int64(n)
+	if l.size > l.limit {
+		return n, ErrAttachmentTooLarge
+	}
+
+	return n, err
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"time"
+	"to-do-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Smallest content detected as a PNG image
+const pngHeader = "\x89PNG\r\n\x1a\n"
+
+func TestAttachments(t *testing.T) {
+	setMockRepository()
+	title := "Test Task"
+	taskId, _ := CreateNewTask(context.Background(), TaskRequestBody{Title: &title})
+
+	attachmentId, err := AddAttachment(context.Background(), taskId, "C:\\shots\\screen.png", strings.NewReader(pngHeader+"pixels"))
+	assert.Nil(t, err)
+
+	attachments, err := GetAttachments(context.Background(), taskId)
+	assert.Nil(t, err)
+	assert.Len(t, attachments, 1)
+	assert.Equal(t, "screen.png", attachments[0].Filename, "Only the base name of the file should be kept")
+	assert.Equal(t, "image/png", attachments[0].ContentType, "Type should be detected from the content")
+	assert.Equal(t, int64(14), attachments[0].Size)
+
+	info, contents, err := OpenAttachment(context.Background(), taskId, attachmentId)
+	assert.Nil(t, err)
+	data, _ := io.ReadAll(contents)
+	contents.Close()
+	assert.Equal(t, pngHeader+"pixels", string(data))
+	assert.Equal(t, "image/png", info.ContentType)
+
+	assert.Nil(t, DeleteAttachment(context.Background(), taskId, attachmentId))
+	_, _, err = OpenAttachment(context.Background(), taskId, attachmentId)
+	assert.Equal(t, ErrRowNotFound, err)
+}
+
+func TestAddAttachmentLimits(t *testing.T) {
+	setMockRepository()
+	title := "Test Task"
+	taskId, _ := CreateNewTask(context.Background(), TaskRequestBody{Title: &title})
+
+	_, err := AddAttachment(context.Background(), taskId, "page.html", strings.NewReader("<html><script>alert(1)</script></html>"))
+	assert.True(t, errors.Is(err, ErrAttachmentType), "HTML should not be attached")
+
+	_, err = AddAttachment(context.Background(), taskId, "empty.log", strings.NewReader(""))
+	assert.True(t, errors.Is(err, ErrInvalidInput), "Empty file should not be attached")
+
+	SetMaxAttachmentSize(1024)
+	defer SetMaxAttachmentSize(defaultMaxAttachmentSizeMB << 20)
+	_, err = AddAttachment(context.Background(), taskId, "big.log", strings.NewReader(strings.Repeat("a", 1025)))
+	assert.Equal(t, ErrAttachmentTooLarge, err)
+	_, err = AddAttachment(context.Background(), taskId, "fits.log", strings.NewReader(strings.Repeat("a", 1024)))
+	assert.Nil(t, err, "File of the maximum size should be attached")
+
+	attachments, _ := GetAttachments(context.Background(), taskId)
+	assert.Len(t, attachments, 1, "Rejected files should not be attached")
+}
+
+func TestAddAttachmentViewer(t *testing.T) {
+	setMockRepository()
+	SetUserRepository(models.NewMemoryUserRepository())
+	ownerId, _ := userRepository.AddUser(context.Background(), models.User{Username: "alice"})
+	viewerId, _ := userRepository.AddUser(context.Background(), models.User{Username: "bob"})
+	ownerCtx := ContextWithUser(context.Background(), ownerId)
+	viewerCtx := ContextWithUser(context.Background(), viewerId)
+
+	name := "Backend"
+	projectId, _ := CreateProject(ownerCtx, ProjectRequestBody{Name: &name})
+	username, role := "bob", models.RoleViewer
+	SetProjectMember(ownerCtx, projectId, ProjectMemberRequestBody{Username: &username, Role: &role})
+	title := "Test Task"
+	taskId, _ := CreateNewTask(ownerCtx, TaskRequestBody{Title: &title, ProjectId: &projectId})
+	attachmentId, _ := AddAttachment(ownerCtx, taskId, "crash.log", strings.NewReader("panic"))
+
+	_, err := AddAttachment(viewerCtx, taskId, "crash.log", strings.NewReader("panic"))
+	assert.Equal(t, ErrForbidden, err, "Viewers should not attach files")
+	assert.Equal(t, ErrForbidden, DeleteAttachment(viewerCtx, taskId, attachmentId), "Viewers should not delete attachments")
+
+	attachments, err := GetAttachments(viewerCtx, taskId)
+	assert.Nil(t, err, "Viewers should see the attachments")
+	assert.Equal(t, "alice", attachments[0].Uploader)
+}
+
+func TestPurgeTrashAttachments(t *testing.T) {
+	setMockRepository()
+	store := models.NewMemoryBlobStore()
+	SetBlobStore(store)
+	title := "Test Task"
+	taskId, _ := CreateNewTask(context.Background(), TaskRequestBody{Title: &title})
+	AddAttachment(context.Background(), taskId, "crash.log", strings.NewReader("panic"))
+	attachment, _ := attachmentRepository.QueryAttachment(context.Background(), taskId, 1)
+	DeleteTask(context.Background(), taskId, AnyVersion)
+
+	PurgeTrash(context.Background(), time.Hour)
+	_, err := store.OpenBlob(context.Background(), attachment.BlobKey)
+	assert.Nil(t, err, "Contents should be kept while the task is in the trash")
+
+	purged, err := PurgeTrash(context.Background(), -time.Minute)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), purged)
+	_, err = store.OpenBlob(context.Background(), attachment.BlobKey)
+	assert.Equal(t, models.ErrBlobNotFound, err, "Contents should be removed with the purged task")
+}
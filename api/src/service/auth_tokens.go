@@ -0,0 +1,130 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultAccessTokenTTL = 15 * time.Minute
+const defaultRefreshTokenTTL = 7 * 24 * time.Hour
+
+// Kinds of token, a refresh token can not be used to access the API and vice versa
+const (
+	accessToken  = "access"
+	refreshToken = "refresh"
+)
+
+type AuthConfig struct {
+	Secret          []byte // key signing the tokens
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+var authConfig = AuthConfig{Secret: randomSecret(), AccessTokenTTL: defaultAccessTokenTTL, RefreshTokenTTL: defaultRefreshTokenTTL}
+
+func SetAuthConfig(config AuthConfig) {
+	authConfig = config
+}
+
+// LoadAuthConfig reads the tokens configuration from AUTH_TOKEN_SECRET, AUTH_ACCESS_TOKEN_TTL
+// and AUTH_REFRESH_TOKEN_TTL env variables. Without secret a random one is used, so the
+// issued tokens are only valid until the API restarts.
+func LoadAuthConfig() AuthConfig {
+	config := AuthConfig{
+		Secret:          []byte(os.Getenv("AUTH_TOKEN_SECRET")),
+		AccessTokenTTL:  lookupTokenTTL("AUTH_ACCESS_TOKEN_TTL", defaultAccessTokenTTL),
+		RefreshTokenTTL: lookupTokenTTL("AUTH_REFRESH_TOKEN_TTL", defaultRefreshTokenTTL),
+	}
+
+	if len(config.Secret) == 0 {
+		fmt.Println("AUTH_TOKEN_SECRET not defined, tokens will be invalid after restart")
+		config.Secret = randomSecret()
+	}
+
+	return config
+}
+
+func lookupTokenTTL(key string, defaultValue time.Duration) time.Duration {
+	value, exist := os.LookupEnv(key)
+	if !exist || value == "" {
+		return defaultValue
+	}
+
+	ttl, err := time.ParseDuration(value)
+	if err != nil || ttl <= 0 {
+		log.Fatalf("Invalid %s value '%s', must be a duration > 0", key, value)
+	}
+
+	return ttl
+}
+
+func randomSecret() []byte {
+	secret := make([]byte, 32)
+	rand.Read(secret)
+	return secret
+}
+
+// Tokens are JWTs signed with HMAC-SHA256
+type tokenClaims struct {
+	Subject   uint   `json:"sub"`
+	Type      string `json:"typ"`
+	IssuedAt  int64  `json:"iat"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+var tokenHeader = base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","typ":"JWT"}`))
+
+func issueToken(userId uint, tokenType string, ttl time.Duration) string {
+	now := time.Now()
+	claims, _ := json.Marshal(tokenClaims{
+		Subject:   userId,
+		Type:      tokenType,
+		IssuedAt:  now.Unix(),
+		ExpiresAt: now.Add(ttl).Unix(),
+	})
+
+	unsignedToken := tokenHeader + "." + base64.RawURLEncoding.EncodeToString(claims)
+	return unsignedToken + "." + signToken(unsignedToken)
+}
+
+// Returns the user of a valid, not expired, token of the given type
+func parseToken(token string, tokenType string) (uint, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 || parts[0] != tokenHeader {
+		return 0, ErrInvalidToken
+	}
+
+	expectedSignature := signToken(parts[0] + "." + parts[1])
+	if !hmac.Equal([]byte(parts[2]), []byte(expectedSignature)) {
+		return 0, ErrInvalidToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+
+	var claims tokenClaims
+	if err = json.Unmarshal(payload, &claims); err != nil {
+		return 0, ErrInvalidToken
+	}
+	if claims.Type != tokenType || time.Now().Unix() >= claims.ExpiresAt {
+		return 0, ErrInvalidToken
+	}
+
+	return claims.Subject, nil
+}
+
+func signToken(unsignedToken string) string {
+	mac := hmac.New(sha256.New, authConfig.Secret)
+	mac.Write([]byte(unsignedToken))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
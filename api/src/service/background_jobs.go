@@ -0,0 +1,29 @@
+package service
+
+import (
+	"context"
+	"time"
+)
+
+// Runs job every interval in a background goroutine. The returned function stops it: the context
+// of the job is canceled, and it waits for the run in progress to end
+func runPeriodically(interval time.Duration, job func(ctx context.Context)) (stop func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		for ctx.Err() == nil {
+			job(ctx)
+			select {
+			case <-ctx.Done():
+			case <-time.After(interval):
+			}
+		}
+	}()
+
+	return func() {
+		cancel()
+		<-done
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunPeriodicallyStop(t *testing.T) {
+	started := make(chan struct{})
+	var ended bool
+	stop := runPeriodically(time.Hour, func(ctx context.Context) {
+		close(started)
+		<-ctx.Done()
+		ended = true
+	})
+	<-started
+
+	stop()
+
+	assert.True(t, ended, "Stop should wait for the run in progress to end")
+}
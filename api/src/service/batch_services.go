@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Operations accepted by a batch
+const (
+	OpCreate = "create"
+	OpUpdate = "update"
+	OpPatch  = "patch"
+	OpDelete = "delete"
+)
+
+// Batch modes: all the operations or none are applied, or each one is applied on its own
+const (
+	BatchAtomic  = "atomic"
+	BatchPerItem = "per-item"
+)
+
+const maxBatchOperations = 100
+
+type BatchRequestBody struct {
+	Mode       string                `json:"mode"` // atomic (default) or per-item
+	Operations []BatchOperationInput `json:"operations"`
+}
+
+type BatchOperationInput struct {
+	Op      string          `json:"op"`      // create, update, patch or delete
+	TaskId  uint            `json:"task_id"` // task changed by update, patch and delete
+	Version uint            `json:"version"` // expected task version, as on If-Match (0 skips the check)
+	Task    json.RawMessage `json:"task"`    // task for create and update, merge patch for patch
+}
+
+// TaskOperation is a validated operation of a batch
+type TaskOperation struct {
+	Op      string
+	TaskId  uint
+	Version uint
+	Task    TaskRequestBody
+	Patch   TaskPatch
+}
+
+type BatchResult struct {
+	Index  int    `json:"index"`
+	Op     string `json:"op"`
+	TaskId uint   `json:"task_id"` // id of the created task for create
+	Err    error  `json:"-"`       // nil when the operation was applied
+}
+
+// BatchError is the failure of an operation rolling back an atomic batch
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("operation %d failed: %v", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// RunBatch applies the operations in order inside one transaction. In atomic mode the first failed
+// operation rolls back the whole batch and is returned as BatchError. In per-item mode each operation
+// is applied or rolled back on its own, and the results hold the error of the failed ones.
+func RunBatch(ctx context.Context, mode string, operations []TaskOperation) ([]BatchResult, error) {
+	results := []BatchResult{}
+
+	err := transactor.RunInTransaction(ctx, func(txCtx context.Context) error {
+		results = []BatchResult{}
+		for index, operation := range operations {
+			result := BatchResult{Index: index, Op: operation.Op, TaskId: operation.TaskId}
+
+			if mode == BatchPerItem {
+				// Savepoint, so a failed operation does not abort the others
+				result.Err = transactor.RunInTransaction(txCtx, func(itemCtx context.Context) error {
+					var err error
+					result.TaskId, err = runTaskOperation(itemCtx, operation)
+					return err
+				})
+			} else {
+				result.TaskId, result.Err = runTaskOperation(txCtx, operation)
+				if result.Err != nil {
+					return &BatchError{Index: index, Err: result.Err}
+				}
+			}
+			results = append(results, result)
+		}
+		return nil
+	})
+
+	var batchErr *BatchError
+	if err != nil && !errors.As(err, &batchErr) {
+		return []BatchResult{}, databaseError("Run Batch", err)
+	}
+
+	return results, err
+}
+
+func runTaskOperation(ctx context.Context, operation TaskOperation) (uint, error) {
+	switch operation.Op {
+	case OpCreate:
+		return CreateNewTask(ctx, operation.Task)
+	case OpUpdate:
+		return operation.TaskId, UpdateTask(ctx, operation.TaskId, operation.Task, operation.Version)
+	case OpPatch:
+		return operation.TaskId, PatchTask(ctx, operation.TaskId, operation.Patch, operation.Version)
+	default:
+		return operation.TaskId, DeleteTask(ctx, operation.TaskId, operation.Version)
+	}
+}
@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"to-do-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getTestBatchOperations(t *testing.T, mode string) []TaskOperation {
+	operations, err := ValidateBatchInput(BatchRequestBody{Mode: mode, Operations: []BatchOperationInput{
+		{Op: OpCreate, Task: json.RawMessage(`{"title": "New Task"}`)},
+		{Op: OpPatch, TaskId: 1, Task: json.RawMessage(`{"priority": 4}`)},
+		{Op: OpDelete, TaskId: 10},
+	}})
+	assert.Nil(t, err)
+	return operations
+}
+
+func TestRunBatchAtomic(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Test Task"})
+
+	results, err := RunBatch(context.Background(), BatchAtomic, getTestBatchOperations(t, BatchAtomic))
+
+	var batchErr *BatchError
+	assert.True(t, errors.As(err, &batchErr), "Failed operation should fail the batch")
+	assert.Equal(t, 2, batchErr.Index)
+	assert.ErrorIs(t, err, ErrRowNotFound)
+	assert.Len(t, results, 2)
+
+	tasksAmount, _ := repository.GetAmountOfTasks(context.Background(), noUser)
+	assert.Equal(t, uint(1), tasksAmount, "Created task should be rolled back")
+	task, _ := repository.QueryTask(context.Background(), noUser, 1)
+	assert.Equal(t, uint16(0), task.Priority, "Patch should be rolled back")
+}
+
+func TestRunBatchPerItem(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Test Task"})
+
+	results, err := RunBatch(context.Background(), BatchPerItem, getTestBatchOperations(t, BatchPerItem))
+
+	assert.Nil(t, err)
+	assert.Len(t, results, 3)
+	assert.Nil(t, results[0].Err)
+	assert.Equal(t, uint(2), results[0].TaskId, "Result should hold the id of the created task")
+	assert.Nil(t, results[1].Err)
+	assert.Equal(t, ErrRowNotFound, results[2].Err)
+
+	tasksAmount, _ := repository.GetAmountOfTasks(context.Background(), noUser)
+	assert.Equal(t, uint(2), tasksAmount, "Successful operations should be kept")
+	task, _ := repository.QueryTask(context.Background(), noUser, 1)
+	assert.Equal(t, uint16(4), task.Priority)
+}
+
+func TestValidateBatchInput(t *testing.T) {
+	_, err := ValidateBatchInput(BatchRequestBody{})
+	assert.Equal(t, errors.New("a batch must have between 1 and 100 operations"), err)
+
+	_, err = ValidateBatchInput(BatchRequestBody{Mode: "some", Operations: []BatchOperationInput{{Op: OpDelete, TaskId: 1}}})
+	assert.Equal(t, errors.New("invalid mode 'some'. Valid values: ['atomic', 'per-item']"), err)
+
+	_, err = ValidateBatchInput(BatchRequestBody{Operations: []BatchOperationInput{{Op: OpDelete, TaskId: 1}, {Op: OpUpdate, TaskId: 1}}})
+	assert.Equal(t, errors.New("operation 1: missing required field: 'task'"), err)
+
+	_, err = ValidateBatchInput(BatchRequestBody{Operations: []BatchOperationInput{{Op: OpCreate, Task: json.RawMessage(`{"description": "text"}`)}}})
+	assert.Equal(t, errors.New("operation 0: missing required field: 'title'"), err)
+
+	_, err = ValidateBatchInput(BatchRequestBody{Operations: []BatchOperationInput{{Op: "move", TaskId: 1}}})
+	assert.Equal(t, errors.New("operation 0: invalid op 'move'. Valid values: ['create', 'update', 'patch', 'delete']"), err)
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"to-do-api/models"
+)
+
+// Longest text accepted by the checklist_items table (VARCHAR(500))
+const maxChecklistTextLength = 500
+
+type ChecklistItemRequestBody struct {
+	Text    *string `json:"text"`
+	Checked *bool   `json:"checked"`
+}
+
+type ChecklistOrderRequestBody struct {
+	ItemIds []uint `json:"item_ids"` // every item of the checklist, in the new order
+}
+
+type ChecklistItemInfo struct {
+	Id       uint   `json:"id"`
+	Text     string `json:"text"`
+	Checked  bool   `json:"checked"`
+	Position uint   `json:"position"`
+}
+
+func GetChecklist(ctx context.Context, taskId uint) ([]ChecklistItemInfo, error) {
+	if _, err := taskRepository.QueryTask(ctx, currentUser(ctx), taskId); err != nil {
+		return []ChecklistItemInfo{}, databaseError("Query Task", err)
+	}
+
+	items, err := checklistRepository.QueryChecklist(ctx, taskId)
+	if err != nil {
+		return []ChecklistItemInfo{}, databaseError("Query Checklist", err)
+	}
+
+	checklist := []ChecklistItemInfo{}
+	for _, item := range items {
+		checklist = append(checklist, ChecklistItemInfo{Id: item.Id, Text: item.Text, Checked: item.Checked, Position: item.Position})
+	}
+
+	return checklist, nil
+}
+
+// AddChecklistItem appends an item at the end of the checklist of the task
+func AddChecklistItem(ctx context.Context, taskId uint, item ChecklistItemRequestBody) (uint, error) {
+	if _, err := queryTaskWithRole(ctx, taskId, models.RoleEditor); err != nil {
+		return 0, err
+	}
+
+	newItem := models.ChecklistItem{TaskId: taskId, Text: *item.Text}
+	if item.Checked != nil {
+		newItem.Checked = *item.Checked
+	}
+
+	itemId, err := checklistRepository.AddChecklistItem(ctx, newItem)
+	if err != nil {
+		return 0, databaseError("Add Checklist Item", err)
+	}
+
+	return itemId, nil
+}
+
+// UpdateChecklistItem changes the text of the item or checks it, the fields missing from the request being kept
+func UpdateChecklistItem(ctx context.Context, taskId uint, itemId uint, item ChecklistItemRequestBody) error {
+	if _, err := queryTaskWithRole(ctx, taskId, models.RoleEditor); err != nil {
+		return err
+	}
+
+	items, err := checklistRepository.QueryChecklist(ctx, taskId)
+	if err != nil {
+		return databaseError("Query Checklist", err)
+	}
+	idx := slices.IndexFunc(items, func(stored models.ChecklistItem) bool { return stored.Id == itemId })
+	if idx < 0 {
+		return ErrRowNotFound
+	}
+
+	updatedItem := items[idx]
+	if item.Text != nil {
+		updatedItem.Text = *item.Text
+	}
+	if item.Checked != nil {
+		updatedItem.Checked = *item.Checked
+	}
+
+	if err = checklistRepository.UpdateChecklistItem(ctx, updatedItem); err != nil {
+		return databaseError("Update Checklist Item", err)
+	}
+
+	return nil
+}
+
+// ReorderChecklist sorts the checklist as itemIds, which must list every item of the checklist once
+func ReorderChecklist(ctx context.Context, taskId uint, itemIds []uint) error {
+	if _, err := queryTaskWithRole(ctx, taskId, models.RoleEditor); err != nil {
+		return err
+	}
+
+	items, err := checklistRepository.QueryChecklist(ctx, taskId)
+	if err != nil {
+		return databaseError("Query Checklist", err)
+	}
+
+	currentIds := []uint{}
+	for _, item := range items {
+		currentIds = append(currentIds, item.Id)
+	}
+	sortedIds := slices.Clone(itemIds)
+	slices.Sort(currentIds)
+	slices.Sort(sortedIds)
+	if !slices.Equal(currentIds, sortedIds) {
+		return fmt.Errorf("%w: 'item_ids' must list every item of the checklist once", ErrInvalidInput)
+	}
+
+	if err = checklistRepository.ReorderChecklist(ctx, taskId, itemIds); err != nil {
+		return databaseError("Reorder Checklist", err)
+	}
+
+	return nil
+}
+
+func DeleteChecklistItem(ctx context.Context, taskId uint, itemId uint) error {
+	if _, err := queryTaskWithRole(ctx, taskId, models.RoleEditor); err != nil {
+		return err
+	}
+
+	if err := checklistRepository.DeleteChecklistItem(ctx, taskId, itemId); err != nil {
+		return databaseError("Delete Checklist Item", err)
+	}
+
+	return nil
+}
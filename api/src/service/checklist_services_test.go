@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"to-do-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func checklistItem(text string) ChecklistItemRequestBody {
+	return ChecklistItemRequestBody{Text: &text}
+}
+
+func TestChecklist(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Task 1", Status: "backlog"})
+
+	for _, text := range []string{"first", "second", "third"} {
+		_, err := AddChecklistItem(context.Background(), 1, checklistItem(text))
+		assert.Nil(t, err)
+	}
+	checked := true
+	assert.Nil(t, UpdateChecklistItem(context.Background(), 1, 2, ChecklistItemRequestBody{Checked: &checked}))
+	assert.Nil(t, ReorderChecklist(context.Background(), 1, []uint{3, 1, 2}))
+	assert.Nil(t, DeleteChecklistItem(context.Background(), 1, 1))
+
+	checklist, err := GetChecklist(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []ChecklistItemInfo{
+		{Id: 3, Text: "third", Position: 0},
+		{Id: 2, Text: "second", Checked: true, Position: 2},
+	}, checklist)
+
+	task, _ := GetTaskById(context.Background(), 1)
+	assert.Equal(t, uint(2), task.ChecklistTotal, "Task should count the checklist items")
+	assert.Equal(t, uint(1), task.ChecklistChecked, "Task should count the checked items")
+}
+
+func TestChecklistErrors(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Task 1", Status: "backlog"})
+	AddChecklistItem(context.Background(), 1, checklistItem("first"))
+	AddChecklistItem(context.Background(), 1, checklistItem("second"))
+
+	_, err := AddChecklistItem(context.Background(), 5, checklistItem("first"))
+	assert.Equal(t, ErrRowNotFound, err, "Task should exist")
+
+	err = UpdateChecklistItem(context.Background(), 1, 9, checklistItem("renamed"))
+	assert.Equal(t, ErrRowNotFound, err, "Item should exist")
+
+	err = ReorderChecklist(context.Background(), 1, []uint{2, 2})
+	assert.ErrorIs(t, err, ErrInvalidInput, "Every item should be listed once")
+
+	err = DeleteChecklistItem(context.Background(), 1, 9)
+	assert.Equal(t, ErrRowNotFound, err)
+}
+
+func TestValidateChecklistItemInput(t *testing.T) {
+	assert.Equal(t, errors.New("missing required field: 'text'"), ValidateChecklistItemInput(ChecklistItemRequestBody{}))
+	assert.Equal(t, errors.New("text must not be empty"), ValidateChecklistItemInput(checklistItem("")))
+	assert.Equal(t, errors.New("text must be at most 500 characters"), ValidateChecklistItemInput(checklistItem(strings.Repeat("a", 501))))
+	assert.Nil(t, ValidateChecklistItemInput(checklistItem("buy milk")))
+
+	assert.Equal(t, errors.New("at least one field must be present: 'text', 'checked'"), ValidateChecklistItemUpdateInput(ChecklistItemRequestBody{}))
+}
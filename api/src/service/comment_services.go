@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"time"
+	"to-do-api/models"
+)
+
+// Longest comment body accepted
+const maxCommentLength = 10000
+
+type CommentRequestBody struct {
+	Body *string `json:"body"`
+}
+
+type CommentInfo struct {
+	Id        uint              `json:"id"`
+	AuthorId  uint              `json:"author_id"`
+	Author    string            `json:"author"` // username, empty when unknown
+	Body      string            `json:"body"`
+	CreatedAt int64             `json:"created_at"`
+	UpdatedAt int64             `json:"updated_at"` // 0 when the comment was never edited
+	Edits     []CommentEditInfo `json:"edits"`      // previous bodies, oldest first
+}
+
+type CommentEditInfo struct {
+	Body     string `json:"body"`
+	EditedAt int64  `json:"edited_at"`
+}
+
+// GetComments lists the comments of the task, oldest first
+func GetComments(ctx context.Context, taskId uint) ([]CommentInfo, error) {
+	if _, err := taskRepository.QueryTask(ctx, currentUser(ctx), taskId); err != nil {
+		return []CommentInfo{}, databaseError("Query Task", err)
+	}
+
+	comments, err := commentRepository.QueryComments(ctx, taskId)
+	if err != nil {
+		return []CommentInfo{}, databaseError("Query Comments", err)
+	}
+
+	commentsInfo := []CommentInfo{}
+	usernames := usernameCache{}
+	for _, comment := range comments {
+		author, err := usernames.username(ctx, comment.AuthorId)
+		if err != nil {
+			return []CommentInfo{}, err
+		}
+
+		info := CommentInfo{
+			Id:        comment.Id,
+			AuthorId:  comment.AuthorId,
+			Author:    author,
+			Body:      comment.Body,
+			CreatedAt: comment.CreatedAt.Unix(),
+			Edits:     []CommentEditInfo{},
+		}
+		if !comment.UpdatedAt.IsZero() {
+			info.UpdatedAt = comment.UpdatedAt.Unix()
+		}
+		for _, edit := range comment.Edits {
+			info.Edits = append(info.Edits, CommentEditInfo{Body: edit.Body, EditedAt: edit.EditedAt.Unix()})
+		}
+		commentsInfo = append(commentsInfo, info)
+	}
+
+	return commentsInfo, nil
+}
+
+// AddComment comments the task as the current user. Every user who can see the task can comment it.
+func AddComment(ctx context.Context, taskId uint, comment CommentRequestBody) (uint, error) {
+	if _, err := taskRepository.QueryTask(ctx, currentUser(ctx), taskId); err != nil {
+		return 0, databaseError("Query Task", err)
+	}
+
+	newComment := models.Comment{TaskId: taskId, AuthorId: currentUser(ctx), Body: *comment.Body, CreatedAt: time.Now()}
+	commentId, err := commentRepository.AddComment(ctx, newComment)
+	if err != nil {
+		return 0, databaseError("Add Comment", err)
+	}
+
+	return commentId, nil
+}
+
+// UpdateComment replaces the body of a comment of the current user, the previous one being kept on its edits
+func UpdateComment(ctx context.Context, taskId uint, commentId uint, comment CommentRequestBody) error {
+	currentComment, err := queryOwnComment(ctx, taskId, commentId)
+	if err != nil {
+		return err
+	}
+	if currentComment.Body == *comment.Body {
+		return nil
+	}
+
+	if err = commentRepository.UpdateComment(ctx, taskId, commentId, *comment.Body, time.Now()); err != nil {
+		return databaseError("Update Comment", err)
+	}
+
+	return nil
+}
+
+// DeleteComment removes a comment of the current user
+func DeleteComment(ctx context.Context, taskId uint, commentId uint) error {
+	if _, err := queryOwnComment(ctx, taskId, commentId); err != nil {
+		return err
+	}
+
+	if err := commentRepository.DeleteComment(ctx, taskId, commentId); err != nil {
+		return databaseError("Delete Comment", err)
+	}
+
+	return nil
+}
+
+// Queries a comment of a task the user can see, failing when the user is not its author
+func queryOwnComment(ctx context.Context, taskId uint, commentId uint) (models.Comment, error) {
+	if _, err := taskRepository.QueryTask(ctx, currentUser(ctx), taskId); err != nil {
+		return models.Comment{}, databaseError("Query Task", err)
+	}
+
+	comments, err := commentRepository.QueryComments(ctx, taskId)
+	if err != nil {
+		return models.Comment{}, databaseError("Query Comments", err)
+	}
+	idx := slices.IndexFunc(comments, func(comment models.Comment) bool { return comment.Id == commentId })
+	if idx < 0 {
+		return models.Comment{}, ErrRowNotFound
+	}
+	if comments[idx].AuthorId != currentUser(ctx) {
+		return models.Comment{}, ErrNotCommentAuthor
+	}
+
+	return comments[idx], nil
+}
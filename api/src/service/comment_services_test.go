@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"to-do-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func commentRequest(body string) CommentRequestBody {
+	return CommentRequestBody{Body: &body}
+}
+
+func TestComments(t *testing.T) {
+	setMockRepository()
+	SetUserRepository(models.NewMemoryUserRepository())
+	ownerId, _ := userRepository.AddUser(context.Background(), models.User{Username: "alice"})
+	memberId, _ := userRepository.AddUser(context.Background(), models.User{Username: "bob"})
+	ownerCtx := ContextWithUser(context.Background(), ownerId)
+	memberCtx := ContextWithUser(context.Background(), memberId)
+
+	name := "Backend"
+	projectId, _ := CreateProject(ownerCtx, ProjectRequestBody{Name: &name})
+	title := "Test Task"
+	taskId, _ := CreateNewTask(ownerCtx, TaskRequestBody{Title: &title, ProjectId: &projectId})
+	username, role := "bob", models.RoleViewer
+	SetProjectMember(ownerCtx, projectId, ProjectMemberRequestBody{Username: &username, Role: &role})
+
+	commentId, err := AddComment(ownerCtx, taskId, commentRequest("Needs a test"))
+	assert.Nil(t, err)
+	_, err = AddComment(memberCtx, taskId, commentRequest("On it"))
+	assert.Nil(t, err, "Viewers should comment the tasks")
+
+	assert.Equal(t, ErrNotCommentAuthor, UpdateComment(memberCtx, taskId, commentId, commentRequest("Done")), "Only the author should edit the comment")
+	assert.Equal(t, ErrNotCommentAuthor, DeleteComment(memberCtx, taskId, commentId), "Only the author should delete the comment")
+	assert.Nil(t, UpdateComment(ownerCtx, taskId, commentId, commentRequest("Needs two tests")))
+
+	comments, err := GetComments(memberCtx, taskId)
+	assert.Nil(t, err)
+	assert.Len(t, comments, 2)
+	assert.Equal(t, "alice", comments[0].Author)
+	assert.Equal(t, "Needs two tests", comments[0].Body)
+	assert.NotZero(t, comments[0].UpdatedAt, "Edited comment should have its edition time")
+	assert.Equal(t, "Needs a test", comments[0].Edits[0].Body, "Previous body should be kept")
+	assert.Equal(t, "bob", comments[1].Author)
+	assert.Zero(t, comments[1].UpdatedAt)
+
+	task, _ := GetTaskById(ownerCtx, taskId)
+	assert.Equal(t, uint(2), task.CommentCount)
+}
+
+func TestCommentsOfDeletedTask(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Task 1", Status: "backlog"})
+	AddComment(context.Background(), 1, commentRequest("First"))
+
+	assert.Nil(t, DeleteTask(context.Background(), 1, AnyVersion))
+	_, err := GetComments(context.Background(), 1)
+	assert.Equal(t, ErrRowNotFound, err, "Comments should be hidden with the task in the trash")
+	_, err = AddComment(context.Background(), 1, commentRequest("Second"))
+	assert.Equal(t, ErrRowNotFound, err, "Task in the trash should not be commented")
+
+	assert.Nil(t, RestoreTask(context.Background(), 1))
+	comments, _ := GetComments(context.Background(), 1)
+	assert.Len(t, comments, 1, "Comments should be restored with the task")
+}
+
+func TestValidateCommentInput(t *testing.T) {
+	assert.Equal(t, errors.New("missing required field: 'body'"), ValidateCommentInput(CommentRequestBody{}))
+	assert.Equal(t, errors.New("body must not be empty"), ValidateCommentInput(commentRequest(" ")))
+	assert.Equal(t, errors.New("body must be at most 10000 characters"), ValidateCommentInput(commentRequest(strings.Repeat("a", 10001))))
+	assert.Nil(t, ValidateCommentInput(commentRequest("Looks good")))
+}
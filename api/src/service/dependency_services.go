@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"to-do-api/models"
+)
+
+// AddTaskDependency makes taskId depend on dependsOnId, rejecting dependencies that close a cycle
+func AddTaskDependency(ctx context.Context, taskId uint, dependsOnId uint) error {
+	if _, err := queryTaskWithRole(ctx, taskId, models.RoleEditor); err != nil {
+		return err
+	}
+
+	idExist, err := checkIdExist(ctx, dependsOnId)
+	if err != nil {
+		return err
+	} else if !idExist {
+		return ErrRowNotFound
+	}
+
+	if err := dependencyRepository.AddDependency(ctx, taskId, dependsOnId); err != nil {
+		return databaseError("Add Dependency", err)
+	}
+
+	return nil
+}
+
+func RemoveTaskDependency(ctx context.Context, taskId uint, dependsOnId uint) error {
+	if _, err := queryTaskWithRole(ctx, taskId, models.RoleEditor); err != nil {
+		return err
+	}
+
+	if err := dependencyRepository.DeleteDependency(ctx, taskId, dependsOnId); err != nil {
+		return databaseError("Delete Dependency", err)
+	}
+
+	return nil
+}
+
+func GetTaskDependencies(ctx context.Context, taskId uint) ([]uint, error) {
+	idExist, err := checkIdExist(ctx, taskId)
+	if err != nil {
+		return []uint{}, err
+	} else if !idExist {
+		return []uint{}, ErrRowNotFound
+	}
+
+	dependencies, err := dependencyRepository.QueryDependencies(ctx, taskId)
+	if err != nil {
+		return []uint{}, databaseError("Query Dependencies", err)
+	}
+
+	return dependencies, nil
+}
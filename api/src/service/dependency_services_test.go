@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddTaskDependency(t *testing.T) {
+	repository := setMockRepository()
+	for _, task := range getTestTasksList() {
+		repository.AddTask(context.Background(), task)
+	}
+
+	err := AddTaskDependency(context.Background(), 1, 2)
+	assert.Nil(t, err)
+
+	dependencies, err := GetTaskDependencies(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint{2}, dependencies)
+}
+
+func TestAddTaskDependencyCycle(t *testing.T) {
+	repository := setMockRepository()
+	for _, task := range getTestTasksList() {
+		repository.AddTask(context.Background(), task)
+	}
+	AddTaskDependency(context.Background(), 1, 2)
+	AddTaskDependency(context.Background(), 2, 3)
+
+	err := AddTaskDependency(context.Background(), 3, 1)
+
+	assert.Equal(t, ErrDependencyCycle, err)
+}
+
+func TestAddTaskDependencyInexistentTask(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), getTestTasksList()[0])
+
+	err := AddTaskDependency(context.Background(), 1, 10)
+
+	assert.Equal(t, ErrRowNotFound, err)
+}
+
+func TestAddTaskDependencyDBError(t *testing.T) {
+	repository := setMockRepository()
+	for _, task := range getTestTasksList() {
+		repository.AddTask(context.Background(), task)
+	}
+	repository.addDependency = func(taskId uint, dependsOnId uint) error {
+		return errors.New("database error")
+	}
+
+	err := AddTaskDependency(context.Background(), 1, 2)
+
+	assert.Equal(t, ErrDatabaseGeneral, err)
+}
+
+func TestRemoveTaskDependencyNotFound(t *testing.T) {
+	setMockRepository()
+
+	err := RemoveTaskDependency(context.Background(), 1, 2)
+
+	assert.Equal(t, ErrRowNotFound, err)
+}
+
+func TestGetTaskDependenciesInexistentTask(t *testing.T) {
+	setMockRepository()
+
+	_, err := GetTaskDependencies(context.Background(), 1)
+
+	assert.Equal(t, ErrRowNotFound, err)
+}
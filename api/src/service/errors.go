@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"to-do-api/models"
+)
+
+var ErrDatabaseGeneral = errors.New("fail processing request on database")
+var ErrRowNotFound = errors.New("requested resource not found on database")
+var ErrInvalidInput = errors.New("invalid input")
+var ErrDatabaseTimeout = errors.New("database did not respond in time")
+var ErrDatabaseUnavailable = errors.New("database unavailable, try again later")
+var ErrRequestCanceled = errors.New("request canceled")
+var ErrDependencyCycle = errors.New("dependency would create a cycle between tasks")
+var ErrDependencyExists = errors.New("dependency already exists")
+var ErrUserExists = errors.New("username already taken")
+var ErrInvalidCredentials = errors.New("invalid username or password")
+var ErrInvalidToken = errors.New("invalid or expired token")
+var ErrProjectArchived = errors.New("project is archived")
+var ErrStatusTransition = errors.New("status transition not allowed by the workflow")
+var ErrForbidden = errors.New("operation not allowed for your role on the project")
+var ErrVersionMismatch = errors.New("task was modified since it was read")
+var ErrConcurrentChange = errors.New("task kept being modified concurrently, try again")
+var ErrParentCycle = errors.New("parent would make the task a subtask of itself")
+var ErrParentDeleted = errors.New("parent task is in the trash, restore it first")
+var ErrTagExists = errors.New("tag name already taken")
+var ErrNotCommentAuthor = errors.New("only the author can change the comment")
+var ErrAttachmentTooLarge = errors.New("attachment exceeds the maximum size")
+var ErrAttachmentType = errors.New("attachment type not allowed")
+var ErrBlobStore = errors.New("fail processing the attachment contents")
+
+// Converts an error returned by the blob store into one of the service errors.
+// Unexpected errors are logged with the failed operation.
+func blobStoreError(operation string, err error) error {
+	switch {
+	case errors.Is(err, ErrAttachmentTooLarge):
+		return ErrAttachmentTooLarge
+	case errors.Is(err, models.ErrBlobNotFound):
+		return ErrRowNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		fmt.Printf("%s timed out: %v\n", operation, err)
+		return ErrDatabaseTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrRequestCanceled
+	default:
+		fmt.Printf("%s failed: %v\n", operation, err)
+		return ErrBlobStore
+	}
+}
+
+// Converts an error returned by the repositories into one of the service errors.
+// Unexpected errors are logged with the failed operation.
+func databaseError(operation string, err error) error {
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return ErrRowNotFound
+	case errors.Is(err, models.ErrDependencyCycle):
+		return ErrDependencyCycle
+	case errors.Is(err, models.ErrDependencyExists):
+		return ErrDependencyExists
+	case errors.Is(err, models.ErrUserExists):
+		return ErrUserExists
+	case errors.Is(err, models.ErrTagExists):
+		return ErrTagExists
+	case errors.Is(err, models.ErrVersionConflict):
+		return ErrVersionMismatch
+	case errors.Is(err, context.DeadlineExceeded):
+		fmt.Printf("%s timed out: %v\n", operation, err)
+		return ErrDatabaseTimeout
+	case errors.Is(err, context.Canceled):
+		return ErrRequestCanceled
+	case models.IsUnavailableError(err):
+		fmt.Printf("%s failed, database unavailable: %v\n", operation, err)
+		return ErrDatabaseUnavailable
+	default:
+		fmt.Printf("%s failed: %v\n", operation, err)
+		return ErrDatabaseGeneral
+	}
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+	"to-do-api/models"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDatabaseError(t *testing.T) {
+	assert.Equal(t, ErrRowNotFound, databaseError("Query Task", sql.ErrNoRows), "ErrNoRows should be mapped to not found")
+	assert.Equal(t, ErrDependencyCycle, databaseError("Add Dependency", models.ErrDependencyCycle), "Cycle should be mapped to dependency cycle")
+	assert.Equal(t, ErrDependencyExists, databaseError("Add Dependency", models.ErrDependencyExists), "Duplicate should be mapped to existing dependency")
+	assert.Equal(t, ErrDatabaseTimeout, databaseError("Query Task", fmt.Errorf("timeout: %w", context.DeadlineExceeded)), "Deadline should be mapped to timeout")
+	assert.Equal(t, ErrRequestCanceled, databaseError("Query Task", context.Canceled), "Cancellation should be mapped to canceled request")
+	assert.Equal(t, ErrDatabaseUnavailable, databaseError("Query Task", &pgconn.PgError{Code: "57P01"}), "Admin shutdown should be mapped to unavailable")
+	assert.Equal(t, ErrDatabaseUnavailable, databaseError("Query Task", &pgconn.PgError{Code: "53300"}), "Too many connections should be mapped to unavailable")
+	assert.Equal(t, ErrDatabaseGeneral, databaseError("Query Task", &pgconn.PgError{Code: "23505"}), "Other database errors should be mapped to general error")
+	assert.Equal(t, ErrDatabaseGeneral, databaseError("Query Task", errors.New("unknown")), "Unknown errors should be mapped to general error")
+}
+
+func TestGetTaskByIdCanceledContext(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), getTestTasksList()[0])
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Run function
+	_, err := GetTaskById(ctx, 1)
+
+	// Assertions
+	assert.Equal(t, ErrRequestCanceled, err)
+}
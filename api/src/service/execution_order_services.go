@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"slices"
+	"to-do-api/models"
+)
+
+// Enough to fetch every task matching the filters in a single page
+const allTasksLimit = math.MaxInt32
+
+type ExecutionOrderTask struct {
+	TaskInfo
+	DependsOn []uint `json:"depends_on"`
+	BlockedBy []uint `json:"blocked_by"` // unfinished tasks it depends on
+}
+
+// GetExecutionOrder lists the tasks matching the filters in an order where every
+// task comes after the tasks it depends on. Dependencies on tasks outside the
+// filtered list do not change the order, but still block the task until done.
+// Dependencies the user can no longer see (e.g. after leaving their project, or
+// moved to the trash) keep blocking the task, as whether they are done is unknown.
+func GetExecutionOrder(ctx context.Context, filterConfig []models.TasksFilterQuery) ([]ExecutionOrderTask, error) {
+	tasks, dependencies, err := queryTasksWithDependencies(ctx, filterConfig)
+	if err != nil {
+		return []ExecutionOrderTask{}, err
+	}
+
+	statuses := map[uint]string{}
+	for _, task := range tasks {
+		statuses[task.Id] = task.Status
+	}
+
+	// Status of the dependencies left out by the filters
+	for _, dependsOn := range dependencies {
+		for _, dependsOnId := range dependsOn {
+			if _, known := statuses[dependsOnId]; known {
+				continue
+			}
+			task, err := taskRepository.QueryTask(ctx, currentUser(ctx), dependsOnId)
+			if err != nil {
+				if err = databaseError("Query Task", err); !errors.Is(err, ErrRowNotFound) {
+					return []ExecutionOrderTask{}, err
+				}
+			}
+			statuses[dependsOnId] = task.Status
+		}
+	}
+
+	orderedTasks, err := topologicalOrder(tasks, dependencies)
+	if err != nil {
+		return []ExecutionOrderTask{}, err
+	}
+
+	executionOrder := []ExecutionOrderTask{}
+	for _, task := range orderedTasks {
+		orderTask := ExecutionOrderTask{
+			TaskInfo:  newTaskInfo(task),
+			DependsOn: []uint{},
+			BlockedBy: []uint{},
+		}
+		for _, dependsOnId := range dependencies[task.Id] {
+			orderTask.DependsOn = append(orderTask.DependsOn, dependsOnId)
+			if !workflow.isDone(statuses[dependsOnId]) {
+				orderTask.BlockedBy = append(orderTask.BlockedBy, dependsOnId)
+			}
+		}
+		executionOrder = append(executionOrder, orderTask)
+	}
+
+	return executionOrder, nil
+}
+
+// GetBlockedTasks returns the ids of the tasks still waiting for unfinished dependencies
+func GetBlockedTasks(executionOrder []ExecutionOrderTask) []uint {
+	blockedTasks := []uint{}
+	for _, task := range executionOrder {
+		if len(task.BlockedBy) > 0 {
+			blockedTasks = append(blockedTasks, task.Id)
+		}
+	}
+
+	return blockedTasks
+}
+
+// Queries every task matching the filters together with their dependencies
+func queryTasksWithDependencies(ctx context.Context, filterConfig []models.TasksFilterQuery) ([]models.Task, map[uint][]uint, error) {
+	tasks, err := queryAllTasks(ctx, filterConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	taskIds := []uint{}
+	for _, task := range tasks {
+		taskIds = append(taskIds, task.Id)
+	}
+
+	dependencies, err := dependencyRepository.QueryDependenciesOf(ctx, taskIds)
+	if err != nil {
+		return nil, nil, databaseError("Query Dependencies", err)
+	}
+
+	return tasks, dependencies, nil
+}
+
+// Queries every task matching the filters, ordered by id
+func queryAllTasks(ctx context.Context, filterConfig []models.TasksFilterQuery) ([]models.Task, error) {
+	allTasksPage := models.TasksPaginationQuery{Offset: 0, SortBy: "id", SortOrder: "ASC", Limit: allTasksLimit}
+	tasks, err := taskRepository.QueryTasks(ctx, currentUser(ctx), filterConfig, allTasksPage)
+	if err != nil {
+		return nil, databaseError("Query Tasks", err)
+	}
+
+	return tasks, nil
+}
+
+// Sorts the tasks so each one comes after its dependencies (Kahn's algorithm).
+// Among the tasks ready at the same time, the most important one comes first.
+func topologicalOrder(tasks []models.Task, dependencies map[uint][]uint) ([]models.Task, error) {
+	tasksById := map[uint]models.Task{}
+	for _, task := range tasks {
+		tasksById[task.Id] = task
+	}
+
+	pendingDependencies := map[uint]int{}
+	dependents := map[uint][]uint{}
+	for taskId, dependsOn := range dependencies {
+		for _, dependsOnId := range dependsOn {
+			if _, listed := tasksById[dependsOnId]; !listed {
+				continue
+			}
+			pendingDependencies[taskId]++
+			dependents[dependsOnId] = append(dependents[dependsOnId], taskId)
+		}
+	}
+
+	ready := []models.Task{}
+	for _, task := range tasks {
+		if pendingDependencies[task.Id] == 0 {
+			ready = append(ready, task)
+		}
+	}
+
+	orderedTasks := []models.Task{}
+	for len(ready) > 0 {
+		next := slices.MinFunc(ready, compareExecutionPriority)
+		ready = slices.DeleteFunc(ready, func(task models.Task) bool { return task.Id == next.Id })
+		orderedTasks = append(orderedTasks, next)
+
+		for _, dependentId := range dependents[next.Id] {
+			pendingDependencies[dependentId]--
+			if pendingDependencies[dependentId] == 0 {
+				ready = append(ready, tasksById[dependentId])
+			}
+		}
+	}
+
+	// Cycles are rejected when the dependencies are added, so this only
+	// happens when the stored data is inconsistent
+	if len(orderedTasks) != len(tasks) {
+		return []models.Task{}, ErrDependencyCycle
+	}
+
+	return orderedTasks, nil
+}
+
+// Higher priority first, then earlier due date (tasks without due date last)
+func compareExecutionPriority(a models.Task, b models.Task) int {
+	if result := models.CompareTaskColumn(b, a, "priority"); result != 0 {
+		return result
+	}
+	if a.DueDate.IsZero() != b.DueDate.IsZero() {
+		if a.DueDate.IsZero() {
+			return 1
+		}
+		return -1
+	}
+	if result := models.CompareTaskColumn(a, b, "due_date"); result != 0 {
+		return result
+	}
+
+	return models.CompareTaskColumn(a, b, "id")
+}
@@ -0,0 +1,124 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"to-do-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func getExecutionOrderIds(executionOrder []ExecutionOrderTask) []uint {
+	taskIds := []uint{}
+	for _, task := range executionOrder {
+		taskIds = append(taskIds, task.Id)
+	}
+	return taskIds
+}
+
+func TestGetExecutionOrderTieBreak(t *testing.T) {
+	repository := setMockRepository()
+	for _, task := range getTestTasksList() {
+		repository.AddTask(context.Background(), task)
+	}
+
+	// Run function
+	executionOrder, err := GetExecutionOrder(context.Background(), []models.TasksFilterQuery{})
+
+	// Without dependencies the highest priority comes first
+	assert.Nil(t, err)
+	assert.Equal(t, []uint{2, 3, 1}, getExecutionOrderIds(executionOrder))
+	assert.Empty(t, GetBlockedTasks(executionOrder))
+}
+
+func TestGetExecutionOrderWithDependencies(t *testing.T) {
+	repository := setMockRepository()
+	for _, task := range getTestTasksList() {
+		repository.AddTask(context.Background(), task)
+	}
+	repository.AddDependency(context.Background(), 2, 1)
+
+	// Run function
+	executionOrder, err := GetExecutionOrder(context.Background(), []models.TasksFilterQuery{})
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Equal(t, []uint{3, 1, 2}, getExecutionOrderIds(executionOrder))
+	assert.Equal(t, []uint{1}, executionOrder[2].DependsOn)
+	assert.Equal(t, []uint{1}, executionOrder[2].BlockedBy)
+	assert.Equal(t, []uint{2}, GetBlockedTasks(executionOrder))
+}
+
+func TestGetExecutionOrderFiltered(t *testing.T) {
+	repository := setMockRepository()
+	for _, task := range getTestTasksList() {
+		repository.AddTask(context.Background(), task)
+	}
+	repository.AddDependency(context.Background(), 1, 2)
+	repository.AddDependency(context.Background(), 3, 1)
+	filterConfig, _ := CreateFilterConfig("", "", "pending", "", "", "", "")
+
+	// Run function
+	executionOrder, err := GetExecutionOrder(context.Background(), filterConfig)
+
+	// Task 2 is filtered out, but being done it does not block task 1
+	assert.Nil(t, err)
+	assert.Equal(t, []uint{1, 3}, getExecutionOrderIds(executionOrder))
+	assert.Equal(t, []uint{2}, executionOrder[0].DependsOn)
+	assert.Empty(t, executionOrder[0].BlockedBy)
+	assert.Equal(t, []uint{3}, GetBlockedTasks(executionOrder))
+}
+
+func TestGetExecutionOrderInvisibleDependency(t *testing.T) {
+	repository := setMockRepository()
+	SetUserRepository(models.NewMemoryUserRepository())
+	ownerId, _ := userRepository.AddUser(context.Background(), models.User{Username: "alice"})
+	memberId, _ := userRepository.AddUser(context.Background(), models.User{Username: "bob"})
+	ownerCtx := ContextWithUser(context.Background(), ownerId)
+	memberCtx := ContextWithUser(context.Background(), memberId)
+
+	name := "Backend"
+	projectId, _ := CreateProject(ownerCtx, ProjectRequestBody{Name: &name})
+	username := "bob"
+	role := models.RoleEditor
+	SetProjectMember(ownerCtx, projectId, ProjectMemberRequestBody{Username: &username, Role: &role})
+	title := "Test Task"
+	projectTaskId, _ := CreateNewTask(ownerCtx, TaskRequestBody{Title: &title, ProjectId: &projectId})
+	taskId, _ := CreateNewTask(memberCtx, TaskRequestBody{Title: &title})
+	assert.Nil(t, repository.AddDependency(context.Background(), taskId, projectTaskId))
+	assert.Nil(t, RemoveProjectMember(memberCtx, projectId, memberId))
+
+	// Run function
+	executionOrder, err := GetExecutionOrder(memberCtx, []models.TasksFilterQuery{})
+
+	// The dependency is no longer visible, but still blocks the task
+	assert.Nil(t, err)
+	assert.Equal(t, []uint{taskId}, getExecutionOrderIds(executionOrder))
+	assert.Equal(t, []uint{projectTaskId}, executionOrder[0].DependsOn)
+	assert.Equal(t, []uint{projectTaskId}, executionOrder[0].BlockedBy)
+}
+
+func TestGetExecutionOrderDBError(t *testing.T) {
+	repository := setMockRepository()
+	repository.queryTasks = func(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]models.Task, error) {
+		return []models.Task{}, errors.New("database error")
+	}
+
+	// Run function
+	_, err := GetExecutionOrder(context.Background(), []models.TasksFilterQuery{})
+
+	// Assertions
+	assert.Equal(t, ErrDatabaseGeneral, err)
+}
+
+func TestTopologicalOrderCycle(t *testing.T) {
+	tasks := getTestTasksList()
+	dependencies := map[uint][]uint{1: {2}, 2: {1}}
+
+	// Run function
+	_, err := topologicalOrder(tasks, dependencies)
+
+	// Assertions
+	assert.Equal(t, ErrDependencyCycle, err)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"reflect"
+	"time"
+	"to-do-api/models"
+)
+
+type TaskEventInfo struct {
+	Id        uint                 `json:"id"`
+	Action    string               `json:"action"`
+	ActorId   uint                 `json:"actor_id"`
+	Actor     string               `json:"actor"` // username, empty when unknown
+	Changes   []models.FieldChange `json:"changes"`
+	CreatedAt int64                `json:"created_at"`
+}
+
+// GetTaskHistory lists the changes done on the task, oldest first
+func GetTaskHistory(ctx context.Context, taskId uint) ([]TaskEventInfo, error) {
+	idExist, err := checkIdExist(ctx, taskId)
+	if err != nil {
+		return []TaskEventInfo{}, err
+	} else if !idExist {
+		return []TaskEventInfo{}, ErrRowNotFound
+	}
+
+	events, err := taskEventRepository.QueryTaskEvents(ctx, taskId)
+	if err != nil {
+		return []TaskEventInfo{}, databaseError("Query Task Events", err)
+	}
+
+	history := []TaskEventInfo{}
+	usernames := usernameCache{}
+	for _, event := range events {
+		actor, err := usernames.username(ctx, event.ActorId)
+		if err != nil {
+			return []TaskEventInfo{}, err
+		}
+
+		history = append(history, TaskEventInfo{
+			Id:        event.Id,
+			Action:    event.Action,
+			ActorId:   event.ActorId,
+			Actor:     actor,
+			Changes:   event.Changes,
+			CreatedAt: event.CreatedAt.Unix(),
+		})
+	}
+
+	return history, nil
+}
+
+// Records the change done by the current user on the task history, in the transaction of the
+// change so it is never done without being recorded
+func recordTaskEvent(ctx context.Context, action string, taskId uint, before *models.Task, after *models.Task) error {
+	changes := taskChanges(before, after)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	event := models.TaskEvent{TaskId: taskId, ActorId: currentUser(ctx), Action: action, Changes: changes, CreatedAt: time.Now()}
+	if _, err := taskEventRepository.AddTaskEvent(ctx, event); err != nil {
+		return databaseError("Record Task Event", err)
+	}
+
+	return nil
+}
+
+// Fields that differ between the task versions, nil for the missing version
+func taskChanges(before *models.Task, after *models.Task) []models.FieldChange {
+	var beforeFields, afterFields []taskField
+	if before != nil {
+		beforeFields = taskFields(*before)
+	}
+	if after != nil {
+		afterFields = taskFields(*after)
+	}
+
+	changes := []models.FieldChange{}
+	for i, field := range taskFields(models.Task{}) {
+		change := models.FieldChange{Field: field.name}
+		if beforeFields != nil {
+			change.Before = beforeFields[i].value
+		}
+		if afterFields != nil {
+			change.After = afterFields[i].value
+		}
+		if beforeFields == nil || afterFields == nil || !reflect.DeepEqual(change.Before, change.After) {
+			changes = append(changes, change)
+		}
+	}
+
+	return changes
+}
+
+type taskField struct {
+	name  string
+	value any
+}
+
+// Task fields tracked on the history, with the values as shown by the API
+func taskFields(task models.Task) []taskField {
+	return []taskField{
+		{"title", task.Title},
+		{"description", task.Description},
+		{"status", task.Status},
+		{"priority", task.Priority},
+		{"due_date", task.DueDate.Unix()},
+		{"estimate_hours", task.EstimateHours},
+		{"project_id", task.ProjectId},
+		{"parent_id", task.ParentId},
+		{"recurrence", task.Recurrence},
+	}
+}
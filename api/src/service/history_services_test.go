@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"to-do-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskHistory(t *testing.T) {
+	setMockRepository()
+	SetUserRepository(models.NewMemoryUserRepository())
+	userId, _ := userRepository.AddUser(context.Background(), models.User{Username: "alice"})
+	ctx := ContextWithUser(context.Background(), userId)
+
+	title := "Test Task"
+	taskId, _ := CreateNewTask(ctx, TaskRequestBody{Title: &title})
+
+	newTitle := "New title"
+	sameStatus := "backlog"
+	PatchTask(ctx, taskId, TaskPatch{Set: TaskRequestBody{Title: &newTitle, Status: &sameStatus}}, AnyVersion)
+
+	history, err := GetTaskHistory(ctx, taskId)
+
+	assert.Nil(t, err)
+	assert.Len(t, history, 2)
+	assert.Equal(t, models.ActionCreate, history[0].Action)
+	assert.Equal(t, "alice", history[0].Actor)
+	assert.Contains(t, history[0].Changes, models.FieldChange{Field: "title", Before: nil, After: title})
+	assert.Equal(t, []models.FieldChange{{Field: "title", Before: title, After: newTitle}}, history[1].Changes, "Only the changed fields should be recorded")
+}
+
+func TestTaskHistoryDelete(t *testing.T) {
+	repository := setMockRepository()
+
+	title := "Test Task"
+	taskId, _ := CreateNewTask(context.Background(), TaskRequestBody{Title: &title})
+	DeleteTask(context.Background(), taskId, AnyVersion)
+
+	events, _ := repository.QueryTaskEvents(context.Background(), taskId)
+	assert.Len(t, events, 2)
+	assert.Equal(t, models.ActionDelete, events[1].Action)
+	assert.Contains(t, events[1].Changes, models.FieldChange{Field: "title", Before: title, After: nil})
+}
+
+func TestTaskHistoryInexistentTask(t *testing.T) {
+	setMockRepository()
+
+	_, err := GetTaskHistory(context.Background(), 1)
+
+	assert.Equal(t, ErrRowNotFound, err)
+}
+
+func TestTaskHistoryNoChange(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Test Task"})
+
+	title := "Test Task"
+	PatchTask(context.Background(), 1, TaskPatch{Set: TaskRequestBody{Title: &title}}, AnyVersion)
+
+	events, _ := repository.QueryTaskEvents(context.Background(), 1)
+	assert.Empty(t, events, "Updates without changes should not be recorded")
+}
+
+type failingTaskEventRepository struct {
+	models.TaskEventRepository
+}
+
+func (failingTaskEventRepository) AddTaskEvent(ctx context.Context, event models.TaskEvent) (uint, error) {
+	return 0, errors.New("database error")
+}
+
+func TestTaskHistoryRecordError(t *testing.T) {
+	repository := setMockRepository()
+	SetTaskEventRepository(failingTaskEventRepository{repository})
+
+	title := "Test Task"
+	_, err := CreateNewTask(context.Background(), TaskRequestBody{Title: &title})
+
+	assert.Equal(t, ErrDatabaseGeneral, err, "Failing to record the history should fail the change")
+	amount, _ := repository.GetAmountOfTasks(context.Background(), noUser)
+	assert.Equal(t, uint(0), amount, "Change should be rolled back without its history")
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"time"
+	"to-do-api/models"
+)
+
+type MassUpdateRequestBody struct {
+	Changes          json.RawMessage `json:"changes"`             // merge patch applied to every task
+	ShiftDueDateDays int             `json:"shift_due_date_days"` // days added to the due date of the tasks having one
+	OverdueOnly      bool            `json:"overdue_only"`        // only the unfinished tasks past their due date
+	Confirm          bool            `json:"confirm"`             // false only previews the update
+}
+
+// MassUpdate is a validated change of every task matching the filters
+type MassUpdate struct {
+	Patch            TaskPatch
+	ShiftDueDateDays int
+	OverdueOnly      bool
+}
+
+type MassUpdateItem struct {
+	Before TaskInfo
+	After  TaskInfo
+	Err    error // why the change is not allowed on the task, nil when it is
+	task   models.Task
+}
+
+type MassUpdateResult struct {
+	Matched   int              // tasks matching the filters
+	Changed   int              // tasks updated, or updated once confirmed
+	Unchanged int              // tasks already holding the changes
+	Rejected  int              // tasks the change is not allowed on
+	Tasks     []MassUpdateItem // changed and rejected tasks
+}
+
+// MassUpdateTasks changes every task matching the filters. Without confirm it is a dry run returning
+// the tasks that would change, else the changes are applied in one transaction. Tasks the user can
+// not edit, or moved to a status the workflow does not allow, are rejected and left as they are.
+func MassUpdateTasks(ctx context.Context, filterConfig []models.TasksFilterQuery, update MassUpdate, confirm bool) (MassUpdateResult, error) {
+	if !confirm {
+		return previewMassUpdate(ctx, filterConfig, update)
+	}
+
+	result := MassUpdateResult{}
+	err := inTransaction(ctx, "Mass Update Tasks", func(txCtx context.Context) error {
+		var err error
+		if result, err = previewMassUpdate(txCtx, filterConfig, update); err != nil {
+			return err
+		}
+
+		for index, item := range result.Tasks {
+			if item.Err != nil {
+				continue
+			}
+			// Savepoint, so a task changed since it was read does not abort the others
+			err = inTransaction(txCtx, "Update Task", func(itemCtx context.Context) error {
+				return changeTask(itemCtx, item.task.Id, item.task.Version, update.apply)
+			})
+			if err != nil && !isRejection(err) {
+				return err
+			} else if err != nil {
+				result.Tasks[index].Err = err
+				result.Changed--
+				result.Rejected++
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return MassUpdateResult{}, err
+	}
+
+	return result, nil
+}
+
+// Lists the tasks matching the filters with the changes applied, without storing them
+func previewMassUpdate(ctx context.Context, filterConfig []models.TasksFilterQuery, update MassUpdate) (MassUpdateResult, error) {
+	tasks, err := queryAllTasks(ctx, filterConfig)
+	if err != nil {
+		return MassUpdateResult{}, err
+	}
+
+	result := MassUpdateResult{Tasks: []MassUpdateItem{}}
+	now := time.Now()
+	for _, task := range tasks {
+		if update.OverdueOnly && (task.DueDate.IsZero() || !task.DueDate.Before(now) || workflow.isDone(task.Status)) {
+			continue
+		}
+		result.Matched++
+
+		updatedTask := update.apply(task)
+		item := MassUpdateItem{Before: newTaskInfo(task), After: newTaskInfo(updatedTask), task: task}
+		if reflect.DeepEqual(item.Before, item.After) {
+			result.Unchanged++
+			continue
+		}
+
+		item.Err = checkTaskRole(ctx, task, models.RoleEditor)
+		if item.Err == nil {
+			item.Err = checkTaskChange(ctx, task, updatedTask)
+		}
+		if item.Err != nil && !isRejection(item.Err) {
+			return MassUpdateResult{}, item.Err
+		}
+
+		if item.Err != nil {
+			result.Rejected++
+		} else {
+			result.Changed++
+		}
+		result.Tasks = append(result.Tasks, item)
+	}
+
+	return result, nil
+}
+
+// Task with the changes of the mass update
+func (update MassUpdate) apply(task models.Task) models.Task {
+	task = update.Patch.apply(task)
+	if update.ShiftDueDateDays != 0 && !task.DueDate.IsZero() {
+		task.DueDate = task.DueDate.AddDate(0, 0, update.ShiftDueDateDays)
+	}
+
+	return task
+}
+
+// Errors leaving a task out of the mass update, the others failing it
+func isRejection(err error) bool {
+	return errors.Is(err, ErrForbidden) || errors.Is(err, ErrStatusTransition) || errors.Is(err, ErrProjectArchived) ||
+		errors.Is(err, ErrRowNotFound) || errors.Is(err, ErrVersionMismatch)
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+	"to-do-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func setMassUpdateTestTasks() *mockTaskRepository {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Task 1", Status: "open", Priority: 3})
+	repository.AddTask(context.Background(), models.Task{Title: "Task 2", Status: "backlog", Priority: 3})
+	repository.AddTask(context.Background(), models.Task{Title: "Task 3", Status: "done", Priority: 3})
+	repository.AddTask(context.Background(), models.Task{Title: "Task 4", Status: "backlog", Priority: 1})
+	return repository
+}
+
+func TestMassUpdateTasksDryRun(t *testing.T) {
+	setTestWorkflow(t, strictWorkflow)
+	repository := setMassUpdateTestTasks()
+	filterConfig, _ := CreateFilterConfig("", "", "", "3", "", "", "")
+	update, err := ValidateMassUpdateInput(MassUpdateRequestBody{Changes: json.RawMessage(`{"status": "done"}`)})
+	assert.Nil(t, err)
+
+	result, err := MassUpdateTasks(context.Background(), filterConfig, update, false)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 3, result.Matched)
+	assert.Equal(t, 1, result.Changed)
+	assert.Equal(t, 1, result.Unchanged, "Task already done should be unchanged")
+	assert.Equal(t, 1, result.Rejected, "Backlog task can not move to done")
+	assert.Len(t, result.Tasks, 2)
+	assert.Equal(t, "done", result.Tasks[0].After.Status)
+	assert.ErrorIs(t, result.Tasks[1].Err, ErrStatusTransition)
+
+	task, _ := repository.QueryTask(context.Background(), noUser, 1)
+	assert.Equal(t, "open", task.Status, "Dry run should not update the tasks")
+}
+
+func TestMassUpdateTasksConfirm(t *testing.T) {
+	repository := setMassUpdateTestTasks()
+	filterConfig, _ := CreateFilterConfig("", "", "backlog", "", "", "", "")
+	update, _ := ValidateMassUpdateInput(MassUpdateRequestBody{Changes: json.RawMessage(`{"status": "open", "priority": null}`)})
+
+	result, err := MassUpdateTasks(context.Background(), filterConfig, update, true)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, result.Changed)
+	for _, taskId := range []uint{2, 4} {
+		task, _ := repository.QueryTask(context.Background(), noUser, taskId)
+		assert.Equal(t, "open", task.Status, "Matching tasks should be updated")
+		assert.Equal(t, uint16(0), task.Priority, "Cleared field should be reset")
+		assert.Equal(t, uint(2), task.Version)
+	}
+	task, _ := repository.QueryTask(context.Background(), noUser, 1)
+	assert.Equal(t, uint16(3), task.Priority, "Other tasks should not be updated")
+
+	events, _ := repository.QueryTaskEvents(context.Background(), 2)
+	assert.Len(t, events, 1, "Update should be recorded in the task history")
+	assert.Equal(t, models.ActionUpdate, events[0].Action)
+}
+
+func TestMassUpdateTasksShiftOverdue(t *testing.T) {
+	repository := setMockRepository()
+	overdue := time.Now().AddDate(0, 0, -2).Truncate(time.Second)
+	repository.AddTask(context.Background(), models.Task{Title: "Overdue", Status: "backlog", DueDate: overdue})
+	repository.AddTask(context.Background(), models.Task{Title: "Overdue done", Status: "done", DueDate: overdue})
+	repository.AddTask(context.Background(), models.Task{Title: "Future", Status: "backlog", DueDate: time.Now().AddDate(0, 0, 2)})
+	repository.AddTask(context.Background(), models.Task{Title: "No due date", Status: "backlog"})
+	update, _ := ValidateMassUpdateInput(MassUpdateRequestBody{ShiftDueDateDays: 7, OverdueOnly: true})
+
+	result, err := MassUpdateTasks(context.Background(), []models.TasksFilterQuery{}, update, true)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, result.Matched, "Only unfinished tasks past their due date should match")
+	task, _ := repository.QueryTask(context.Background(), noUser, 1)
+	assert.Equal(t, overdue.AddDate(0, 0, 7), task.DueDate, "Due date should be pushed by a week")
+}
+
+func TestMassUpdateTasksDatabaseError(t *testing.T) {
+	repository := setMassUpdateTestTasks()
+	repository.updateTask = func(task models.Task) error { return errors.New("connection reset") }
+	update, _ := ValidateMassUpdateInput(MassUpdateRequestBody{Changes: json.RawMessage(`{"priority": 5}`)})
+
+	_, err := MassUpdateTasks(context.Background(), []models.TasksFilterQuery{}, update, true)
+
+	assert.ErrorIs(t, err, ErrDatabaseGeneral)
+	task, _ := repository.QueryTask(context.Background(), noUser, 1)
+	assert.Equal(t, uint16(3), task.Priority, "Failed mass update should be rolled back")
+}
+
+func TestValidateMassUpdateInput(t *testing.T) {
+	_, err := ValidateMassUpdateInput(MassUpdateRequestBody{})
+	assert.Equal(t, errors.New("at least one change must be present: 'changes', 'shift_due_date_days'"), err)
+
+	_, err = ValidateMassUpdateInput(MassUpdateRequestBody{Changes: json.RawMessage(`{"owner": 2}`)})
+	assert.Equal(t, errors.New("invalid changes: unknown field 'owner'"), err)
+
+	_, err = ValidateMassUpdateInput(MassUpdateRequestBody{Changes: json.RawMessage(`{"due_date": null}`), ShiftDueDateDays: 1})
+	assert.Equal(t, errors.New("'changes' can not set the due date together with 'shift_due_date_days'"), err)
+}
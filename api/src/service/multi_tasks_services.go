@@ -1,7 +1,7 @@
 package service
 
 import (
-	"database/sql"
+	"context"
 	"errors"
 	"fmt"
 	"to-do-api/models"
@@ -10,13 +10,25 @@ import (
 var defaultPageConfig models.TasksPaginationQuery = models.TasksPaginationQuery{Offset: 0, SortBy: "id", SortOrder: "ASC", Limit: 10}
 
 type TaskInfo struct {
-	Id          uint   `json:"id"`
-	Title       string `json:"title"`
-	Status      string `json:"status"`
-	Priority    uint16 `json:"priority"`
-	Description string `json:"description"`
-	CreatedAt   int64  `json:"created_at"`
-	DueDate     int64  `json:"due_date"`
+	Id            uint   `json:"id"`
+	Title         string `json:"title"`
+	Status        string `json:"status"`
+	Priority      uint16 `json:"priority"`
+	Description   string `json:"description"`
+	CreatedAt     int64  `json:"created_at"`
+	DueDate       int64  `json:"due_date"`
+	EstimateHours uint   `json:"estimate_hours"`
+	ProjectId     uint   `json:"project_id"`
+	ParentId      uint   `json:"parent_id"`
+
+	ChecklistTotal   uint `json:"checklist_total"`   // items on the checklist
+	ChecklistChecked uint `json:"checklist_checked"` // checked items on the checklist
+
+	Tags         []string `json:"tags"`          // names of the tags labeling the task
+	CommentCount uint     `json:"comment_count"` // comments on the task
+
+	SeriesId   uint   `json:"series_id"`  // series of the recurring task, 0 when it does not recur
+	Recurrence string `json:"recurrence"` // RRULE of the series
 }
 
 func CreatePageConfig(offset string, limit string, sortBy string, sortOrder string) (models.TasksPaginationQuery, error) {
@@ -56,7 +68,9 @@ func CreatePageConfig(offset string, limit string, sortBy string, sortOrder stri
 	return pageConfig, nil
 }
 
-func CreateFilterConfig(titleFilter string, descriptionFilter string, statusFilter string, priorityFilter string) ([]models.TasksFilterQuery, error) {
+// CreateFilterConfig builds the filters of the tasks list. tagsFilter is a comma separated list of tag
+// names, matching the tasks labeled with any of them, or with all of them when tagsMatch is "all".
+func CreateFilterConfig(titleFilter string, descriptionFilter string, statusFilter string, priorityFilter string, projectFilter string, tagsFilter string, tagsMatch string) ([]models.TasksFilterQuery, error) {
 	filterConfig := []models.TasksFilterQuery{}
 
 	if titleFilter != "" {
@@ -83,6 +97,26 @@ func CreateFilterConfig(titleFilter string, descriptionFilter string, statusFilt
 		}
 		filterConfig = appendFilter(filterConfig, "priority", priorityFilter)
 	}
+	if projectFilter != "" {
+		if !isValidIdFilter(projectFilter) {
+			return nil, errors.New("invalid project filter: must be a project id")
+		}
+		filterConfig = appendFilter(filterConfig, "project_id", projectFilter)
+	}
+	if tagsMatch != "" && tagsMatch != string(models.MatchAny) && tagsMatch != string(models.MatchAll) {
+		return nil, errors.New("invalid tags_match value. Valid values: ['any', 'all']")
+	}
+	if tagsFilter != "" {
+		tagNames, valid := parseTagsFilter(tagsFilter)
+		if !valid {
+			return nil, errors.New("invalid tags filter: must be comma separated tag names")
+		}
+		if tagsMatch == string(models.MatchAll) {
+			filterConfig = appendFilter(filterConfig, "tags_all", tagNames)
+		} else {
+			filterConfig = appendFilter(filterConfig, "tags_any", tagNames)
+		}
+	}
 
 	return filterConfig, nil //errors.New("invalid filter option")
 }
@@ -96,63 +130,96 @@ func appendFilter(filterCriteria []models.TasksFilterQuery, filterType string, f
 		// titleContainsQuery
 		filterQuery.Query = fmt.Sprintf("title LIKE $%d", nextParamIdx)
 		filterQuery.Value = "%" + filterValue + "%"
+		filterQuery.Match = models.MatchContains
 	case "description":
 		//  descriptionContainsQuery
 		filterQuery.Query = fmt.Sprintf("description LIKE $%d", nextParamIdx)
 		filterQuery.Value = "%" + filterValue + "%"
+		filterQuery.Match = models.MatchContains
 	case "status":
 		// statusMatchQuery
 		filterQuery.Query = fmt.Sprintf("status = $%d", nextParamIdx)
 		filterQuery.Value = filterValue
+		filterQuery.Match = models.MatchExact
 	case "priority":
 		// priorityMatchQuery
 		filterQuery.Query = fmt.Sprintf("priority = $%d", nextParamIdx)
 		filterQuery.Value = filterValue
+		filterQuery.Match = models.MatchExact
+	case "project_id":
+		// projectMatchQuery
+		filterQuery.Query = fmt.Sprintf("project_id = $%d", nextParamIdx)
+		filterQuery.Value = filterValue
+		filterQuery.Match = models.MatchExact
+	case "tags_any":
+		// tagsAnyQuery, the value being the comma separated tag names
+		filterQuery.Query = fmt.Sprintf("id IN (SELECT tt.task_id FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE g.name = ANY(string_to_array($%d, ',')))", nextParamIdx)
+		filterQuery.Value = filterValue
+		filterQuery.Match = models.MatchAny
+		filterQuery.Column = "tags"
+	case "tags_all":
+		// tagsAllQuery, the task having as many of the tag names as there are in the value
+		filterQuery.Query = fmt.Sprintf("id IN (SELECT tt.task_id FROM task_tags tt JOIN tags g ON g.id = tt.tag_id WHERE g.name = ANY(string_to_array($%[1]d, ',')) GROUP BY tt.task_id HAVING COUNT(DISTINCT g.name) = cardinality(string_to_array($%[1]d, ',')))", nextParamIdx)
+		filterQuery.Value = filterValue
+		filterQuery.Match = models.MatchAll
+		filterQuery.Column = "tags"
 	default:
 		return filterCriteria
 	}
 
+	if filterQuery.Column == "" {
+		filterQuery.Column = filterType
+	}
 	filterCriteria = append(filterCriteria, filterQuery)
 
 	return filterCriteria
 }
 
-func GetTasksList(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]TaskInfo, error) {
+func GetTasksList(ctx context.Context, filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]TaskInfo, error) {
 	orderedTasks := []TaskInfo{}
 
-	queriedTasks, err := models.QueryTasks(filterConfig, pageConfig)
-
-	for taskIdx, task := range queriedTasks {
-		newTask := TaskInfo{
-			Id:          task.Id,
-			Title:       task.Title,
-			Status:      task.Status,
-			Priority:    task.Priority,
-			Description: task.Description,
-			CreatedAt:   task.CreatedAt.Unix(),
-			DueDate:     task.DueDate.Unix(),
-		}
-		orderedTasks = append(orderedTasks, newTask)
-		orderedTasks[taskIdx] = newTask
+	queriedTasks, err := taskRepository.QueryTasks(ctx, currentUser(ctx), filterConfig, pageConfig)
+
+	for _, task := range queriedTasks {
+		orderedTasks = append(orderedTasks, newTaskInfo(task))
 	}
 
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return orderedTasks, ErrRowNotFound
-		} else {
-			fmt.Printf("Query Task failed: %v\n", err)
-			return orderedTasks, ErrDatabaseGeneral
-		}
+		return orderedTasks, databaseError("Query Tasks", err)
 	}
 
 	return orderedTasks, nil
 }
 
-func GetReturnInfo(pageConfig models.TasksPaginationQuery) (map[string]uint, map[string]string) {
+func newTaskInfo(task models.Task) TaskInfo {
+	return TaskInfo{
+		Id:            task.Id,
+		Title:         task.Title,
+		Status:        task.Status,
+		Priority:      task.Priority,
+		Description:   task.Description,
+		CreatedAt:     task.CreatedAt.Unix(),
+		DueDate:       task.DueDate.Unix(),
+		EstimateHours: task.EstimateHours,
+		ProjectId:     task.ProjectId,
+		ParentId:      task.ParentId,
+
+		ChecklistTotal:   task.ChecklistTotal,
+		ChecklistChecked: task.ChecklistChecked,
+
+		Tags:         task.Tags,
+		CommentCount: task.CommentCount,
+
+		SeriesId:   task.SeriesId,
+		Recurrence: task.Recurrence,
+	}
+}
+
+func GetReturnInfo(ctx context.Context, pageConfig models.TasksPaginationQuery) (map[string]uint, map[string]string) {
 	paginationInfo := map[string]uint{"offset": pageConfig.Offset, "limit": pageConfig.Limit}
 	sortingInfo := map[string]string{"by": pageConfig.SortBy, "order": pageConfig.SortOrder}
 
-	totalTasks, err := models.GetAmountOfTasks()
+	totalTasks, err := taskRepository.GetAmountOfTasks(ctx, currentUser(ctx))
 	if err != nil {
 		fmt.Printf("Error getting total tasks. e: %v\n", err)
 	} else {
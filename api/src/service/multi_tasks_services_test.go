@@ -1,13 +1,13 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"testing"
 	"time"
 	"to-do-api/models"
 
-	"bou.ke/monkey"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -54,11 +54,11 @@ func TestGetTasksList(t *testing.T) {
 
 	testList := getTestTasksList()
 
-	// Mock models.QueryTasks function
-	monkey.Patch(models.QueryTasks, func(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]models.Task, error) {
+	// Mock repository.QueryTasks function
+	repository := setMockRepository()
+	repository.queryTasks = func(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]models.Task, error) {
 		return testList, nil
-	})
-	defer monkey.UnpatchAll()
+	}
 
 	pageConfig := models.TasksPaginationQuery{Offset: 0, SortBy: "id", SortOrder: "ASC", Limit: 10}
 	filterConfig := []models.TasksFilterQuery{
@@ -66,28 +66,47 @@ func TestGetTasksList(t *testing.T) {
 	}
 
 	// Run function
-	taskList, err := GetTasksList(filterConfig, pageConfig)
+	taskList, err := GetTasksList(context.Background(), filterConfig, pageConfig)
 
 	// Assertions
 	expectedOutput := []TaskInfo{
-		0: {taskList[0].Id, taskList[0].Title, taskList[0].Status, taskList[0].Priority, taskList[0].Description, taskList[0].CreatedAt, taskList[0].DueDate},
-		1: {taskList[1].Id, taskList[1].Title, taskList[1].Status, taskList[1].Priority, taskList[1].Description, taskList[1].CreatedAt, taskList[1].DueDate},
-		2: {taskList[2].Id, taskList[2].Title, taskList[2].Status, taskList[2].Priority, taskList[2].Description, taskList[2].CreatedAt, taskList[2].DueDate},
+		0: {taskList[0].Id, taskList[0].Title, taskList[0].Status, taskList[0].Priority, taskList[0].Description, taskList[0].CreatedAt, taskList[0].DueDate, taskList[0].EstimateHours, taskList[0].ProjectId, taskList[0].ParentId, taskList[0].ChecklistTotal, taskList[0].ChecklistChecked, taskList[0].Tags, taskList[0].CommentCount, taskList[0].SeriesId, taskList[0].Recurrence},
+		1: {taskList[1].Id, taskList[1].Title, taskList[1].Status, taskList[1].Priority, taskList[1].Description, taskList[1].CreatedAt, taskList[1].DueDate, taskList[1].EstimateHours, taskList[1].ProjectId, taskList[1].ParentId, taskList[1].ChecklistTotal, taskList[1].ChecklistChecked, taskList[1].Tags, taskList[1].CommentCount, taskList[1].SeriesId, taskList[1].Recurrence},
+		2: {taskList[2].Id, taskList[2].Title, taskList[2].Status, taskList[2].Priority, taskList[2].Description, taskList[2].CreatedAt, taskList[2].DueDate, taskList[2].EstimateHours, taskList[2].ProjectId, taskList[2].ParentId, taskList[2].ChecklistTotal, taskList[2].ChecklistChecked, taskList[2].Tags, taskList[2].CommentCount, taskList[2].SeriesId, taskList[2].Recurrence},
 	}
 
 	assert.Nil(t, err)
 	assert.Equal(t, expectedOutput, taskList)
 }
 
+func TestGetTasksListMemoryRepository(t *testing.T) {
+	repository := setMockRepository()
+	for _, task := range getTestTasksList() {
+		repository.AddTask(context.Background(), task)
+	}
+
+	pageConfig, _ := CreatePageConfig("", "", "priority", "desc")
+	filterConfig, _ := CreateFilterConfig("Task", "", "pending", "", "", "", "")
+
+	// Run function
+	taskList, err := GetTasksList(context.Background(), filterConfig, pageConfig)
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Len(t, taskList, 2)
+	assert.Equal(t, uint(3), taskList[0].Id)
+	assert.Equal(t, uint(1), taskList[1].Id)
+}
+
 func TestGetTasksListNoRows(t *testing.T) {
 
 	testList := getTestTasksList()
 
-	// Mock models.QueryTasks function
-	monkey.Patch(models.QueryTasks, func(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]models.Task, error) {
+	// Mock repository.QueryTasks function
+	repository := setMockRepository()
+	repository.queryTasks = func(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]models.Task, error) {
 		return testList, sql.ErrNoRows
-	})
-	defer monkey.UnpatchAll()
+	}
 
 	pageConfig := models.TasksPaginationQuery{Offset: 0, SortBy: "id", SortOrder: "ASC", Limit: 10}
 	filterConfig := []models.TasksFilterQuery{
@@ -95,7 +114,7 @@ func TestGetTasksListNoRows(t *testing.T) {
 	}
 
 	// Run function
-	_, err := GetTasksList(filterConfig, pageConfig)
+	_, err := GetTasksList(context.Background(), filterConfig, pageConfig)
 
 	assert.Equal(t, errors.New("requested resource not found on database"), err)
 }
@@ -104,11 +123,11 @@ func TestGetTasksListServerError(t *testing.T) {
 
 	testList := getTestTasksList()
 
-	// Mock models.QueryTasks function
-	monkey.Patch(models.QueryTasks, func(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]models.Task, error) {
+	// Mock repository.QueryTasks function
+	repository := setMockRepository()
+	repository.queryTasks = func(filterConfig []models.TasksFilterQuery, pageConfig models.TasksPaginationQuery) ([]models.Task, error) {
 		return testList, sql.ErrConnDone
-	})
-	defer monkey.UnpatchAll()
+	}
 
 	pageConfig := models.TasksPaginationQuery{Offset: 0, SortBy: "id", SortOrder: "ASC", Limit: 10}
 	filterConfig := []models.TasksFilterQuery{
@@ -116,7 +135,7 @@ func TestGetTasksListServerError(t *testing.T) {
 	}
 
 	// Run function
-	_, err := GetTasksList(filterConfig, pageConfig)
+	_, err := GetTasksList(context.Background(), filterConfig, pageConfig)
 
 	assert.Equal(t, errors.New("fail processing request on database"), err)
 }
@@ -127,10 +146,10 @@ func TestAppendFilters(t *testing.T) {
 
 	assert.Len(t, filterConfig, 0)
 
-	expectedTitleFilter := models.TasksFilterQuery{Query: "title LIKE $1", Value: "%title_value%"}
-	expectedDescFilter := models.TasksFilterQuery{Query: "description LIKE $2", Value: "%description_value%"}
-	expectedStatusFilter := models.TasksFilterQuery{Query: "status = $3", Value: "status_value"}
-	expectedPriorityFilter := models.TasksFilterQuery{Query: "priority = $4", Value: "priority_value"}
+	expectedTitleFilter := models.TasksFilterQuery{Query: "title LIKE $1", Value: "%title_value%", Column: "title", Match: models.MatchContains}
+	expectedDescFilter := models.TasksFilterQuery{Query: "description LIKE $2", Value: "%description_value%", Column: "description", Match: models.MatchContains}
+	expectedStatusFilter := models.TasksFilterQuery{Query: "status = $3", Value: "status_value", Column: "status", Match: models.MatchExact}
+	expectedPriorityFilter := models.TasksFilterQuery{Query: "priority = $4", Value: "priority_value", Column: "priority", Match: models.MatchExact}
 
 	filterConfig = appendFilter(filterConfig, "title", "title_value")
 	assert.Len(t, filterConfig, 1, "Wrong length for filter config")
@@ -163,44 +182,67 @@ func TestAppendFiltersInvalidType(t *testing.T) {
 // Create Filter Config test /////////////////////////////////////////////////
 func TestCreateFilters(t *testing.T) {
 	expectedFilterConfig := []models.TasksFilterQuery{
-		{Query: "title LIKE $1", Value: "%title_value%"},
-		{Query: "description LIKE $2", Value: "%description_value%"},
-		{Query: "status = $3", Value: "status_value"},
-		{Query: "priority = $4", Value: "1"},
+		{Query: "title LIKE $1", Value: "%title_value%", Column: "title", Match: models.MatchContains},
+		{Query: "description LIKE $2", Value: "%description_value%", Column: "description", Match: models.MatchContains},
+		{Query: "status = $3", Value: "status_value", Column: "status", Match: models.MatchExact},
+		{Query: "priority = $4", Value: "1", Column: "priority", Match: models.MatchExact},
+		{Query: "project_id = $5", Value: "2", Column: "project_id", Match: models.MatchExact},
 	}
 
-	filterConfig, err := CreateFilterConfig("title_value", "description_value", "status_value", "1")
+	filterConfig, err := CreateFilterConfig("title_value", "description_value", "status_value", "1", "2", "", "")
 
 	assert.Nil(t, err, "Create Filter returned error")
-	assert.Len(t, filterConfig, 4, "Wrong length for filter config")
+	assert.Len(t, filterConfig, 5, "Wrong length for filter config")
 	assert.Equal(t, expectedFilterConfig, filterConfig, "Fail creating filter")
 
 }
 
 func TestCreateFiltersInvalidTitle(t *testing.T) {
-	_, err := CreateFilterConfig("{}", "description_value", "status_value", "1")
+	_, err := CreateFilterConfig("{}", "description_value", "status_value", "1", "", "", "")
 
 	assert.Equal(t, errors.New("invalid title filter: must be alphanumeric"), err, "Did not raise error with invalid Title format")
 }
 
 func TestCreateFiltersInvalidDescription(t *testing.T) {
-	_, err := CreateFilterConfig("", "()", "status_value", "1")
+	_, err := CreateFilterConfig("", "()", "status_value", "1", "", "", "")
 
 	assert.Equal(t, errors.New("invalid description filter: must be alphanumeric"), err, "Did not raise error with invalid Description format")
 }
 
 func TestCreateFiltersInvalidStatus(t *testing.T) {
-	_, err := CreateFilterConfig("title_value", "description_value", ">", "1")
+	_, err := CreateFilterConfig("title_value", "description_value", ">", "1", "", "", "")
 
 	assert.Equal(t, errors.New("invalid status filter: must be alphanumeric"), err, "Did not raise error with invalid Status format")
 }
 
 func TestCreateFiltersInvalidPriority(t *testing.T) {
-	_, err := CreateFilterConfig("title_value", "description_value", "status_value", "alpha")
+	_, err := CreateFilterConfig("title_value", "description_value", "status_value", "alpha", "", "", "")
 
 	assert.Equal(t, errors.New("invalid priority filter: must be positive integer"), err, "Did not raise error with invalid Priority format")
 }
 
+func TestCreateFiltersInvalidProject(t *testing.T) {
+	_, err := CreateFilterConfig("", "", "", "", "0", "", "")
+
+	assert.Equal(t, errors.New("invalid project filter: must be a project id"), err, "Did not raise error with invalid Project format")
+}
+
+func TestCreateFiltersTags(t *testing.T) {
+	filterConfig, err := CreateFilterConfig("", "", "", "", "", "bug, urgent,bug", "all")
+
+	assert.Nil(t, err, "Create Filter returned error")
+	assert.Len(t, filterConfig, 1, "Wrong length for filter config")
+	assert.Equal(t, "bug,urgent", filterConfig[0].Value, "Tag names should be trimmed and deduplicated")
+	assert.Equal(t, models.MatchAll, filterConfig[0].Match)
+	assert.Equal(t, "tags", filterConfig[0].Column)
+
+	_, err = CreateFilterConfig("", "", "", "", "", "bug,", "")
+	assert.Equal(t, errors.New("invalid tags filter: must be comma separated tag names"), err, "Did not raise error with empty tag name")
+
+	_, err = CreateFilterConfig("", "", "", "", "", "bug", "some")
+	assert.Equal(t, errors.New("invalid tags_match value. Valid values: ['any', 'all']"), err, "Did not raise error with invalid match mode")
+}
+
 // Create Page Config test /////////////////////////////////////////////////
 func TestCreatePageConfig(t *testing.T) {
 	expectedPageConfig := models.TasksPaginationQuery{
@@ -253,16 +295,16 @@ func TestGetReturnInfo(t *testing.T) {
 		"order": "ASC",
 	}
 
-	// Mock models.AddTask function
-	monkey.Patch(models.GetAmountOfTasks, func() (uint, error) {
+	// Mock repository.AddTask function
+	repository := setMockRepository()
+	repository.getAmountOfTasks = func() (uint, error) {
 		return 5, nil
-	})
-	defer monkey.UnpatchAll()
+	}
 
 	pageConfig, err := CreatePageConfig("0", "10", "priority", "ASC")
 	assert.Nil(t, err, "Create Page Config returned error")
 
-	paginationInfo, sortingInfo := GetReturnInfo(pageConfig)
+	paginationInfo, sortingInfo := GetReturnInfo(context.Background(), pageConfig)
 
 	assert.Equal(t, expectedPaginationInfo, paginationInfo, "Returned wrong pagination info")
 	assert.Equal(t, expectedSortingInfo, sortingInfo, "Returned wrong sorting info")
@@ -279,16 +321,16 @@ func TestGetReturnInfoErrorGettingTotal(t *testing.T) {
 		"order": "ASC",
 	}
 
-	// Mock models.AddTask function
-	monkey.Patch(models.GetAmountOfTasks, func() (uint, error) {
+	// Mock repository.AddTask function
+	repository := setMockRepository()
+	repository.getAmountOfTasks = func() (uint, error) {
 		return 5, sql.ErrNoRows
-	})
-	defer monkey.UnpatchAll()
+	}
 
 	pageConfig, err := CreatePageConfig("0", "10", "priority", "ASC")
 	assert.Nil(t, err, "Create Page Config returned error")
 
-	paginationInfo, sortingInfo := GetReturnInfo(pageConfig)
+	paginationInfo, sortingInfo := GetReturnInfo(context.Background(), pageConfig)
 
 	assert.Equal(t, expectedPaginationInfo, paginationInfo, "Returned wrong pagination info")
 	assert.Equal(t, expectedSortingInfo, sortingInfo, "Returned wrong sorting info")
@@ -0,0 +1,235 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"mime"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/smtp"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// Notification channels of the reminders
+const (
+	ChannelWebhook = "webhook" // POST to the URL of REMINDER_WEBHOOK_URL
+	ChannelEmail   = "email"   // mail to the recipient of the reminder through the SMTP server of SMTP_ADDR
+)
+
+// Longest wait for a webhook response
+const webhookTimeout = 10 * time.Second
+
+// Longest time to connect to the SMTP server, and then to send the mail
+const smtpTimeout = 30 * time.Second
+
+// Notification is the message sent when a reminder fires
+type Notification struct {
+	ReminderId uint
+	TaskId     uint
+	TaskTitle  string
+	DueDate    time.Time // zero when the task has no due date
+	Username   string    // user reminded
+	Recipient  string    // address on the channel, empty when the channel has a fixed one
+	FireAt     time.Time
+}
+
+// Notifier delivers the reminders on a channel. Deliveries are retried when they fail, and may be
+// repeated when the API is killed during one, so the notifications carry the reminder id.
+type Notifier interface {
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// Notifiers by channel, only the configured channels accept reminders
+var notifiers = map[string]Notifier{}
+
+func SetNotifiers(channelNotifiers map[string]Notifier) {
+	notifiers = channelNotifiers
+}
+
+// WebhookNotifier posts the notifications as JSON to a fixed URL. With a secret, the body is signed
+// with HMAC-SHA256 in the X-Signature header ("sha256=" and the hex digest).
+type WebhookNotifier struct {
+	URL    string
+	Secret string
+	Client *http.Client
+}
+
+type webhookPayload struct {
+	ReminderId uint   `json:"reminder_id"`
+	TaskId     uint   `json:"task_id"`
+	Title      string `json:"title"`
+	DueDate    int64  `json:"due_date"` // 0 when the task has no due date
+	Username   string `json:"username"`
+	RemindAt   int64  `json:"remind_at"`
+}
+
+func NewWebhookNotifier(url string, secret string) *WebhookNotifier {
+	return &WebhookNotifier{URL: url, Secret: secret, Client: &http.Client{Timeout: webhookTimeout}}
+}
+
+func (n *WebhookNotifier) Notify(ctx context.Context, notification Notification) error {
+	payload := webhookPayload{
+		ReminderId: notification.ReminderId,
+		TaskId:     notification.TaskId,
+		Title:      notification.TaskTitle,
+		Username:   notification.Username,
+		RemindAt:   notification.FireAt.Unix(),
+	}
+	if !notification.DueDate.IsZero() {
+		payload.DueDate = notification.DueDate.Unix()
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, n.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("Idempotency-Key", fmt.Sprintf("reminder-%d", notification.ReminderId))
+	if n.Secret != "" {
+		mac := hmac.New(sha256.New, []byte(n.Secret))
+		mac.Write(body)
+		request.Header.Set("X-Signature", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	}
+
+	response, err := n.Client.Do(request)
+	if err != nil {
+		return err
+	}
+	response.Body.Close()
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", response.Status)
+	}
+
+	return nil
+}
+
+// SMTPNotifier mails the notifications to the recipient of the reminder. The server connection
+// switches to TLS when it supports STARTTLS, and authenticates when a username is given.
+type SMTPNotifier struct {
+	Addr     string // host:port of the server
+	From     string
+	Username string
+	Password string
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, notification Notification) error {
+	dialer := net.Dialer{Timeout: smtpTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", n.Addr)
+	if err != nil {
+		return err
+	}
+	// The SMTP client has no timeouts of its own, so the connection bounds the whole exchange
+	deadline := time.Now().Add(smtpTimeout)
+	if ctxDeadline, found := ctx.Deadline(); found && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	if err = conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	host, _, _ := net.SplitHostPort(n.Addr)
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if supported, _ := client.Extension("STARTTLS"); supported {
+		if err = client.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			return err
+		}
+	}
+	if n.Username != "" {
+		if err = client.Auth(smtp.PlainAuth("", n.Username, n.Password, host)); err != nil {
+			return err
+		}
+	}
+	if err = client.Mail(n.From); err != nil {
+		return err
+	}
+	if err = client.Rcpt(notification.Recipient); err != nil {
+		return err
+	}
+
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = writer.Write(n.message(notification)); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+
+	return client.Quit()
+}
+
+// Plain text mail of the notification. The title is encoded in the subject, so it can not add headers.
+func (n *SMTPNotifier) message(notification Notification) []byte {
+	due := "has no due date"
+	if !notification.DueDate.IsZero() {
+		due = "is due on " + notification.DueDate.UTC().Format("Mon, 02 Jan 2006")
+	}
+
+	var message strings.Builder
+	fmt.Fprintf(&message, "From: %s\r\n", n.From)
+	fmt.Fprintf(&message, "To: %s\r\n", notification.Recipient)
+	fmt.Fprintf(&message, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", "Reminder: "+notification.TaskTitle))
+	fmt.Fprintf(&message, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	// Same id on every delivery of the reminder, so the mail clients can drop the repeated ones
+	fmt.Fprintf(&message, "Message-ID: <reminder-%d@to-do-api>\r\n", notification.ReminderId)
+	message.WriteString("MIME-Version: 1.0\r\n")
+	message.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+	message.WriteString("Content-Transfer-Encoding: quoted-printable\r\n\r\n")
+	body := quotedprintable.NewWriter(&message)
+	fmt.Fprintf(body, "Hi %s,\r\n\r\nTask #%d %s:\r\n\r\n%s\r\n", notification.Username, notification.TaskId, due, notification.TaskTitle)
+	body.Close()
+
+	return []byte(message.String())
+}
+
+// LoadNotifiers configures the notification channels from the env variables: the webhook with
+// REMINDER_WEBHOOK_URL (and REMINDER_WEBHOOK_SECRET to sign it), the email with SMTP_ADDR and SMTP_FROM
+// (and SMTP_USERNAME, SMTP_PASSWORD to authenticate). Channels without configuration are disabled.
+func LoadNotifiers() map[string]Notifier {
+	channelNotifiers := map[string]Notifier{}
+
+	if webhookURL := os.Getenv("REMINDER_WEBHOOK_URL"); webhookURL != "" {
+		parsedURL, err := url.Parse(webhookURL)
+		if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+			log.Fatalf("Invalid REMINDER_WEBHOOK_URL value '%s', must be an http(s) URL", webhookURL)
+		}
+		channelNotifiers[ChannelWebhook] = NewWebhookNotifier(webhookURL, os.Getenv("REMINDER_WEBHOOK_SECRET"))
+	}
+
+	if smtpAddr := os.Getenv("SMTP_ADDR"); smtpAddr != "" {
+		if _, _, err := net.SplitHostPort(smtpAddr); err != nil {
+			log.Fatalf("Invalid SMTP_ADDR value '%s', must be host:port", smtpAddr)
+		}
+		from := os.Getenv("SMTP_FROM")
+		if from == "" {
+			log.Fatalf("SMTP_FROM must be defined with SMTP_ADDR")
+		}
+		channelNotifiers[ChannelEmail] = &SMTPNotifier{Addr: smtpAddr, From: from, Username: os.Getenv("SMTP_USERNAME"), Password: os.Getenv("SMTP_PASSWORD")}
+	}
+
+	return channelNotifiers
+}
@@ -0,0 +1,174 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testNotification = Notification{
+	ReminderId: 4,
+	TaskId:     1,
+	TaskTitle:  "Renew the certificate",
+	DueDate:    time.Unix(testDueDate, 0),
+	Username:   "ada",
+	Recipient:  "ada@example.com",
+	FireAt:     time.Unix(testDueDate-3600, 0),
+}
+
+func TestWebhookNotifier(t *testing.T) {
+	var body []byte
+	var header http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		header = r.Header
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.URL, "s3cret").Notify(context.Background(), testNotification)
+
+	assert.Nil(t, err)
+	var payload map[string]any
+	json.Unmarshal(body, &payload)
+	assert.Equal(t, map[string]any{
+		"reminder_id": float64(4), "task_id": float64(1), "title": "Renew the certificate",
+		"due_date": float64(testDueDate), "username": "ada", "remind_at": float64(testDueDate - 3600),
+	}, payload)
+	assert.Equal(t, "reminder-4", header.Get("Idempotency-Key"))
+	mac := hmac.New(sha256.New, []byte("s3cret"))
+	mac.Write(body)
+	assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), header.Get("X-Signature"), "Body should be signed with the secret")
+}
+
+func TestWebhookNotifierError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := NewWebhookNotifier(server.URL, "").Notify(context.Background(), testNotification)
+	assert.ErrorContains(t, err, "502", "Response not 2xx should fail the delivery")
+}
+
+// Local SMTP stand-in accepting one mail, sent on the channel with its envelope
+type testSMTPMail struct {
+	from string
+	to   []string
+	data string
+}
+
+func startTestSMTPServer(t *testing.T) (string, <-chan testSMTPMail) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	mails := make(chan testSMTPMail, 1)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		reader := bufio.NewReader(conn)
+		reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+		reply("220 localhost ESMTP test")
+		var mail testSMTPMail
+		for {
+			line, err := reader.ReadString('\n')
+			if err != nil {
+				return
+			}
+			command := strings.TrimRight(line, "\r\n")
+			switch verb := strings.ToUpper(strings.SplitN(command, " ", 2)[0]); verb {
+			case "EHLO", "HELO":
+				reply("250 localhost")
+			case "MAIL":
+				mail.from = command
+				reply("250 OK")
+			case "RCPT":
+				mail.to = append(mail.to, command)
+				reply("250 OK")
+			case "DATA":
+				reply("354 End data with <CR><LF>.<CR><LF>")
+				var data strings.Builder
+				for {
+					dataLine, err := reader.ReadString('\n')
+					if err != nil || dataLine == ".\r\n" {
+						break
+					}
+					data.WriteString(dataLine)
+				}
+				mail.data = data.String()
+				reply("250 OK")
+				mails <- mail
+			case "QUIT":
+				reply("221 Bye")
+				return
+			default:
+				reply("502 Command not implemented")
+			}
+		}
+	}()
+
+	return listener.Addr().String(), mails
+}
+
+func TestSMTPNotifier(t *testing.T) {
+	addr, mails := startTestSMTPServer(t)
+	notifier := &SMTPNotifier{Addr: addr, From: "todo@example.com"}
+
+	notification := testNotification
+	notification.TaskTitle = "Renew\r\nBcc: eve@example.com"
+	err := notifier.Notify(context.Background(), notification)
+
+	assert.Nil(t, err)
+	mail := <-mails
+	assert.Equal(t, "MAIL FROM:<todo@example.com>", mail.from)
+	assert.Equal(t, []string{"RCPT TO:<ada@example.com>"}, mail.to, "Only the recipient should get the mail")
+	assert.Contains(t, mail.data, "To: ada@example.com\r\n")
+	assert.Contains(t, mail.data, "Message-ID: <reminder-4@to-do-api>\r\n")
+	assert.Contains(t, mail.data, "Subject: =?utf-8?q?Reminder:_Renew=0D=0ABcc:_eve@example.com?=\r\n", "Title should be encoded")
+	headers, _, _ := strings.Cut(mail.data, "\r\n\r\n")
+	assert.NotContains(t, headers, "\r\nBcc:", "Title should not add headers")
+	assert.Contains(t, mail.data, "Task #1 is due on Wed, 11 Feb 2026")
+}
+
+func TestSMTPNotifierTimeout(t *testing.T) {
+	// Server accepting the connection but never answering
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("Unable to listen: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	go func() {
+		if conn, err := listener.Accept(); err == nil {
+			io.Copy(io.Discard, conn)
+			conn.Close()
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	notifier := &SMTPNotifier{Addr: listener.Addr().String(), From: "todo@example.com"}
+	start := time.Now()
+	err = notifier.Notify(ctx, testNotification)
+
+	assert.ErrorIs(t, err, os.ErrDeadlineExceeded, "Silent server should not block the delivery")
+	assert.Less(t, time.Since(start), time.Second)
+}
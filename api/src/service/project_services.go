@@ -0,0 +1,265 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"to-do-api/models"
+)
+
+type ProjectRequestBody struct {
+	Name        *string `json:"name"`
+	Description *string `json:"description"`
+	Archived    *bool   `json:"archived"`
+}
+
+type ProjectInfo struct {
+	Id          uint   `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Archived    bool   `json:"archived"`
+	CreatedAt   int64  `json:"created_at"`
+	Role        string `json:"role"`
+}
+
+type ProjectMemberRequestBody struct {
+	Username *string `json:"username"`
+	Role     *string `json:"role"`
+}
+
+type ProjectMemberInfo struct {
+	UserId   uint   `json:"user_id"`
+	Username string `json:"username"`
+	Role     string `json:"role"`
+}
+
+// Privilege of each role, a role allows everything the lower ones do
+var roleRank = map[string]int{models.RoleViewer: 1, models.RoleEditor: 2, models.RoleOwner: 3}
+
+func CreateProject(ctx context.Context, project ProjectRequestBody) (uint, error) {
+	newProject := models.Project{
+		Name:      *project.Name,
+		OwnerId:   currentUser(ctx),
+		CreatedAt: time.Now(),
+	}
+
+	// Optional fields
+	if project.Description != nil {
+		newProject.Description = *project.Description
+	}
+	if project.Archived != nil {
+		newProject.Archived = *project.Archived
+	}
+
+	projectId, err := projectRepository.AddProject(ctx, newProject)
+	if err != nil {
+		return 0, databaseError("Create Project", err)
+	}
+
+	return projectId, nil
+}
+
+// GetProjects lists the projects of the user, archived ones only when includeArchived is set
+func GetProjects(ctx context.Context, includeArchived bool) ([]ProjectInfo, error) {
+	projectsInfo := []ProjectInfo{}
+
+	projects, err := projectRepository.QueryProjects(ctx, currentUser(ctx), includeArchived)
+	if err != nil {
+		return projectsInfo, databaseError("Query Projects", err)
+	}
+
+	for _, project := range projects {
+		projectsInfo = append(projectsInfo, newProjectInfo(project))
+	}
+
+	return projectsInfo, nil
+}
+
+func GetProjectById(ctx context.Context, projectId uint) (ProjectInfo, error) {
+	project, err := projectRepository.QueryProject(ctx, currentUser(ctx), projectId)
+	if err != nil {
+		return ProjectInfo{}, databaseError("Query Project", err)
+	}
+
+	return newProjectInfo(project), nil
+}
+
+func UpdateProject(ctx context.Context, projectId uint, project ProjectRequestBody) error {
+	currentProject, err := queryProjectWithRole(ctx, projectId, models.RoleOwner)
+	if err != nil {
+		return err
+	}
+
+	if project.Name != nil {
+		currentProject.Name = *project.Name
+	}
+	if project.Description != nil {
+		currentProject.Description = *project.Description
+	}
+	if project.Archived != nil {
+		currentProject.Archived = *project.Archived
+	}
+
+	if err = projectRepository.UpdateProject(ctx, currentUser(ctx), currentProject); err != nil {
+		return databaseError("Update Project", err)
+	}
+
+	return nil
+}
+
+// DeleteProject removes the project, its tasks are kept without project
+func DeleteProject(ctx context.Context, projectId uint) error {
+	if _, err := queryProjectWithRole(ctx, projectId, models.RoleOwner); err != nil {
+		return err
+	}
+
+	if err := projectRepository.DeleteProject(ctx, currentUser(ctx), projectId); err != nil {
+		return databaseError("Delete Project", err)
+	}
+
+	return nil
+}
+
+func newProjectInfo(project models.Project) ProjectInfo {
+	return ProjectInfo{
+		Id:          project.Id,
+		Name:        project.Name,
+		Description: project.Description,
+		Archived:    project.Archived,
+		CreatedAt:   project.CreatedAt.Unix(),
+		Role:        project.Role,
+	}
+}
+
+// GetProjectMembers lists the users with access to the project, starting by the one who created it
+func GetProjectMembers(ctx context.Context, projectId uint) ([]ProjectMemberInfo, error) {
+	project, err := queryProjectWithRole(ctx, projectId, models.RoleViewer)
+	if err != nil {
+		return []ProjectMemberInfo{}, err
+	}
+
+	members, err := projectRepository.QueryProjectMembers(ctx, projectId)
+	if err != nil {
+		return []ProjectMemberInfo{}, databaseError("Query Project Members", err)
+	}
+	members = append([]models.ProjectMember{{UserId: project.OwnerId, Role: models.RoleOwner}}, members...)
+
+	membersInfo := []ProjectMemberInfo{}
+	for _, member := range members {
+		user, err := userRepository.QueryUser(ctx, member.UserId)
+		if err != nil {
+			return []ProjectMemberInfo{}, databaseError("Query User", err)
+		}
+		membersInfo = append(membersInfo, ProjectMemberInfo{UserId: member.UserId, Username: user.Username, Role: member.Role})
+	}
+
+	return membersInfo, nil
+}
+
+// SetProjectMember shares the project with the user, or changes its role when already shared
+func SetProjectMember(ctx context.Context, projectId uint, member ProjectMemberRequestBody) (uint, error) {
+	project, err := queryProjectWithRole(ctx, projectId, models.RoleOwner)
+	if err != nil {
+		return 0, err
+	}
+
+	user, err := userRepository.QueryUserByUsername(ctx, *member.Username)
+	if err != nil {
+		err = databaseError("Query User", err)
+		if errors.Is(err, ErrRowNotFound) {
+			return 0, fmt.Errorf("%w: user '%s' not found", ErrInvalidInput, *member.Username)
+		}
+		return 0, err
+	}
+	if user.Id == project.OwnerId {
+		return 0, fmt.Errorf("%w: the user who created the project is always its owner", ErrInvalidInput)
+	}
+
+	if err = projectRepository.SetProjectMember(ctx, projectId, models.ProjectMember{UserId: user.Id, Role: *member.Role}); err != nil {
+		return 0, databaseError("Set Project Member", err)
+	}
+
+	return user.Id, nil
+}
+
+// RemoveProjectMember stops sharing the project with the user. Members can remove themselves.
+func RemoveProjectMember(ctx context.Context, projectId uint, userId uint) error {
+	requiredRole := models.RoleOwner
+	if userId == currentUser(ctx) {
+		requiredRole = models.RoleViewer
+	}
+	if _, err := queryProjectWithRole(ctx, projectId, requiredRole); err != nil {
+		return err
+	}
+
+	if err := projectRepository.DeleteProjectMember(ctx, projectId, userId); err != nil {
+		return databaseError("Delete Project Member", err)
+	}
+
+	return nil
+}
+
+// Queries the project, failing with ErrForbidden when the user has a role below requiredRole
+func queryProjectWithRole(ctx context.Context, projectId uint, requiredRole string) (models.Project, error) {
+	project, err := projectRepository.QueryProject(ctx, currentUser(ctx), projectId)
+	if err != nil {
+		return models.Project{}, databaseError("Query Project", err)
+	}
+
+	if roleRank[project.Role] < roleRank[requiredRole] {
+		return models.Project{}, ErrForbidden
+	}
+
+	return project, nil
+}
+
+// Queries the task, failing with ErrForbidden when the user has a role below requiredRole.
+// Users are owners of the tasks they created, and have their project role on the others.
+func queryTaskWithRole(ctx context.Context, taskId uint, requiredRole string) (models.Task, error) {
+	task, err := taskRepository.QueryTask(ctx, currentUser(ctx), taskId)
+	if err != nil {
+		return models.Task{}, databaseError("Query Task", err)
+	}
+
+	if err = checkTaskRole(ctx, task, requiredRole); err != nil {
+		return models.Task{}, err
+	}
+
+	return task, nil
+}
+
+// Checks the user has at least requiredRole on the task, its creator having every right
+func checkTaskRole(ctx context.Context, task models.Task, requiredRole string) error {
+	if task.OwnerId == currentUser(ctx) {
+		return nil
+	}
+
+	_, err := queryProjectWithRole(ctx, task.ProjectId, requiredRole)
+	return err
+}
+
+// Checks the user can move a task to the project (0 meaning no project)
+func checkProjectAssignable(ctx context.Context, projectId uint) error {
+	if projectId == 0 {
+		return nil
+	}
+
+	project, err := projectRepository.QueryProject(ctx, currentUser(ctx), projectId)
+	if err != nil {
+		err = databaseError("Query Project", err)
+		if errors.Is(err, ErrRowNotFound) {
+			return fmt.Errorf("%w: project %d not found", ErrInvalidInput, projectId)
+		}
+		return err
+	}
+
+	if roleRank[project.Role] < roleRank[models.RoleEditor] {
+		return ErrForbidden
+	}
+	if project.Archived {
+		return ErrProjectArchived
+	}
+
+	return nil
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"to-do-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateAndGetProject(t *testing.T) {
+	setMockRepository()
+
+	name := "Backend"
+	description := "API work"
+	projectId, err := CreateProject(context.Background(), ProjectRequestBody{Name: &name, Description: &description})
+	assert.Nil(t, err)
+
+	project, err := GetProjectById(context.Background(), projectId)
+	assert.Nil(t, err)
+	assert.Equal(t, name, project.Name)
+	assert.Equal(t, description, project.Description)
+	assert.False(t, project.Archived)
+}
+
+func TestGetProjectsArchived(t *testing.T) {
+	setMockRepository()
+
+	name := "Backend"
+	archived := true
+	CreateProject(context.Background(), ProjectRequestBody{Name: &name})
+	archivedId, _ := CreateProject(context.Background(), ProjectRequestBody{Name: &name})
+	UpdateProject(context.Background(), archivedId, ProjectRequestBody{Archived: &archived})
+
+	projects, err := GetProjects(context.Background(), false)
+	assert.Nil(t, err)
+	assert.Len(t, projects, 1)
+
+	projects, err = GetProjects(context.Background(), true)
+	assert.Nil(t, err)
+	assert.Len(t, projects, 2)
+}
+
+func TestDeleteProjectInexistent(t *testing.T) {
+	setMockRepository()
+
+	err := DeleteProject(context.Background(), 1)
+
+	assert.Equal(t, ErrRowNotFound, err)
+}
+
+func TestMoveTaskBetweenProjects(t *testing.T) {
+	setMockRepository()
+
+	name := "Backend"
+	firstId, _ := CreateProject(context.Background(), ProjectRequestBody{Name: &name})
+	secondId, _ := CreateProject(context.Background(), ProjectRequestBody{Name: &name})
+
+	title := "Test Task"
+	taskId, err := CreateNewTask(context.Background(), TaskRequestBody{Title: &title, ProjectId: &firstId})
+	assert.Nil(t, err)
+
+	err = PatchTask(context.Background(), taskId, TaskPatch{Set: TaskRequestBody{ProjectId: &secondId}}, AnyVersion)
+	assert.Nil(t, err)
+	task, _ := GetTaskById(context.Background(), taskId)
+	assert.Equal(t, secondId, task.ProjectId)
+
+	noProject := uint(0)
+	err = PatchTask(context.Background(), taskId, TaskPatch{Set: TaskRequestBody{ProjectId: &noProject}}, AnyVersion)
+	assert.Nil(t, err)
+	task, _ = GetTaskById(context.Background(), taskId)
+	assert.Equal(t, uint(0), task.ProjectId)
+}
+
+func TestCreateTaskInvalidProject(t *testing.T) {
+	setMockRepository()
+
+	title := "Test Task"
+	projectId := uint(5)
+	_, err := CreateNewTask(context.Background(), TaskRequestBody{Title: &title, ProjectId: &projectId})
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+	assert.EqualError(t, err, "invalid input: project 5 not found")
+}
+
+func TestCreateTaskArchivedProject(t *testing.T) {
+	setMockRepository()
+
+	name := "Backend"
+	archived := true
+	projectId, _ := CreateProject(context.Background(), ProjectRequestBody{Name: &name, Archived: &archived})
+
+	title := "Test Task"
+	_, err := CreateNewTask(context.Background(), TaskRequestBody{Title: &title, ProjectId: &projectId})
+
+	assert.Equal(t, ErrProjectArchived, err)
+}
+
+// Project sharing test /////////////////////////////////////////////////
+func TestProjectRoles(t *testing.T) {
+	setMockRepository()
+	SetUserRepository(models.NewMemoryUserRepository())
+	ownerId, _ := userRepository.AddUser(context.Background(), models.User{Username: "alice"})
+	memberId, _ := userRepository.AddUser(context.Background(), models.User{Username: "bob"})
+	ownerCtx := ContextWithUser(context.Background(), ownerId)
+	memberCtx := ContextWithUser(context.Background(), memberId)
+
+	name := "Backend"
+	projectId, _ := CreateProject(ownerCtx, ProjectRequestBody{Name: &name})
+	title := "Test Task"
+	taskId, _ := CreateNewTask(ownerCtx, TaskRequestBody{Title: &title, ProjectId: &projectId})
+
+	_, err := GetTaskById(memberCtx, taskId)
+	assert.Equal(t, ErrRowNotFound, err, "Tasks should not be visible before sharing the project")
+
+	username := "bob"
+	role := models.RoleViewer
+	_, err = SetProjectMember(ownerCtx, projectId, ProjectMemberRequestBody{Username: &username, Role: &role})
+	assert.Nil(t, err)
+
+	_, err = GetTaskById(memberCtx, taskId)
+	assert.Nil(t, err, "Viewers should read the tasks")
+	assert.Equal(t, ErrForbidden, PatchTask(memberCtx, taskId, TaskPatch{Set: TaskRequestBody{Title: &title}}, AnyVersion), "Viewers should not update tasks")
+	assert.Equal(t, ErrForbidden, DeleteTask(memberCtx, taskId, AnyVersion), "Viewers should not delete tasks")
+	_, err = CreateNewTask(memberCtx, TaskRequestBody{Title: &title, ProjectId: &projectId})
+	assert.Equal(t, ErrForbidden, err, "Viewers should not add tasks to the project")
+
+	role = models.RoleEditor
+	SetProjectMember(ownerCtx, projectId, ProjectMemberRequestBody{Username: &username, Role: &role})
+
+	assert.Nil(t, PatchTask(memberCtx, taskId, TaskPatch{Set: TaskRequestBody{Title: &title}}, AnyVersion), "Editors should update tasks")
+	assert.Equal(t, ErrForbidden, DeleteProject(memberCtx, projectId), "Editors should not delete the project")
+	_, err = SetProjectMember(memberCtx, projectId, ProjectMemberRequestBody{Username: &username, Role: &role})
+	assert.Equal(t, ErrForbidden, err, "Editors should not share the project")
+
+	members, err := GetProjectMembers(memberCtx, projectId)
+	assert.Nil(t, err)
+	assert.Equal(t, []ProjectMemberInfo{{ownerId, "alice", models.RoleOwner}, {memberId, "bob", models.RoleEditor}}, members)
+
+	assert.Nil(t, RemoveProjectMember(memberCtx, projectId, memberId), "Members should be able to leave the project")
+	_, err = GetProjectById(memberCtx, projectId)
+	assert.Equal(t, ErrRowNotFound, err, "Project should not be visible after leaving it")
+}
+
+func TestSetProjectMemberInexistentUser(t *testing.T) {
+	setMockRepository()
+	SetUserRepository(models.NewMemoryUserRepository())
+
+	name := "Backend"
+	projectId, _ := CreateProject(context.Background(), ProjectRequestBody{Name: &name})
+
+	username := "nobody"
+	role := models.RoleViewer
+	_, err := SetProjectMember(context.Background(), projectId, ProjectMemberRequestBody{Username: &username, Role: &role})
+
+	assert.ErrorIs(t, err, ErrInvalidInput)
+}
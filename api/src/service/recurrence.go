@@ -0,0 +1,391 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Days searched for the occurrences of a rule (about 137 years), so a rule no date matches
+// (like FREQ=MONTHLY;BYMONTH=2 starting on a 31st) ends instead of looping forever
+const maxRecurrenceDays = 50000
+
+// Longest rule accepted, stored as text on the series
+const maxRecurrenceLength = 500
+
+const (
+	FreqDaily   = "DAILY"
+	FreqWeekly  = "WEEKLY"
+	FreqMonthly = "MONTHLY"
+	FreqYearly  = "YEARLY"
+)
+
+var weekdayCodes = []string{"SU", "MO", "TU", "WE", "TH", "FR", "SA"}
+
+// Subset of the RFC 5545 RRULE supported by the recurring tasks: the frequencies from daily
+// to yearly with INTERVAL, COUNT, UNTIL, BYDAY, BYMONTHDAY, BYMONTH and WKST.
+// The due date of the first task of the series is the DTSTART of the rule, always its first occurrence.
+type recurrenceRule struct {
+	freq       string
+	interval   int
+	count      int       // occurrences of the series, 0 when unbounded
+	until      time.Time // last time an occurrence can be due, zero when unbounded
+	byDay      []weekdayNum
+	byMonthDay []int // negative days count from the end of the month
+	byMonth    []int
+	weekStart  time.Weekday
+}
+
+// Weekday of BYDAY, like MO or with an ordinal within the month or year like -1FR
+type weekdayNum struct {
+	weekday time.Weekday
+	ordinal int // 0 for every such weekday
+}
+
+// Reads an RRULE value, with or without the "RRULE:" prefix
+func parseRecurrenceRule(value string) (recurrenceRule, error) {
+	value = strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(value)), "RRULE:")
+	rule := recurrenceRule{interval: 1, weekStart: time.Monday}
+	if value == "" {
+		return rule, errors.New("rule must not be empty")
+	}
+
+	seen := map[string]bool{}
+	for _, part := range strings.Split(value, ";") {
+		name, partValue, found := strings.Cut(part, "=")
+		if !found || partValue == "" {
+			return rule, fmt.Errorf("invalid part '%s', must be NAME=VALUE", part)
+		}
+		if seen[name] {
+			return rule, fmt.Errorf("'%s' is defined twice", name)
+		}
+		seen[name] = true
+
+		var err error
+		switch name {
+		case "FREQ":
+			if !slices.Contains([]string{FreqDaily, FreqWeekly, FreqMonthly, FreqYearly}, partValue) {
+				return rule, fmt.Errorf("unsupported FREQ '%s'. Valid values: [%s, %s, %s, %s]", partValue, FreqDaily, FreqWeekly, FreqMonthly, FreqYearly)
+			}
+			rule.freq = partValue
+		case "INTERVAL":
+			rule.interval, err = parseRulePositive(name, partValue, 1000)
+		case "COUNT":
+			rule.count, err = parseRulePositive(name, partValue, 10000)
+		case "UNTIL":
+			rule.until, err = parseRuleUntil(partValue)
+		case "BYDAY":
+			rule.byDay, err = parseRuleByDay(partValue)
+		case "BYMONTHDAY":
+			rule.byMonthDay, err = parseRuleList(name, partValue, 31, true)
+		case "BYMONTH":
+			rule.byMonth, err = parseRuleList(name, partValue, 12, false)
+		case "WKST":
+			index := slices.Index(weekdayCodes, partValue)
+			if index < 0 {
+				return rule, fmt.Errorf("invalid WKST '%s'", partValue)
+			}
+			rule.weekStart = time.Weekday(index)
+		default:
+			return rule, fmt.Errorf("unsupported part '%s'", name)
+		}
+		if err != nil {
+			return rule, err
+		}
+	}
+
+	return rule, rule.validate()
+}
+
+func (r recurrenceRule) validate() error {
+	if r.freq == "" {
+		return errors.New("missing required part 'FREQ'")
+	}
+	if r.count > 0 && !r.until.IsZero() {
+		return errors.New("'COUNT' and 'UNTIL' can not be both defined")
+	}
+	if r.freq == FreqWeekly && len(r.byMonthDay) > 0 {
+		return errors.New("'BYMONTHDAY' is not allowed with FREQ=WEEKLY")
+	}
+	if len(r.byMonthDay) > 0 && !r.hasMonthDay() {
+		return errors.New("no month of 'BYMONTH' has the days of 'BYMONTHDAY'")
+	}
+	for _, day := range r.byDay {
+		if day.ordinal != 0 && r.freq != FreqMonthly && r.freq != FreqYearly {
+			return errors.New("'BYDAY' ordinals are only allowed with FREQ=MONTHLY or FREQ=YEARLY")
+		}
+		if day.ordinal != 0 && r.freq == FreqMonthly && (day.ordinal < -5 || day.ordinal > 5) {
+			return fmt.Errorf("'BYDAY' ordinal %d must be between -5 and 5 with FREQ=MONTHLY", day.ordinal)
+		}
+	}
+
+	return nil
+}
+
+// Whether a day of BYMONTHDAY falls in a month of BYMONTH (any month when not defined)
+func (r recurrenceRule) hasMonthDay() bool {
+	months := r.byMonth
+	if len(months) == 0 {
+		months = []int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12}
+	}
+	return slices.ContainsFunc(months, func(month int) bool {
+		// Days of the month on a leap year
+		daysInMonth := time.Date(2024, time.Month(month)+1, 0, 0, 0, 0, 0, time.UTC).Day()
+		return slices.ContainsFunc(r.byMonthDay, func(monthDay int) bool { return max(monthDay, -monthDay) <= daysInMonth })
+	})
+}
+
+func parseRulePositive(name string, value string, max int) (int, error) {
+	number, err := strconv.Atoi(value)
+	if err != nil || number < 1 || number > max {
+		return 0, fmt.Errorf("'%s' must be a number between 1 and %d", name, max)
+	}
+	return number, nil
+}
+
+// Values between 1 and max, or between -max and -1 when negative values are allowed
+func parseRuleList(name string, value string, max int, negative bool) ([]int, error) {
+	numbers := []int{}
+	for _, item := range strings.Split(value, ",") {
+		number, err := strconv.Atoi(item)
+		if err != nil || number == 0 || number > max || number < -max || (!negative && number < 0) {
+			return []int{}, fmt.Errorf("invalid '%s' value '%s'", name, item)
+		}
+		numbers = append(numbers, number)
+	}
+	return numbers, nil
+}
+
+func parseRuleByDay(value string) ([]weekdayNum, error) {
+	days := []weekdayNum{}
+	for _, item := range strings.Split(value, ",") {
+		if len(item) < 2 {
+			return []weekdayNum{}, fmt.Errorf("invalid 'BYDAY' value '%s'", item)
+		}
+		index := slices.Index(weekdayCodes, item[len(item)-2:])
+		ordinal := 0
+		var err error
+		if prefix := item[:len(item)-2]; prefix != "" {
+			ordinal, err = strconv.Atoi(prefix)
+		}
+		if index < 0 || err != nil || ordinal > 53 || ordinal < -53 || (ordinal == 0 && len(item) > 2) {
+			return []weekdayNum{}, fmt.Errorf("invalid 'BYDAY' value '%s'", item)
+		}
+		days = append(days, weekdayNum{weekday: time.Weekday(index), ordinal: ordinal})
+	}
+	return days, nil
+}
+
+// UNTIL as a UTC date-time (20250131T100000Z), or a date including the whole day (20250131)
+func parseRuleUntil(value string) (time.Time, error) {
+	if until, err := time.Parse("20060102T150405Z", value); err == nil {
+		return until, nil
+	}
+	if until, err := time.Parse("20060102", value); err == nil {
+		return until.Add(24*time.Hour - time.Second), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid 'UNTIL' value '%s', must be like 20250131 or 20250131T100000Z", value)
+}
+
+// Canonical form of the rule, as stored on the series
+func (r recurrenceRule) String() string {
+	parts := []string{"FREQ=" + r.freq}
+	if r.interval > 1 {
+		parts = append(parts, "INTERVAL="+strconv.Itoa(r.interval))
+	}
+	if r.count > 0 {
+		parts = append(parts, "COUNT="+strconv.Itoa(r.count))
+	}
+	if !r.until.IsZero() {
+		parts = append(parts, "UNTIL="+r.until.UTC().Format("20060102T150405Z"))
+	}
+	if len(r.byMonth) > 0 {
+		parts = append(parts, "BYMONTH="+joinInts(r.byMonth))
+	}
+	if len(r.byMonthDay) > 0 {
+		parts = append(parts, "BYMONTHDAY="+joinInts(r.byMonthDay))
+	}
+	if len(r.byDay) > 0 {
+		days := []string{}
+		for _, day := range r.byDay {
+			code := weekdayCodes[day.weekday]
+			if day.ordinal != 0 {
+				code = strconv.Itoa(day.ordinal) + code
+			}
+			days = append(days, code)
+		}
+		parts = append(parts, "BYDAY="+strings.Join(days, ","))
+	}
+	if r.weekStart != time.Monday {
+		parts = append(parts, "WKST="+weekdayCodes[r.weekStart])
+	}
+
+	return strings.Join(parts, ";")
+}
+
+func joinInts(numbers []int) string {
+	items := []string{}
+	for _, number := range numbers {
+		items = append(items, strconv.Itoa(number))
+	}
+	return strings.Join(items, ",")
+}
+
+// Up to limit occurrences of the series starting at start that are due after after, in order
+func (r recurrenceRule) occurrencesAfter(start time.Time, after time.Time, limit int) []time.Time {
+	occurrences := []time.Time{}
+	if limit <= 0 {
+		return occurrences
+	}
+
+	r.iterate(start, func(occurrence time.Time) bool {
+		if occurrence.After(after) {
+			occurrences = append(occurrences, occurrence)
+		}
+		return len(occurrences) < limit
+	})
+
+	return occurrences
+}
+
+// Rule continuing the series from the occurrence due at splitAt, the COUNT reduced by the occurrences before it
+func (r recurrenceRule) continuedAt(start time.Time, splitAt time.Time) recurrenceRule {
+	if r.count == 0 {
+		return r
+	}
+
+	before := 0
+	r.iterate(start, func(occurrence time.Time) bool {
+		if !occurrence.Before(splitAt) {
+			return false
+		}
+		before++
+		return true
+	})
+	r.count = max(r.count-before, 1)
+
+	return r
+}
+
+// Calls yield with the occurrences of the series in order, until it returns false or the series ends
+func (r recurrenceRule) iterate(start time.Time, yield func(time.Time) bool) {
+	emitted := 0
+	emit := func(occurrence time.Time) bool {
+		if (!r.until.IsZero() && occurrence.After(r.until)) || (r.count > 0 && emitted == r.count) {
+			return false
+		}
+		emitted++
+		return yield(occurrence)
+	}
+
+	if !emit(start) {
+		return
+	}
+	for period, scanned := 0, 0; scanned < maxRecurrenceDays; period++ {
+		days, length := r.periodDays(start, period)
+		scanned += length
+		for _, day := range days {
+			occurrence := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), start.Second(), 0, start.Location())
+			if occurrence.After(start) && !emit(occurrence) {
+				return
+			}
+		}
+	}
+}
+
+// Days of the period number period since the start matching the rule, in order, and the length of the period
+func (r recurrenceRule) periodDays(start time.Time, period int) ([]time.Time, int) {
+	startDay := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, start.Location())
+	var first time.Time
+	var length int
+
+	switch r.freq {
+	case FreqDaily:
+		first, length = startDay.AddDate(0, 0, period*r.interval), 1
+	case FreqWeekly:
+		weekOffset := (int(startDay.Weekday()) - int(r.weekStart) + 7) % 7
+		first, length = startDay.AddDate(0, 0, period*r.interval*7-weekOffset), 7
+	case FreqMonthly:
+		first = time.Date(start.Year(), start.Month()+time.Month(period*r.interval), 1, 0, 0, 0, 0, start.Location())
+		length = first.AddDate(0, 1, -1).Day()
+	default:
+		first = time.Date(start.Year()+period*r.interval, time.January, 1, 0, 0, 0, 0, start.Location())
+		length = first.AddDate(1, 0, -1).YearDay()
+	}
+
+	days := []time.Time{}
+	for i := 0; i < length; i++ {
+		day := first.AddDate(0, 0, i)
+		if r.matches(day, start) {
+			days = append(days, day)
+		}
+	}
+
+	return days, length
+}
+
+// Whether the day is an occurrence within its period, the parts missing from the rule taking their value from the start
+func (r recurrenceRule) matches(day time.Time, start time.Time) bool {
+	if len(r.byMonth) > 0 && !slices.Contains(r.byMonth, int(day.Month())) {
+		return false
+	}
+
+	switch r.freq {
+	case FreqDaily:
+		return r.matchesMonthDay(day) && r.matchesWeekday(day)
+	case FreqWeekly:
+		if len(r.byDay) == 0 {
+			return day.Weekday() == start.Weekday()
+		}
+		return r.matchesWeekday(day)
+	case FreqMonthly:
+		return r.matchesInMonth(day, start)
+	default:
+		switch {
+		case len(r.byMonthDay) > 0 || (len(r.byMonth) > 0 && len(r.byDay) > 0):
+			return r.matchesInMonth(day, start)
+		case len(r.byDay) > 0:
+			daysInYear := time.Date(day.Year(), time.December, 31, 0, 0, 0, 0, day.Location()).YearDay()
+			return r.matchesNthWeekday(day, day.YearDay(), daysInYear)
+		case len(r.byMonth) > 0:
+			return day.Day() == start.Day()
+		default:
+			return day.Month() == start.Month() && day.Day() == start.Day()
+		}
+	}
+}
+
+func (r recurrenceRule) matchesInMonth(day time.Time, start time.Time) bool {
+	if len(r.byMonthDay) == 0 && len(r.byDay) == 0 {
+		return day.Day() == start.Day()
+	}
+	daysInMonth := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+	return r.matchesMonthDay(day) && (len(r.byDay) == 0 || r.matchesNthWeekday(day, day.Day(), daysInMonth))
+}
+
+func (r recurrenceRule) matchesMonthDay(day time.Time) bool {
+	if len(r.byMonthDay) == 0 {
+		return true
+	}
+	daysInMonth := time.Date(day.Year(), day.Month()+1, 0, 0, 0, 0, 0, day.Location()).Day()
+	return slices.ContainsFunc(r.byMonthDay, func(monthDay int) bool {
+		return monthDay == day.Day() || (monthDay < 0 && daysInMonth+monthDay+1 == day.Day())
+	})
+}
+
+func (r recurrenceRule) matchesWeekday(day time.Time) bool {
+	return len(r.byDay) == 0 || slices.ContainsFunc(r.byDay, func(byDay weekdayNum) bool { return byDay.weekday == day.Weekday() })
+}
+
+// Whether the day is one of the BYDAY weekdays, at its ordinal within the month or year when defined.
+// dayOfSpan is the day number within the month or year, of spanLength days.
+func (r recurrenceRule) matchesNthWeekday(day time.Time, dayOfSpan int, spanLength int) bool {
+	nth := (dayOfSpan-1)/7 + 1
+	nthFromEnd := (spanLength-dayOfSpan)/7 + 1
+	return slices.ContainsFunc(r.byDay, func(byDay weekdayNum) bool {
+		return byDay.weekday == day.Weekday() && (byDay.ordinal == 0 || byDay.ordinal == nth || byDay.ordinal == -nthFromEnd)
+	})
+}
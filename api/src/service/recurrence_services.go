@@ -0,0 +1,254 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"to-do-api/models"
+)
+
+// Occurrences previewed when no count is requested, and at most
+const defaultOccurrenceCount = 5
+const maxOccurrenceCount = 50
+
+// Occurrences of a recurring task a change applies to
+const (
+	ScopeThis   = "this"   // only the task
+	ScopeFuture = "future" // the task and the next occurrences, the series being split from the task on
+)
+
+// The occurrence was generated by a concurrent change, so this one generates nothing
+var errOccurrenceGenerated = errors.New("next occurrence already generated")
+
+// PreviewOccurrences lists the due dates of the next count occurrences following the task.
+// With a rule, they are the occurrences the task would have with it, the task being the first one.
+func PreviewOccurrences(ctx context.Context, taskId uint, rule string, count int) ([]int64, error) {
+	task, err := taskRepository.QueryTask(ctx, currentUser(ctx), taskId)
+	if err != nil {
+		return []int64{}, databaseError("Query Task", err)
+	}
+
+	start := task.DueDate
+	if rule == "" {
+		if task.SeriesId == 0 {
+			return []int64{}, nil
+		}
+		series, err := seriesRepository.QuerySeries(ctx, task.SeriesId)
+		if err != nil {
+			return []int64{}, databaseError("Query Series", err)
+		}
+		rule, start = series.Rule, series.StartAt
+	} else if task.DueDate.IsZero() {
+		return []int64{}, fmt.Errorf("%w: recurring tasks need a due date", ErrInvalidInput)
+	}
+
+	parsedRule, err := parseRecurrenceRule(rule)
+	if err != nil {
+		return []int64{}, fmt.Errorf("%w: invalid recurrence: %v", ErrInvalidInput, err)
+	}
+
+	occurrences := []int64{}
+	for _, occurrence := range parsedRule.occurrencesAfter(start, task.DueDate, count) {
+		occurrences = append(occurrences, occurrence.Unix())
+	}
+
+	return occurrences, nil
+}
+
+// PatchFutureOccurrences applies the merge patch to the recurring task and to its next occurrences already
+// generated, and to the ones to come: the series is split, the task starting a new series with the patched
+// fields as template. The due date and the recurrence of the next occurrences are left as they are.
+// Tasks that do not recur are patched as with PatchTask.
+func PatchFutureOccurrences(ctx context.Context, taskId uint, patch TaskPatch, expectedVersion uint) error {
+	return retryOnConflict(expectedVersion, func() error {
+		return inTransaction(ctx, "Update Task", func(txCtx context.Context) error {
+			previousTask, err := queryTaskWithVersion(txCtx, taskId, expectedVersion)
+			if err != nil {
+				return err
+			}
+			currentTask := patch.apply(previousTask)
+			if previousTask.SeriesId == 0 {
+				return storeTaskChange(txCtx, previousTask, currentTask)
+			}
+
+			occurrences, err := seriesRepository.QuerySeriesTasks(txCtx, currentUser(txCtx), previousTask.SeriesId)
+			if err != nil {
+				return databaseError("Query Series Tasks", err)
+			}
+			if currentTask.SeriesId, err = splitSeries(txCtx, previousTask, currentTask, occurrences); err != nil {
+				return err
+			}
+			if err = storeTaskChange(txCtx, previousTask, currentTask); err != nil {
+				return err
+			}
+
+			// Only the fields shared by the occurrences change on the next ones
+			occurrencePatch := TaskPatch{Set: patch.Set, Cleared: []string{}}
+			occurrencePatch.Set.DueDate, occurrencePatch.Set.Recurrence = nil, nil
+			for _, field := range patch.Cleared {
+				if field != "due_date" && field != "recurrence" {
+					occurrencePatch.Cleared = append(occurrencePatch.Cleared, field)
+				}
+			}
+			for _, occurrence := range occurrences {
+				if occurrence.Id <= taskId {
+					continue
+				}
+				if err = checkTaskRole(txCtx, occurrence, models.RoleEditor); err != nil {
+					return err
+				}
+				changedOccurrence := occurrencePatch.apply(occurrence)
+				changedOccurrence.SeriesId, changedOccurrence.Recurrence = currentTask.SeriesId, currentTask.Recurrence
+				if err = storeTaskChange(txCtx, occurrence, changedOccurrence); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+	})
+}
+
+// Starts the series continuing the one of previousTask from currentTask, its changed version, on.
+// Returns 0 when the recurrence was removed. The latest occurrence of the split series stays the latest one.
+func splitSeries(ctx context.Context, previousTask models.Task, currentTask models.Task, occurrences []models.Task) (uint, error) {
+	if currentTask.Recurrence == "" {
+		return 0, nil
+	}
+	if err := checkRecurrence(currentTask); err != nil {
+		return 0, err
+	}
+
+	series, err := seriesRepository.QuerySeries(ctx, previousTask.SeriesId)
+	if err != nil {
+		return 0, databaseError("Query Series", err)
+	}
+
+	newSeries := seriesTemplate(currentTask)
+	if currentTask.Recurrence == previousTask.Recurrence {
+		rule, err := parseRecurrenceRule(series.Rule)
+		if err != nil {
+			return 0, fmt.Errorf("%w: invalid recurrence of series %d: %v", ErrDatabaseGeneral, series.Id, err)
+		}
+		newSeries.Rule = rule.continuedAt(series.StartAt, previousTask.DueDate).String()
+	}
+
+	// The latest occurrence is the task or one of the next occurrences, unless it was purged
+	if series.LatestTaskId != currentTask.Id {
+		newSeries.LatestTaskId, newSeries.LatestDue = 0, series.LatestDue
+		for _, occurrence := range occurrences {
+			if occurrence.Id == series.LatestTaskId && occurrence.Id > currentTask.Id {
+				newSeries.LatestTaskId = series.LatestTaskId
+			}
+		}
+	}
+
+	seriesId, err := seriesRepository.AddSeries(ctx, newSeries)
+	if err != nil {
+		return 0, databaseError("Add Series", err)
+	}
+
+	return seriesId, nil
+}
+
+// Starts a series with the task as its first and latest occurrence, returning its id
+func startSeries(ctx context.Context, task models.Task) (uint, error) {
+	seriesId, err := seriesRepository.AddSeries(ctx, seriesTemplate(task))
+	if err != nil {
+		return 0, databaseError("Add Series", err)
+	}
+
+	return seriesId, nil
+}
+
+// Series of the task recurrence starting at its due date, with the task as latest occurrence and template
+func seriesTemplate(task models.Task) models.TaskSeries {
+	return models.TaskSeries{
+		Rule:          task.Recurrence,
+		StartAt:       task.DueDate,
+		Title:         task.Title,
+		Description:   task.Description,
+		Priority:      task.Priority,
+		EstimateHours: task.EstimateHours,
+		LatestTaskId:  task.Id,
+		LatestDue:     task.DueDate,
+	}
+}
+
+// Creates the occurrence following the task from the template of its series, when the task is its
+// latest occurrence and the series did not end. The occurrence is due on the next date of the rule
+// after the date it gave to the task, so moving the task does not move the series.
+func generateNextOccurrence(ctx context.Context, task models.Task) error {
+	series, err := seriesRepository.QuerySeries(ctx, task.SeriesId)
+	if err != nil {
+		return databaseError("Query Series", err)
+	}
+	if series.LatestTaskId != task.Id {
+		return nil
+	}
+
+	rule, err := parseRecurrenceRule(series.Rule)
+	if err != nil {
+		return fmt.Errorf("%w: invalid recurrence of series %d: %v", ErrDatabaseGeneral, series.Id, err)
+	}
+	next := rule.occurrencesAfter(series.StartAt, series.LatestDue, 1)
+	if len(next) == 0 {
+		return nil
+	}
+
+	nextTask := models.Task{
+		Title:         series.Title,
+		Description:   series.Description,
+		Status:        workflow.initialStatus(),
+		Priority:      series.Priority,
+		CreatedAt:     time.Now(),
+		DueDate:       next[0],
+		EstimateHours: series.EstimateHours,
+		OwnerId:       task.OwnerId,
+		ProjectId:     task.ProjectId,
+		ParentId:      task.ParentId,
+		SeriesId:      series.Id,
+		Recurrence:    series.Rule,
+	}
+
+	// Savepoint, so the occurrence is dropped when a concurrent change generated it first
+	err = inTransaction(ctx, "Generate Occurrence", func(txCtx context.Context) error {
+		nextTaskId, err := taskRepository.AddTask(txCtx, nextTask)
+		if err != nil {
+			return databaseError("Create Task", err)
+		}
+
+		advanced, err := seriesRepository.AdvanceSeries(txCtx, series.Id, task.Id, nextTaskId, nextTask.DueDate)
+		if err != nil {
+			return databaseError("Advance Series", err)
+		} else if !advanced {
+			return errOccurrenceGenerated
+		}
+		return recordTaskEvent(txCtx, models.ActionCreate, nextTaskId, nil, &nextTask)
+	})
+	if errors.Is(err, errOccurrenceGenerated) {
+		return nil
+	}
+
+	return err
+}
+
+// Recurring tasks need a due date, the first occurrence of their rule
+func checkRecurrence(task models.Task) error {
+	if task.Recurrence != "" && task.DueDate.IsZero() {
+		return fmt.Errorf("%w: recurring tasks need a due date", ErrInvalidInput)
+	}
+
+	return nil
+}
+
+// Canonical form of a validated recurrence, so the same rule is always stored the same way
+func normalizeRecurrence(recurrence string) string {
+	rule, err := parseRecurrenceRule(recurrence)
+	if err != nil {
+		return strings.TrimSpace(recurrence)
+	}
+
+	return rule.String()
+}
@@ -0,0 +1,145 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Creates a task recurring with the rule, due on testDueDate
+func createRecurringTask(t *testing.T, title string, rule string) uint {
+	dueDate := testDueDate
+	taskId, err := CreateNewTask(context.Background(), TaskRequestBody{Title: &title, DueDate: &dueDate, Recurrence: &rule})
+	assert.Nil(t, err)
+	return taskId
+}
+
+// Moves the task to the status through the default workflow
+func moveTask(t *testing.T, taskId uint, statuses ...string) {
+	for _, status := range statuses {
+		err := PatchTask(context.Background(), taskId, TaskPatch{Set: TaskRequestBody{Status: &status}}, AnyVersion)
+		assert.Nil(t, err)
+	}
+}
+
+func TestRecurringTaskGeneratesNextOccurrence(t *testing.T) {
+	repository := setMockRepository()
+	createRecurringTask(t, "Water the plants", "rrule:freq=daily;count=3")
+
+	first, _ := repository.QueryTask(context.Background(), noUser, 1)
+	assert.NotZero(t, first.SeriesId, "Recurring task should start a series")
+	assert.Equal(t, "FREQ=DAILY;COUNT=3", first.Recurrence, "Recurrence should be normalized")
+
+	moveTask(t, 1, "open", "done")
+	next, err := repository.QueryTask(context.Background(), noUser, 2)
+	assert.Nil(t, err, "Done occurrence should generate the next one")
+	assert.Equal(t, "Water the plants", next.Title)
+	assert.Equal(t, "backlog", next.Status)
+	assert.Equal(t, first.SeriesId, next.SeriesId)
+	assert.Equal(t, time.Unix(testDueDate, 0).AddDate(0, 0, 1), next.DueDate)
+
+	// Done again, the first occurrence is no longer the latest one
+	moveTask(t, 1, "open", "done")
+	total, _ := repository.GetAmountOfTasks(context.Background(), noUser)
+	assert.Equal(t, uint(2), total, "Next occurrence should be generated once")
+
+	moveTask(t, 2, "open", "done")
+	moveTask(t, 3, "open", "done")
+	total, _ = repository.GetAmountOfTasks(context.Background(), noUser)
+	assert.Equal(t, uint(3), total, "Series should end after COUNT occurrences")
+}
+
+func TestRecurringTaskNeedsDueDate(t *testing.T) {
+	setMockRepository()
+	title := "Water the plants"
+	rule := "FREQ=DAILY"
+
+	_, err := CreateNewTask(context.Background(), TaskRequestBody{Title: &title, Recurrence: &rule})
+	assert.ErrorIs(t, err, ErrInvalidInput, "Recurring task without due date should be rejected")
+
+	createRecurringTask(t, title, rule)
+	err = PatchTask(context.Background(), 1, TaskPatch{Cleared: []string{"due_date"}}, AnyVersion)
+	assert.ErrorIs(t, err, ErrInvalidInput, "Due date of a recurring task should not be removed")
+}
+
+func TestPatchOccurrenceRecurrence(t *testing.T) {
+	repository := setMockRepository()
+	createRecurringTask(t, "Water the plants", "FREQ=DAILY")
+	moveTask(t, 1, "open", "done")
+
+	rule := "FREQ=WEEKLY"
+	err := PatchTask(context.Background(), 2, TaskPatch{Set: TaskRequestBody{Recurrence: &rule}}, AnyVersion)
+	assert.Nil(t, err)
+
+	first, _ := repository.QueryTask(context.Background(), noUser, 1)
+	second, _ := repository.QueryTask(context.Background(), noUser, 2)
+	assert.NotEqual(t, first.SeriesId, second.SeriesId, "Changed occurrence should start its own series")
+	assert.Equal(t, "FREQ=DAILY", first.Recurrence, "Other occurrences should keep their recurrence")
+	assert.Equal(t, "FREQ=WEEKLY", second.Recurrence)
+
+	moveTask(t, 2, "open", "done")
+	third, _ := repository.QueryTask(context.Background(), noUser, 3)
+	assert.Equal(t, second.DueDate.AddDate(0, 0, 7), third.DueDate, "Next occurrence should follow the new rule")
+}
+
+func TestPatchFutureOccurrences(t *testing.T) {
+	repository := setMockRepository()
+	createRecurringTask(t, "Water the plants", "FREQ=DAILY")
+	moveTask(t, 1, "open", "done")
+	previous, _ := repository.QueryTask(context.Background(), noUser, 1)
+
+	title := "Water the garden"
+	priority := uint(3)
+	err := PatchFutureOccurrences(context.Background(), 1, TaskPatch{Set: TaskRequestBody{Title: &title, Priority: &priority}}, AnyVersion)
+	assert.Nil(t, err)
+
+	first, _ := repository.QueryTask(context.Background(), noUser, 1)
+	second, _ := repository.QueryTask(context.Background(), noUser, 2)
+	assert.Equal(t, title, first.Title)
+	assert.Equal(t, title, second.Title, "Next occurrences should be patched")
+	assert.Equal(t, uint16(3), second.Priority)
+	assert.NotEqual(t, previous.SeriesId, first.SeriesId, "Series should be split from the task on")
+	assert.Equal(t, first.SeriesId, second.SeriesId)
+
+	moveTask(t, 2, "open", "done")
+	third, err := repository.QueryTask(context.Background(), noUser, 3)
+	assert.Nil(t, err, "Split series should keep generating occurrences")
+	assert.Equal(t, title, third.Title, "Occurrences to come should use the patched fields")
+	assert.Equal(t, second.DueDate.AddDate(0, 0, 1), third.DueDate)
+
+	// Stopping the recurrence from the task on
+	err = PatchFutureOccurrences(context.Background(), 2, TaskPatch{Cleared: []string{"recurrence"}}, AnyVersion)
+	assert.Nil(t, err)
+	for taskId, seriesId := range map[uint]uint{1: first.SeriesId, 2: 0, 3: 0} {
+		task, _ := repository.QueryTask(context.Background(), noUser, taskId)
+		assert.Equal(t, seriesId, task.SeriesId, "Wrong series of task %d", taskId)
+	}
+}
+
+func TestPreviewOccurrences(t *testing.T) {
+	setMockRepository()
+	createRecurringTask(t, "Team meeting", "FREQ=WEEKLY;COUNT=3")
+	dueDate := time.Unix(testDueDate, 0)
+
+	occurrences, err := PreviewOccurrences(context.Background(), 1, "", 5)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{dueDate.AddDate(0, 0, 7).Unix(), dueDate.AddDate(0, 0, 14).Unix()}, occurrences, "Series should end after COUNT occurrences")
+
+	occurrences, err = PreviewOccurrences(context.Background(), 1, "FREQ=DAILY", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []int64{dueDate.AddDate(0, 0, 1).Unix(), dueDate.AddDate(0, 0, 2).Unix()}, occurrences, "Rule should be previewed from the due date")
+
+	title := "One-off"
+	CreateNewTask(context.Background(), TaskRequestBody{Title: &title})
+	occurrences, err = PreviewOccurrences(context.Background(), 2, "", 5)
+	assert.Nil(t, err)
+	assert.Empty(t, occurrences, "Task that does not recur has no occurrences")
+
+	_, err = PreviewOccurrences(context.Background(), 2, "FREQ=DAILY", 5)
+	assert.ErrorIs(t, err, ErrInvalidInput, "Task without due date has no occurrences to preview")
+
+	_, err = PreviewOccurrences(context.Background(), 3, "", 5)
+	assert.Equal(t, ErrRowNotFound, err)
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func dates(times []time.Time) []string {
+	formatted := []string{}
+	for _, t := range times {
+		formatted = append(formatted, t.Format("2006-01-02"))
+	}
+	return formatted
+}
+
+func TestRecurrenceOccurrences(t *testing.T) {
+	start := time.Date(2025, 1, 31, 9, 0, 0, 0, time.UTC) // Friday
+	tests := []struct {
+		rule     string
+		expected []string
+	}{
+		{"FREQ=DAILY;INTERVAL=2", []string{"2025-01-31", "2025-02-02", "2025-02-04"}},
+		{"FREQ=WEEKLY", []string{"2025-01-31", "2025-02-07", "2025-02-14"}},
+		{"FREQ=WEEKLY;BYDAY=MO,FR", []string{"2025-01-31", "2025-02-03", "2025-02-07"}},
+		{"FREQ=MONTHLY", []string{"2025-01-31", "2025-03-31", "2025-05-31"}},
+		{"FREQ=MONTHLY;BYMONTHDAY=-1", []string{"2025-01-31", "2025-02-28", "2025-03-31"}},
+		{"FREQ=MONTHLY;BYDAY=1MO", []string{"2025-01-31", "2025-02-03", "2025-03-03"}},
+		{"FREQ=MONTHLY;BYDAY=-1FR", []string{"2025-01-31", "2025-02-28", "2025-03-28"}},
+		{"FREQ=YEARLY;BYMONTH=3;BYDAY=2SU", []string{"2025-01-31", "2025-03-09", "2026-03-08"}},
+		{"FREQ=YEARLY", []string{"2025-01-31", "2026-01-31", "2027-01-31"}},
+		{"FREQ=WEEKLY;COUNT=2", []string{"2025-01-31", "2025-02-07"}},
+		{"FREQ=DAILY;UNTIL=20250201", []string{"2025-01-31", "2025-02-01"}},
+		{"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=29", []string{"2025-01-31", "2028-02-29", "2032-02-29"}},
+		{"FREQ=MONTHLY;BYMONTH=2", []string{"2025-01-31"}}, // no 31st of February, ends after the days searched
+	}
+
+	for _, test := range tests {
+		rule, err := parseRecurrenceRule(test.rule)
+		assert.NoError(t, err, test.rule)
+		occurrences := rule.occurrencesAfter(start, start.Add(-time.Second), 3)
+		assert.Equal(t, test.expected, dates(occurrences), test.rule)
+	}
+}
+
+func TestRecurrenceWeekStart(t *testing.T) {
+	start := time.Date(2025, 2, 4, 9, 0, 0, 0, time.UTC) // Tuesday
+	monday, _ := parseRecurrenceRule("FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,SU")
+	sunday, _ := parseRecurrenceRule("FREQ=WEEKLY;INTERVAL=2;BYDAY=TU,SU;WKST=SU")
+
+	assert.Equal(t, []string{"2025-02-09", "2025-02-18"}, dates(monday.occurrencesAfter(start, start, 2)))
+	assert.Equal(t, []string{"2025-02-16", "2025-02-18"}, dates(sunday.occurrencesAfter(start, start, 2)))
+}
+
+func TestRecurrenceContinuedAt(t *testing.T) {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	rule, _ := parseRecurrenceRule("FREQ=WEEKLY;COUNT=5")
+
+	continued := rule.continuedAt(start, time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC))
+	assert.Equal(t, "FREQ=WEEKLY;COUNT=3", continued.String(), "Occurrences before the split should be discounted")
+}
+
+func TestParseRecurrenceRule(t *testing.T) {
+	rule, err := parseRecurrenceRule("rrule:freq=monthly;bymonthday=1,15;interval=1")
+	assert.NoError(t, err)
+	assert.Equal(t, "FREQ=MONTHLY;BYMONTHDAY=1,15", rule.String(), "Rule should be stored in canonical form")
+
+	invalid := map[string]string{
+		"":                                    "rule must not be empty",
+		"INTERVAL=2":                          "missing required part 'FREQ'",
+		"FREQ=HOURLY":                         "unsupported FREQ 'HOURLY'. Valid values: [DAILY, WEEKLY, MONTHLY, YEARLY]",
+		"FREQ=DAILY;BYSETPOS=1":               "unsupported part 'BYSETPOS'",
+		"FREQ=DAILY;COUNT=2;UNTIL=20250101":   "'COUNT' and 'UNTIL' can not be both defined",
+		"FREQ=WEEKLY;BYDAY=1MO":               "'BYDAY' ordinals are only allowed with FREQ=MONTHLY or FREQ=YEARLY",
+		"FREQ=MONTHLY;BYMONTHDAY=32":          "invalid 'BYMONTHDAY' value '32'",
+		"FREQ=DAILY;INTERVAL=0":               "'INTERVAL' must be a number between 1 and 1000",
+		"FREQ=YEARLY;BYMONTH=2;BYMONTHDAY=30": "no month of 'BYMONTH' has the days of 'BYMONTHDAY'",
+	}
+	for value, message := range invalid {
+		_, err := parseRecurrenceRule(value)
+		assert.EqualError(t, err, message, value)
+	}
+}
@@ -0,0 +1,252 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"time"
+	"to-do-api/models"
+)
+
+// How often the due reminders are fired when REMINDER_CHECK_INTERVAL is not defined
+const defaultReminderCheckInterval = 30 * time.Second
+
+// Failed deliveries of a reminder before giving up, retried after a delay doubling each time
+const maxReminderAttempts = 5
+const reminderRetryDelay = time.Minute
+
+// Time a claimed reminder is skipped by the other claims while it is delivered, well above the
+// notifiers timeouts. It is delivered again after it only when the API was killed during the delivery.
+const reminderDeliveryLease = 5 * time.Minute
+
+// Longest time before the due date of a relative reminder, in minutes (a year)
+const maxReminderBeforeDue = 365 * 24 * 60
+
+type ReminderRequestBody struct {
+	RemindAt  *int64  `json:"remind_at"`  // Unix timestamp, or
+	BeforeDue *uint   `json:"before_due"` // minutes before the due date of the task
+	Channel   *string `json:"channel"`    // 'webhook' or 'email'
+	Recipient *string `json:"recipient"`  // email address, for the email channel
+}
+
+type ReminderInfo struct {
+	Id        uint   `json:"id"`
+	RemindAt  int64  `json:"remind_at"`  // 0 for the reminders relative to the due date
+	BeforeDue uint   `json:"before_due"` // minutes before the due date
+	Channel   string `json:"channel"`
+	Recipient string `json:"recipient"`
+	FireAt    int64  `json:"fire_at"`    // when the reminder is due, 0 while the task has no due date
+	SentAt    int64  `json:"sent_at"`    // 0 until the reminder fired
+	Attempts  uint   `json:"attempts"`   // failed deliveries, the reminder is dropped after 5
+	LastError string `json:"last_error"` // error of the last failed delivery
+}
+
+// GetReminders lists the reminders of the current user on the task
+func GetReminders(ctx context.Context, taskId uint) ([]ReminderInfo, error) {
+	if _, err := taskRepository.QueryTask(ctx, currentUser(ctx), taskId); err != nil {
+		return []ReminderInfo{}, databaseError("Query Task", err)
+	}
+
+	reminders, err := reminderRepository.QueryReminders(ctx, currentUser(ctx), taskId)
+	if err != nil {
+		return []ReminderInfo{}, databaseError("Query Reminders", err)
+	}
+
+	remindersInfo := []ReminderInfo{}
+	for _, reminder := range reminders {
+		remindersInfo = append(remindersInfo, newReminderInfo(reminder))
+	}
+
+	return remindersInfo, nil
+}
+
+// AddReminder reminds the current user about the task on a configured channel. Every user who can
+// see the task can add reminders, relative ones needing the task to have a due date.
+func AddReminder(ctx context.Context, taskId uint, reminder ReminderRequestBody) (uint, error) {
+	task, err := taskRepository.QueryTask(ctx, currentUser(ctx), taskId)
+	if err != nil {
+		return 0, databaseError("Query Task", err)
+	}
+
+	if _, found := notifiers[*reminder.Channel]; !found {
+		return 0, fmt.Errorf("%w: notification channel '%s' is not configured", ErrInvalidInput, *reminder.Channel)
+	}
+
+	newReminder := models.Reminder{TaskId: taskId, UserId: currentUser(ctx), Channel: *reminder.Channel, CreatedAt: time.Now()}
+	if reminder.Recipient != nil {
+		newReminder.Recipient = *reminder.Recipient
+	}
+	if reminder.RemindAt != nil {
+		newReminder.RemindAt = time.Unix(*reminder.RemindAt, 0)
+	} else if task.DueDate.IsZero() {
+		return 0, fmt.Errorf("%w: reminders before the due date need the task to have one", ErrInvalidInput)
+	} else {
+		newReminder.BeforeDue = time.Duration(*reminder.BeforeDue) * time.Minute
+	}
+
+	reminderId, err := reminderRepository.AddReminder(ctx, newReminder)
+	if err != nil {
+		return 0, databaseError("Add Reminder", err)
+	}
+
+	return reminderId, nil
+}
+
+// DeleteReminder removes a reminder of the current user on the task
+func DeleteReminder(ctx context.Context, taskId uint, reminderId uint) error {
+	if _, err := taskRepository.QueryTask(ctx, currentUser(ctx), taskId); err != nil {
+		return databaseError("Query Task", err)
+	}
+
+	if err := reminderRepository.DeleteReminder(ctx, currentUser(ctx), taskId, reminderId); err != nil {
+		return databaseError("Delete Reminder", err)
+	}
+
+	return nil
+}
+
+// FireDueReminders delivers the reminders due at now, returning how many were delivered. Each reminder
+// fires once: it is claimed and leased in its own transaction before the delivery, so the concurrent
+// instances skip it without keeping the transaction open while notifying, and marked sent after.
+// Canceling ctx stops claiming reminders, but the delivery in progress is completed and marked.
+// Reminders of the tasks done or the user can no longer see are dropped without notification.
+func FireDueReminders(ctx context.Context, now time.Time) (uint, error) {
+	var delivered uint
+	for ctx.Err() == nil {
+		reminder, err := claimDueReminder(ctx, now)
+		if errors.Is(err, ErrRowNotFound) {
+			return delivered, nil
+		} else if err != nil {
+			return delivered, err
+		}
+
+		deliveryCtx := context.WithoutCancel(ctx)
+		notified, err := notifyReminder(deliveryCtx, reminder)
+		if err != nil {
+			fmt.Printf("Error delivering reminder %d (attempt %d). e: %v\n", reminder.Id, reminder.Attempts+1, err)
+			retryAt := time.Now().Add(reminderRetryDelay << reminder.Attempts)
+			if err = reminderRepository.MarkReminderFailed(deliveryCtx, reminder.Id, err.Error(), retryAt); err != nil {
+				return delivered, databaseError("Mark Reminder Failed", err)
+			}
+			continue
+		}
+
+		if err = reminderRepository.MarkReminderSent(deliveryCtx, reminder.Id, time.Now()); err != nil {
+			return delivered, databaseError("Mark Reminder Sent", err)
+		}
+		if notified {
+			delivered++
+		}
+	}
+
+	return delivered, nil
+}
+
+// Claims the reminder due first at now and leases it for its delivery, failing with ErrRowNotFound when none is due
+func claimDueReminder(ctx context.Context, now time.Time) (models.Reminder, error) {
+	var reminder models.Reminder
+	err := inTransaction(ctx, "Claim Reminder", func(txCtx context.Context) error {
+		var err error
+		reminder, err = reminderRepository.ClaimDueReminder(txCtx, now, maxReminderAttempts)
+		if err != nil {
+			return databaseError("Claim Reminder", err)
+		}
+		// The lease starts at the claim, the batch may have started long before
+		if err = reminderRepository.LeaseReminder(txCtx, reminder.Id, time.Now().Add(reminderDeliveryLease)); err != nil {
+			return databaseError("Lease Reminder", err)
+		}
+		return nil
+	})
+
+	return reminder, err
+}
+
+// Sends the notification of the reminder, unless its task is done or no longer visible to the user
+func notifyReminder(ctx context.Context, reminder models.Reminder) (bool, error) {
+	task, err := taskRepository.QueryTask(ctx, reminder.UserId, reminder.TaskId)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	} else if err != nil {
+		return false, databaseError("Query Task", err)
+	}
+	if workflow.isDone(task.Status) {
+		return false, nil
+	}
+
+	notifier, found := notifiers[reminder.Channel]
+	if !found {
+		return false, fmt.Errorf("notification channel '%s' is not configured", reminder.Channel)
+	}
+	username, err := usernameCache{}.username(ctx, reminder.UserId)
+	if err != nil {
+		return false, err
+	}
+
+	err = notifier.Notify(ctx, Notification{
+		ReminderId: reminder.Id,
+		TaskId:     task.Id,
+		TaskTitle:  task.Title,
+		DueDate:    task.DueDate,
+		Username:   username,
+		Recipient:  reminder.Recipient,
+		FireAt:     reminder.FireAt,
+	})
+
+	return err == nil, err
+}
+
+// LoadReminderCheckInterval reads how often the due reminders are fired from the
+// REMINDER_CHECK_INTERVAL env variable. 0 disables the reminders scheduler.
+func LoadReminderCheckInterval() time.Duration {
+	value, exist := os.LookupEnv("REMINDER_CHECK_INTERVAL")
+	if !exist || value == "" {
+		return defaultReminderCheckInterval
+	}
+
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		log.Fatalf("Invalid REMINDER_CHECK_INTERVAL value '%s', must be a duration >= 0", value)
+	}
+
+	return interval
+}
+
+// StartReminderScheduler periodically fires the due reminders in the background, unless interval is 0.
+// Reminders that became due while the API was stopped fire on the first check. The returned function
+// stops it, waiting for the delivery in progress to be marked.
+func StartReminderScheduler(interval time.Duration) (stop func()) {
+	if interval == 0 {
+		return func() {}
+	}
+
+	return runPeriodically(interval, func(ctx context.Context) {
+		if _, err := FireDueReminders(ctx, time.Now()); err != nil && ctx.Err() == nil {
+			fmt.Printf("Error firing the reminders. e: %v\n", err)
+		}
+	})
+}
+
+func newReminderInfo(reminder models.Reminder) ReminderInfo {
+	info := ReminderInfo{
+		Id:        reminder.Id,
+		BeforeDue: uint(reminder.BeforeDue / time.Minute),
+		Channel:   reminder.Channel,
+		Recipient: reminder.Recipient,
+		Attempts:  reminder.Attempts,
+		LastError: reminder.LastError,
+	}
+	if !reminder.RemindAt.IsZero() {
+		info.RemindAt = reminder.RemindAt.Unix()
+	}
+	if !reminder.FireAt.IsZero() {
+		info.FireAt = reminder.FireAt.Unix()
+	}
+	if !reminder.SentAt.IsZero() {
+		info.SentAt = reminder.SentAt.Unix()
+	}
+
+	return info
+}
@@ -0,0 +1,250 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Notifier recording the notifications, failing with err when set
+type recordingNotifier struct {
+	notifications []Notification
+	err           error
+}
+
+func (n *recordingNotifier) Notify(ctx context.Context, notification Notification) error {
+	if n.err != nil {
+		return n.err
+	}
+	n.notifications = append(n.notifications, notification)
+	return nil
+}
+
+func setRecordingNotifier(t *testing.T) *recordingNotifier {
+	notifier := &recordingNotifier{}
+	SetNotifiers(map[string]Notifier{ChannelWebhook: notifier})
+	t.Cleanup(func() { SetNotifiers(map[string]Notifier{}) })
+	return notifier
+}
+
+// Creates a task due on testDueDate
+func createDueTask(t *testing.T, title string) uint {
+	dueDate := testDueDate
+	taskId, err := CreateNewTask(context.Background(), TaskRequestBody{Title: &title, DueDate: &dueDate})
+	assert.Nil(t, err)
+	return taskId
+}
+
+func TestReminderFiresOnce(t *testing.T) {
+	setMockRepository()
+	notifier := setRecordingNotifier(t)
+	taskId := createDueTask(t, "Renew the certificate")
+
+	remindAt := time.Now().Add(time.Hour).Unix()
+	channel := ChannelWebhook
+	reminderId, err := AddReminder(context.Background(), taskId, ReminderRequestBody{RemindAt: &remindAt, Channel: &channel})
+	assert.Nil(t, err)
+
+	delivered, err := FireDueReminders(context.Background(), time.Unix(remindAt, 0).Add(-time.Second))
+	assert.Nil(t, err)
+	assert.Equal(t, uint(0), delivered, "Reminder should not fire before its time")
+
+	delivered, err = FireDueReminders(context.Background(), time.Unix(remindAt, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), delivered)
+	assert.Equal(t, []Notification{{
+		ReminderId: reminderId, TaskId: taskId, TaskTitle: "Renew the certificate",
+		DueDate: time.Unix(testDueDate, 0), FireAt: time.Unix(remindAt, 0),
+	}}, notifier.notifications)
+
+	// Later checks, as after a restart, find it sent
+	delivered, _ = FireDueReminders(context.Background(), time.Unix(remindAt, 0).Add(time.Hour))
+	assert.Equal(t, uint(0), delivered, "Reminder should fire once")
+	reminders, _ := GetReminders(context.Background(), taskId)
+	assert.NotZero(t, reminders[0].SentAt)
+}
+
+func TestReminderBeforeDueFollowsDueDate(t *testing.T) {
+	setMockRepository()
+	notifier := setRecordingNotifier(t)
+	taskId := createDueTask(t, "Renew the certificate")
+
+	beforeDue := uint(90)
+	channel := ChannelWebhook
+	_, err := AddReminder(context.Background(), taskId, ReminderRequestBody{BeforeDue: &beforeDue, Channel: &channel})
+	assert.Nil(t, err)
+
+	reminders, _ := GetReminders(context.Background(), taskId)
+	assert.Equal(t, testDueDate-90*60, reminders[0].FireAt)
+
+	laterDueDate := testDueDate + 24*3600
+	err = PatchTask(context.Background(), taskId, TaskPatch{Set: TaskRequestBody{DueDate: &laterDueDate}}, AnyVersion)
+	assert.Nil(t, err)
+	reminders, _ = GetReminders(context.Background(), taskId)
+	assert.Equal(t, laterDueDate-90*60, reminders[0].FireAt, "Reminder should follow the due date")
+
+	delivered, _ := FireDueReminders(context.Background(), time.Unix(testDueDate, 0))
+	assert.Equal(t, uint(0), delivered, "Reminder should not fire before the new due date")
+	delivered, _ = FireDueReminders(context.Background(), time.Unix(laterDueDate-90*60, 0))
+	assert.Equal(t, uint(1), delivered)
+	assert.Len(t, notifier.notifications, 1)
+}
+
+func TestReminderRetriedWhenDeliveryFails(t *testing.T) {
+	setMockRepository()
+	notifier := setRecordingNotifier(t)
+	notifier.err = errors.New("connection refused")
+	taskId := createDueTask(t, "Renew the certificate")
+
+	beforeDue := uint(0)
+	channel := ChannelWebhook
+	AddReminder(context.Background(), taskId, ReminderRequestBody{BeforeDue: &beforeDue, Channel: &channel})
+
+	// Retry delays start at the failure, not at the time of the check
+	now := time.Now()
+	delivered, err := FireDueReminders(context.Background(), now)
+	assert.Nil(t, err, "Failed delivery should not fail the check")
+	assert.Equal(t, uint(0), delivered)
+	reminders, _ := GetReminders(context.Background(), taskId)
+	assert.Equal(t, uint(1), reminders[0].Attempts)
+	assert.Equal(t, "connection refused", reminders[0].LastError)
+
+	// Retried once the delay passed, doubling after each failure
+	FireDueReminders(context.Background(), now.Add(reminderRetryDelay-time.Second))
+	reminders, _ = GetReminders(context.Background(), taskId)
+	assert.Equal(t, uint(1), reminders[0].Attempts, "Reminder should not be retried before the delay")
+	FireDueReminders(context.Background(), now.Add(reminderRetryDelay+time.Second))
+	reminders, _ = GetReminders(context.Background(), taskId)
+	assert.Equal(t, uint(2), reminders[0].Attempts)
+
+	notifier.err = nil
+	delivered, _ = FireDueReminders(context.Background(), now.Add(3*reminderRetryDelay+time.Second))
+	assert.Equal(t, uint(1), delivered, "Reminder should be delivered once the channel works again")
+}
+
+func TestReminderLeasedWhileDelivered(t *testing.T) {
+	setMockRepository()
+	notifier := setRecordingNotifier(t)
+	taskId := createDueTask(t, "Renew the certificate")
+
+	beforeDue := uint(0)
+	channel := ChannelWebhook
+	AddReminder(context.Background(), taskId, ReminderRequestBody{BeforeDue: &beforeDue, Channel: &channel})
+
+	// Claimed by an instance that stops before marking the delivery
+	now := time.Unix(testDueDate, 0)
+	_, err := claimDueReminder(context.Background(), now)
+	assert.Nil(t, err)
+
+	delivered, _ := FireDueReminders(context.Background(), time.Now())
+	assert.Equal(t, uint(0), delivered, "Leased reminder should be skipped by the other claims")
+	delivered, _ = FireDueReminders(context.Background(), time.Now().Add(reminderDeliveryLease))
+	assert.Equal(t, uint(1), delivered, "Reminder should be delivered again once its lease expires")
+	assert.Len(t, notifier.notifications, 1)
+}
+
+// Notifier canceling the check during the delivery, as the scheduler stopping on shutdown
+type cancelingNotifier struct {
+	recordingNotifier
+	cancel context.CancelFunc
+}
+
+func (n *cancelingNotifier) Notify(ctx context.Context, notification Notification) error {
+	n.cancel()
+	return n.recordingNotifier.Notify(ctx, notification)
+}
+
+func TestReminderDeliveryCompletedWhenStopped(t *testing.T) {
+	setMockRepository()
+	ctx, cancel := context.WithCancel(context.Background())
+	notifier := &cancelingNotifier{cancel: cancel}
+	SetNotifiers(map[string]Notifier{ChannelWebhook: notifier})
+	t.Cleanup(func() { SetNotifiers(map[string]Notifier{}) })
+	firstTaskId := createDueTask(t, "Renew the certificate")
+	secondTaskId := createDueTask(t, "Rotate the keys")
+
+	beforeDue := uint(0)
+	channel := ChannelWebhook
+	AddReminder(context.Background(), firstTaskId, ReminderRequestBody{BeforeDue: &beforeDue, Channel: &channel})
+	AddReminder(context.Background(), secondTaskId, ReminderRequestBody{BeforeDue: &beforeDue, Channel: &channel})
+
+	delivered, err := FireDueReminders(ctx, time.Unix(testDueDate, 0))
+
+	assert.Nil(t, err)
+	assert.Equal(t, uint(1), delivered, "Stopping should not claim the next reminders")
+	reminders, _ := GetReminders(context.Background(), firstTaskId)
+	assert.NotZero(t, reminders[0].SentAt, "Delivery in progress should be marked")
+	reminders, _ = GetReminders(context.Background(), secondTaskId)
+	assert.Zero(t, reminders[0].SentAt)
+}
+
+func TestReminderDroppedAfterMaxAttempts(t *testing.T) {
+	setMockRepository()
+	notifier := setRecordingNotifier(t)
+	notifier.err = errors.New("connection refused")
+	taskId := createDueTask(t, "Renew the certificate")
+
+	beforeDue := uint(0)
+	channel := ChannelWebhook
+	AddReminder(context.Background(), taskId, ReminderRequestBody{BeforeDue: &beforeDue, Channel: &channel})
+
+	now := time.Now()
+	for range maxReminderAttempts + 1 {
+		FireDueReminders(context.Background(), now)
+		now = now.Add(reminderRetryDelay << maxReminderAttempts)
+	}
+
+	reminders, _ := GetReminders(context.Background(), taskId)
+	assert.Equal(t, uint(maxReminderAttempts), reminders[0].Attempts, "Reminder should be dropped after the last attempt")
+	assert.Zero(t, reminders[0].SentAt)
+}
+
+func TestReminderOfDoneOrDeletedTask(t *testing.T) {
+	setMockRepository()
+	notifier := setRecordingNotifier(t)
+	doneTaskId := createDueTask(t, "Renew the certificate")
+	deletedTaskId := createDueTask(t, "Rotate the keys")
+
+	beforeDue := uint(0)
+	channel := ChannelWebhook
+	AddReminder(context.Background(), doneTaskId, ReminderRequestBody{BeforeDue: &beforeDue, Channel: &channel})
+	AddReminder(context.Background(), deletedTaskId, ReminderRequestBody{BeforeDue: &beforeDue, Channel: &channel})
+	moveTask(t, doneTaskId, "open", "done")
+	assert.Nil(t, DeleteTask(context.Background(), deletedTaskId, AnyVersion))
+
+	delivered, err := FireDueReminders(context.Background(), time.Unix(testDueDate, 0))
+	assert.Nil(t, err)
+	assert.Equal(t, uint(0), delivered, "Reminders of done and deleted tasks should not be delivered")
+	reminders, _ := GetReminders(context.Background(), doneTaskId)
+	assert.NotZero(t, reminders[0].SentAt, "Reminder of a done task should be dropped")
+
+	// The reminder of the deleted task waits for it to be restored
+	assert.Nil(t, RestoreTask(context.Background(), deletedTaskId))
+	delivered, _ = FireDueReminders(context.Background(), time.Unix(testDueDate, 0))
+	assert.Equal(t, uint(1), delivered)
+	assert.Equal(t, deletedTaskId, notifier.notifications[0].TaskId)
+}
+
+func TestAddReminderInvalid(t *testing.T) {
+	setMockRepository()
+	setRecordingNotifier(t)
+	title := "No due date"
+	taskId, _ := CreateNewTask(context.Background(), TaskRequestBody{Title: &title})
+
+	beforeDue := uint(30)
+	channel := ChannelWebhook
+	_, err := AddReminder(context.Background(), taskId, ReminderRequestBody{BeforeDue: &beforeDue, Channel: &channel})
+	assert.ErrorIs(t, err, ErrInvalidInput, "Reminder before the due date needs a due date")
+
+	email := ChannelEmail
+	recipient := "ada@example.com"
+	remindAt := time.Now().Add(time.Hour).Unix()
+	_, err = AddReminder(context.Background(), taskId, ReminderRequestBody{RemindAt: &remindAt, Channel: &email, Recipient: &recipient})
+	assert.ErrorIs(t, err, ErrInvalidInput, "Channel not configured should be rejected")
+
+	_, err = AddReminder(context.Background(), taskId+1, ReminderRequestBody{RemindAt: &remindAt, Channel: &channel})
+	assert.Equal(t, ErrRowNotFound, err)
+}
@@ -0,0 +1,95 @@
+package service
+
+import (
+	"context"
+	"to-do-api/models"
+)
+
+// Storage backends used by the services, injected at startup
+var defaultRepository = models.NewMemoryTaskRepository()
+var taskRepository models.TaskRepository = defaultRepository
+var dependencyRepository models.DependencyRepository = defaultRepository
+var projectRepository models.ProjectRepository = defaultRepository
+var taskEventRepository models.TaskEventRepository = defaultRepository
+var trashRepository models.TrashRepository = defaultRepository
+var checklistRepository models.ChecklistRepository = defaultRepository
+var tagRepository models.TagRepository = defaultRepository
+var commentRepository models.CommentRepository = defaultRepository
+var attachmentRepository models.AttachmentRepository = defaultRepository
+var seriesRepository models.SeriesRepository = defaultRepository
+var reminderRepository models.ReminderRepository = defaultRepository
+var transactor models.Transactor = defaultRepository
+var userRepository models.UserRepository = models.NewMemoryUserRepository()
+var blobStore models.BlobStore = models.NewMemoryBlobStore()
+
+func SetTaskRepository(repository models.TaskRepository) {
+	taskRepository = repository
+}
+
+func SetDependencyRepository(repository models.DependencyRepository) {
+	dependencyRepository = repository
+}
+
+func SetProjectRepository(repository models.ProjectRepository) {
+	projectRepository = repository
+}
+
+func SetTaskEventRepository(repository models.TaskEventRepository) {
+	taskEventRepository = repository
+}
+
+func SetTrashRepository(repository models.TrashRepository) {
+	trashRepository = repository
+}
+
+func SetChecklistRepository(repository models.ChecklistRepository) {
+	checklistRepository = repository
+}
+
+func SetTagRepository(repository models.TagRepository) {
+	tagRepository = repository
+}
+
+func SetCommentRepository(repository models.CommentRepository) {
+	commentRepository = repository
+}
+
+func SetAttachmentRepository(repository models.AttachmentRepository) {
+	attachmentRepository = repository
+}
+
+func SetSeriesRepository(repository models.SeriesRepository) {
+	seriesRepository = repository
+}
+
+func SetReminderRepository(repository models.ReminderRepository) {
+	reminderRepository = repository
+}
+
+func SetBlobStore(store models.BlobStore) {
+	blobStore = store
+}
+
+func SetTransactor(repository models.Transactor) {
+	transactor = repository
+}
+
+// Runs change in a transaction. The errors of change are returned as they are, being already service errors.
+func inTransaction(ctx context.Context, operation string, change func(ctx context.Context) error) error {
+	var changeErr error
+	err := transactor.RunInTransaction(ctx, func(txCtx context.Context) error {
+		changeErr = change(txCtx)
+		return changeErr
+	})
+	if changeErr != nil {
+		return changeErr
+	} else if err != nil {
+		return databaseError(operation, err)
+	}
+
+	return nil
+}
+
+func SetUserRepository(repository models.UserRepository) {
+	userRepository = repository
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"time"
+	"to-do-api/models"
+)
+
+type ScheduledTask struct {
+	TaskInfo
+	EarliestStart     int64 `json:"earliest_start"`
+	EarliestFinish    int64 `json:"earliest_finish"`
+	LatestStart       int64 `json:"latest_start"`
+	LatestFinish      int64 `json:"latest_finish"`
+	SlackHours        uint  `json:"slack_hours"`
+	Critical          bool  `json:"critical"`
+	InfeasibleDueDate bool  `json:"infeasible_due_date"` // can not be finished before its due date
+}
+
+type Schedule struct {
+	Start           int64           `json:"start"`
+	Finish          int64           `json:"finish"`
+	CriticalPath    []uint          `json:"critical_path"`
+	InfeasibleTasks []uint          `json:"infeasible_tasks"`
+	Tasks           []ScheduledTask `json:"tasks"`
+}
+
+// GetSchedule computes when each task matching the filters can be worked on,
+// starting at the given time and following the dependencies (critical path method).
+// Done tasks take no more time; dependencies on tasks outside the filtered list are ignored.
+func GetSchedule(ctx context.Context, filterConfig []models.TasksFilterQuery, start time.Time) (Schedule, error) {
+	tasks, dependencies, err := queryTasksWithDependencies(ctx, filterConfig)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	orderedTasks, err := topologicalOrder(tasks, dependencies)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	listed := map[uint]bool{}
+	duration := map[uint]uint{}
+	for _, task := range orderedTasks {
+		listed[task.Id] = true
+		if !workflow.isDone(task.Status) {
+			duration[task.Id] = task.EstimateHours
+		}
+	}
+
+	dependents := map[uint][]uint{}
+	for taskId, dependsOn := range dependencies {
+		for _, dependsOnId := range dependsOn {
+			if listed[dependsOnId] {
+				dependents[dependsOnId] = append(dependents[dependsOnId], taskId)
+			}
+		}
+	}
+
+	// Forward pass: a task starts once all its dependencies are finished
+	earliestStart := map[uint]uint{}
+	projectHours := uint(0)
+	for _, task := range orderedTasks {
+		for _, dependsOnId := range dependencies[task.Id] {
+			if listed[dependsOnId] {
+				earliestStart[task.Id] = max(earliestStart[task.Id], earliestStart[dependsOnId]+duration[dependsOnId])
+			}
+		}
+		projectHours = max(projectHours, earliestStart[task.Id]+duration[task.Id])
+	}
+
+	// Backward pass: a task must finish before any of its dependents has to start
+	latestFinish := map[uint]uint{}
+	for idx := len(orderedTasks) - 1; idx >= 0; idx-- {
+		taskId := orderedTasks[idx].Id
+		latestFinish[taskId] = projectHours
+		for _, dependentId := range dependents[taskId] {
+			latestFinish[taskId] = min(latestFinish[taskId], latestFinish[dependentId]-duration[dependentId])
+		}
+	}
+
+	schedule := Schedule{
+		Start:           start.Unix(),
+		Finish:          hoursAfter(start, projectHours),
+		CriticalPath:    []uint{},
+		InfeasibleTasks: []uint{},
+		Tasks:           []ScheduledTask{},
+	}
+	for _, task := range orderedTasks {
+		earliestFinish := earliestStart[task.Id] + duration[task.Id]
+		latestStart := latestFinish[task.Id] - duration[task.Id]
+
+		scheduledTask := ScheduledTask{
+			TaskInfo:       newTaskInfo(task),
+			EarliestStart:  hoursAfter(start, earliestStart[task.Id]),
+			EarliestFinish: hoursAfter(start, earliestFinish),
+			LatestStart:    hoursAfter(start, latestStart),
+			LatestFinish:   hoursAfter(start, latestFinish[task.Id]),
+			SlackHours:     latestStart - earliestStart[task.Id],
+		}
+		scheduledTask.Critical = scheduledTask.SlackHours == 0 && duration[task.Id] > 0
+		scheduledTask.InfeasibleDueDate = !task.DueDate.IsZero() && scheduledTask.EarliestFinish > task.DueDate.Unix()
+
+		if scheduledTask.Critical {
+			schedule.CriticalPath = append(schedule.CriticalPath, task.Id)
+		}
+		if scheduledTask.InfeasibleDueDate {
+			schedule.InfeasibleTasks = append(schedule.InfeasibleTasks, task.Id)
+		}
+		schedule.Tasks = append(schedule.Tasks, scheduledTask)
+	}
+
+	return schedule, nil
+}
+
+func hoursAfter(start time.Time, hours uint) int64 {
+	return start.Add(time.Duration(hours) * time.Hour).Unix()
+}
@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+	"to-do-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testScheduleStart = time.Date(2025, 2, 3, 8, 0, 0, 0, time.UTC)
+
+// Task 2 depends on 1, task 4 depends on 2 and 3
+func setScheduleRepository() *mockTaskRepository {
+	repository := setMockRepository()
+	for _, estimate := range []uint{4, 2, 3, 1} {
+		repository.AddTask(context.Background(), models.Task{Title: "task", Status: "pending", EstimateHours: estimate})
+	}
+	repository.AddDependency(context.Background(), 2, 1)
+	repository.AddDependency(context.Background(), 4, 2)
+	repository.AddDependency(context.Background(), 4, 3)
+	return repository
+}
+
+func TestGetSchedule(t *testing.T) {
+	repository := setScheduleRepository()
+	task, _ := repository.QueryTask(context.Background(), noUser, 4)
+	task.DueDate = testScheduleStart.Add(5 * time.Hour)
+	repository.UpdateTask(context.Background(), noUser, task)
+
+	// Run function
+	schedule, err := GetSchedule(context.Background(), []models.TasksFilterQuery{}, testScheduleStart)
+
+	// Assertions
+	assert.Nil(t, err)
+	assert.Equal(t, testScheduleStart.Add(7*time.Hour).Unix(), schedule.Finish)
+	assert.Equal(t, []uint{1, 2, 4}, schedule.CriticalPath)
+	assert.Equal(t, []uint{4}, schedule.InfeasibleTasks)
+
+	assert.Len(t, schedule.Tasks, 4)
+	taskC := schedule.Tasks[2]
+	assert.Equal(t, uint(3), taskC.Id)
+	assert.Equal(t, testScheduleStart.Unix(), taskC.EarliestStart)
+	assert.Equal(t, testScheduleStart.Add(3*time.Hour).Unix(), taskC.LatestStart)
+	assert.Equal(t, uint(3), taskC.SlackHours)
+	assert.False(t, taskC.Critical)
+
+	taskD := schedule.Tasks[3]
+	assert.Equal(t, testScheduleStart.Add(6*time.Hour).Unix(), taskD.EarliestStart)
+	assert.True(t, taskD.InfeasibleDueDate)
+}
+
+func TestGetScheduleDoneTask(t *testing.T) {
+	repository := setScheduleRepository()
+	task, _ := repository.QueryTask(context.Background(), noUser, 1)
+	task.Status = "done"
+	repository.UpdateTask(context.Background(), noUser, task)
+
+	// Run function
+	schedule, err := GetSchedule(context.Background(), []models.TasksFilterQuery{}, testScheduleStart)
+
+	// Done tasks take no more time, so task 3 becomes the critical one
+	assert.Nil(t, err)
+	assert.Equal(t, testScheduleStart.Add(4*time.Hour).Unix(), schedule.Finish)
+	assert.Equal(t, []uint{3, 4}, schedule.CriticalPath)
+	assert.Empty(t, schedule.InfeasibleTasks)
+}
@@ -1,141 +1,360 @@
 package service
 
 import (
-	"database/sql"
+	"context"
 	"errors"
 	"fmt"
 	"time"
 	"to-do-api/models"
 )
 
-var ErrDatabaseGeneral = errors.New("fail processing request on database")
-var ErrRowNotFound = errors.New("requested resource not found on database")
-var ErrInvalidInput = errors.New("invalid input")
+// Expected version of a task when the change has no precondition on it
+const AnyVersion = uint(0)
+
+// Attempts of a change without expected version, repeated when the task changes concurrently
+const conflictAttempts = 3
 
 type TaskRequestBody struct {
-	Title       *string `json:"title"`
-	Priority    *uint   `json:"priority"`
-	Description *string `json:"description"`
-	Status      *string `json:"status"`
-	DueDate     *int64  `json:"due_date"`
+	Title         *string `json:"title"`
+	Priority      *uint   `json:"priority"`
+	Description   *string `json:"description"`
+	Status        *string `json:"status"`
+	DueDate       *int64  `json:"due_date"`
+	EstimateHours *uint   `json:"estimate_hours"`
+	ProjectId     *uint   `json:"project_id"` // 0 removes the task from its project
+	ParentId      *uint   `json:"parent_id"`  // 0 makes the task a top level task
+	Recurrence    *string `json:"recurrence"` // RFC 5545 RRULE, empty to stop the recurrence
 }
 
-type TaskResponseBody struct {
-	Id          uint
-	Title       string
-	Description string
-	Status      string
-	Priority    uint16
-	CreatedAt   int64
-	DueDate     int64
+// TaskPatch is a JSON Merge Patch (RFC 7386) of a task
+type TaskPatch struct {
+	Set     TaskRequestBody // fields set to a value
+	Cleared []string        // fields set to null, reset to their default value
 }
 
-func CreateNewTask(task TaskRequestBody) (uint, error) {
-	dueDate := time.Unix(*task.DueDate, 0)
+type TaskResponseBody struct {
+	Id            uint
+	Title         string
+	Description   string
+	Status        string
+	Priority      uint16
+	CreatedAt     int64
+	DueDate       int64
+	EstimateHours uint
+	ProjectId     uint
+	Dependencies  []uint
+	Version       uint
+	ParentId      uint
+	Subtasks      []uint // direct subtasks
+	Completion    *uint  // percentage of the subtasks done at any depth, nil without subtasks
+
+	ChecklistTotal   uint
+	ChecklistChecked uint
+
+	Tags         []string
+	CommentCount uint
 
+	SeriesId   uint
+	Recurrence string
+}
+
+func CreateNewTask(ctx context.Context, task TaskRequestBody) (uint, error) {
 	newTask := models.Task{
-		Title:       *task.Title,
-		Description: *task.Description,
-		Status:      *task.Status,
-		Priority:    uint16(*task.Priority),
-		CreatedAt:   time.Now(),
-		DueDate:     dueDate,
+		Title:     *task.Title,
+		Status:    workflow.initialStatus(),
+		CreatedAt: time.Now(),
+		OwnerId:   currentUser(ctx),
+	}
+
+	// Optional fields
+	applyTaskFields(&newTask, task)
+	if err := checkProjectAssignable(ctx, newTask.ProjectId); err != nil {
+		return 0, err
+	}
+	if err := checkParentAssignable(ctx, 0, newTask.ParentId); err != nil {
+		return 0, err
+	}
+	if err := checkRecurrence(newTask); err != nil {
+		return 0, err
 	}
 
-	newTaskId, err := models.AddTask(newTask)
+	var newTaskId uint
+	err := inTransaction(ctx, "Create Task", func(txCtx context.Context) error {
+		var err error
+		if newTask.Recurrence != "" {
+			if newTask.SeriesId, err = startSeries(txCtx, newTask); err != nil {
+				return err
+			}
+		}
 
+		if newTaskId, err = taskRepository.AddTask(txCtx, newTask); err != nil {
+			return databaseError("Create Task", err)
+		}
+		if newTask.SeriesId != 0 {
+			if _, err = seriesRepository.AdvanceSeries(txCtx, newTask.SeriesId, 0, newTaskId, newTask.DueDate); err != nil {
+				return databaseError("Advance Series", err)
+			}
+		}
+		return recordTaskEvent(txCtx, models.ActionCreate, newTaskId, nil, &newTask)
+	})
 	if err != nil {
-		fmt.Printf("Create Task failed: %v\n", err)
-		return 0, ErrDatabaseGeneral
+		return 0, err
 	}
 
 	return newTaskId, nil
 }
 
-func GetTaskById(taskId uint) (TaskResponseBody, error) {
+func GetTaskById(ctx context.Context, taskId uint) (TaskResponseBody, error) {
+	task, err := taskRepository.QueryTask(ctx, currentUser(ctx), taskId)
 
-	idExist, err := checkIdExist(taskId)
-	if !idExist {
-		return TaskResponseBody{}, ErrRowNotFound
-	} else if err != nil {
-		return TaskResponseBody{}, ErrDatabaseGeneral
+	if err != nil {
+		return TaskResponseBody{}, databaseError("Query Task", err)
 	}
 
-	task, err := models.QueryTask(taskId)
+	dependencies, err := dependencyRepository.QueryDependencies(ctx, taskId)
+	if err != nil {
+		return TaskResponseBody{}, databaseError("Query Dependencies", err)
+	}
 
+	descendants, err := taskRepository.QueryDescendants(ctx, currentUser(ctx), taskId)
 	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return TaskResponseBody{}, ErrRowNotFound
-		} else {
-			fmt.Printf("Query Task failed: %v\n", err)
-			return TaskResponseBody{}, ErrDatabaseGeneral
-		}
+		return TaskResponseBody{}, databaseError("Query Subtasks", err)
 	}
+	subtasks, completion := subtasksProgress(taskId, descendants)
 
 	return TaskResponseBody{
-		Id:          task.Id,
-		Title:       task.Title,
-		Description: task.Description,
-		Status:      task.Status,
-		Priority:    task.Priority,
-		CreatedAt:   task.CreatedAt.Unix(),
-		DueDate:     task.DueDate.Unix(),
+		Id:            task.Id,
+		Title:         task.Title,
+		Description:   task.Description,
+		Status:        task.Status,
+		Priority:      task.Priority,
+		CreatedAt:     task.CreatedAt.Unix(),
+		DueDate:       task.DueDate.Unix(),
+		EstimateHours: task.EstimateHours,
+		ProjectId:     task.ProjectId,
+		Dependencies:  dependencies,
+		Version:       task.Version,
+		ParentId:      task.ParentId,
+		Subtasks:      subtasks,
+		Completion:    completion,
+
+		ChecklistTotal:   task.ChecklistTotal,
+		ChecklistChecked: task.ChecklistChecked,
+
+		Tags:         task.Tags,
+		CommentCount: task.CommentCount,
+
+		SeriesId:   task.SeriesId,
+		Recurrence: task.Recurrence,
 	}, nil
 
 }
 
-func UpdateTask(taskId uint, task TaskRequestBody) error {
-	currentTask, err := models.QueryTask(taskId)
+// TaskETag is the entity tag of the task version, as sent on the ETag header
+func TaskETag(version uint) string {
+	return fmt.Sprintf(`"%d"`, version)
+}
 
-	if err != nil {
-		if errors.Is(err, sql.ErrNoRows) {
-			return ErrRowNotFound
-		} else {
-			fmt.Printf("Query Task failed: %v\n", err)
-			return ErrDatabaseGeneral
+// UpdateTask replaces the task, the fields missing from the request taking their default value,
+// except the recurrence which belongs to the series of the task.
+// Only applies when the task still has expectedVersion (AnyVersion to skip the check).
+func UpdateTask(ctx context.Context, taskId uint, task TaskRequestBody, expectedVersion uint) error {
+	return changeTask(ctx, taskId, expectedVersion, func(currentTask models.Task) models.Task {
+		replacedTask := models.Task{
+			Id:         currentTask.Id,
+			CreatedAt:  currentTask.CreatedAt,
+			OwnerId:    currentTask.OwnerId,
+			Version:    currentTask.Version,
+			SeriesId:   currentTask.SeriesId,
+			Recurrence: currentTask.Recurrence,
 		}
+		applyTaskFields(&replacedTask, task)
+		return replacedTask
+	})
+}
+
+// PatchTask applies the merge patch to the task when it still has expectedVersion (AnyVersion to skip the check)
+func PatchTask(ctx context.Context, taskId uint, patch TaskPatch, expectedVersion uint) error {
+	return changeTask(ctx, taskId, expectedVersion, patch.apply)
+}
+
+// Task with the fields of the patch set or cleared
+func (patch TaskPatch) apply(task models.Task) models.Task {
+	for _, field := range patch.Cleared {
+		clearTaskField(&task, field)
 	}
+	applyTaskFields(&task, patch.Set)
+	return task
+}
+
+// Read-modify-write of the task, retried when the task changes concurrently without expected version
+func changeTask(ctx context.Context, taskId uint, expectedVersion uint, change func(models.Task) models.Task) error {
+	return retryOnConflict(expectedVersion, func() error {
+		return inTransaction(ctx, "Update Task", func(txCtx context.Context) error {
+			previousTask, err := queryTaskWithVersion(txCtx, taskId, expectedVersion)
+			if err != nil {
+				return err
+			}
 
-	if task.Title != nil {
-		currentTask.Title = *task.Title
+			return storeTaskChange(txCtx, previousTask, change(previousTask))
+		})
+	})
+}
+
+// Stores the changed task. A task whose recurrence changed starts its own series, and the latest
+// occurrence of a series generates the next one when it is done.
+func storeTaskChange(ctx context.Context, previousTask models.Task, currentTask models.Task) error {
+	if err := checkTaskChange(ctx, previousTask, currentTask); err != nil {
+		return err
 	}
-	if task.Description != nil {
-		currentTask.Description = *task.Description
+
+	var err error
+	if currentTask.Recurrence != previousTask.Recurrence && currentTask.SeriesId == previousTask.SeriesId {
+		currentTask.SeriesId = 0
+		if currentTask.Recurrence != "" {
+			if currentTask.SeriesId, err = startSeries(ctx, currentTask); err != nil {
+				return err
+			}
+		}
 	}
-	if task.Priority != nil {
-		currentTask.Priority = uint16(*task.Priority)
+
+	if err = taskRepository.UpdateTask(ctx, currentUser(ctx), currentTask); err != nil {
+		return databaseError("Update Task", err)
 	}
-	if task.Status != nil {
-		currentTask.Status = *task.Status
+	currentTask.Version++
+	if err = recordTaskEvent(ctx, models.ActionUpdate, currentTask.Id, &previousTask, &currentTask); err != nil {
+		return err
 	}
-	if task.DueDate != nil {
-		// Convert Unix timestamp (seconds) to time.Time
-		currentTask.DueDate = time.Unix(*task.DueDate, 0)
+
+	if currentTask.SeriesId != 0 && workflow.isDone(currentTask.Status) && !workflow.isDone(previousTask.Status) {
+		return generateNextOccurrence(ctx, currentTask)
 	}
+	return nil
+}
 
-	err = models.UpdateTask(currentTask)
-	if err != nil {
-		fmt.Printf("Update Task failed: %v\n", err)
-		return ErrDatabaseGeneral
+// Checks the workflow allows the new status, the new project and parent accept the task,
+// and the task has a due date to recur from
+func checkTaskChange(ctx context.Context, previousTask models.Task, currentTask models.Task) error {
+	if err := workflow.checkTransition(previousTask.Status, currentTask.Status); err != nil {
+		return err
+	}
+	if currentTask.ProjectId != previousTask.ProjectId {
+		if err := checkProjectAssignable(ctx, currentTask.ProjectId); err != nil {
+			return err
+		}
+	}
+	if currentTask.ParentId != previousTask.ParentId {
+		if err := checkParentAssignable(ctx, currentTask.Id, currentTask.ParentId); err != nil {
+			return err
+		}
 	}
 
-	return nil
+	return checkRecurrence(currentTask)
 }
 
-func DeleteTask(taskId uint) error {
+// Sets the fields present on the request
+func applyTaskFields(task *models.Task, fields TaskRequestBody) {
+	if fields.Title != nil {
+		task.Title = *fields.Title
+	}
+	if fields.Description != nil {
+		task.Description = *fields.Description
+	}
+	if fields.Priority != nil {
+		task.Priority = uint16(*fields.Priority)
+	}
+	if fields.Status != nil {
+		task.Status = *fields.Status
+	}
+	if fields.DueDate != nil {
+		// Convert Unix timestamp (seconds) to time.Time
+		task.DueDate = time.Unix(*fields.DueDate, 0)
+	}
+	if fields.EstimateHours != nil {
+		task.EstimateHours = *fields.EstimateHours
+	}
+	if fields.ProjectId != nil {
+		task.ProjectId = *fields.ProjectId
+	}
+	if fields.ParentId != nil {
+		task.ParentId = *fields.ParentId
+	}
+	if fields.Recurrence != nil {
+		task.Recurrence = normalizeRecurrence(*fields.Recurrence)
+	}
+}
 
-	idExist, err := checkIdExist(taskId)
-	if !idExist {
-		return ErrRowNotFound
-	} else if err != nil {
-		return ErrDatabaseGeneral
+// Resets one of the clearableTaskFields to its default value
+func clearTaskField(task *models.Task, field string) {
+	switch field {
+	case "description":
+		task.Description = ""
+	case "priority":
+		task.Priority = 0
+	case "due_date":
+		task.DueDate = time.Time{}
+	case "estimate_hours":
+		task.EstimateHours = 0
+	case "project_id":
+		task.ProjectId = 0
+	case "parent_id":
+		task.ParentId = 0
+	case "recurrence":
+		task.Recurrence = ""
 	}
+}
+
+// DeleteTask moves the task and its subtasks to the trash when the task still has expectedVersion
+// (AnyVersion to skip the check)
+func DeleteTask(ctx context.Context, taskId uint, expectedVersion uint) error {
+	return retryOnConflict(expectedVersion, func() error {
+		return inTransaction(ctx, "Delete Task", func(txCtx context.Context) error {
+			task, err := queryTaskWithVersion(txCtx, taskId, expectedVersion)
+			if err != nil {
+				return err
+			}
+
+			if err = trashTask(txCtx, task); err != nil {
+				return err
+			}
+			return trashSubtasks(txCtx, taskId)
+		})
+	})
+}
 
-	err = models.DeleteTask(taskId)
+func trashTask(ctx context.Context, task models.Task) error {
+	err := taskRepository.DeleteTask(ctx, currentUser(ctx), task.Id, task.Version)
 	if err != nil {
-		fmt.Printf("Delete Task failed: %v\n", err)
-		return ErrDatabaseGeneral
+		return databaseError("Delete Task", err)
 	}
 
-	return nil
+	return recordTaskEvent(ctx, models.ActionDelete, task.Id, &task, nil)
+}
+
+// Queries the task the user can edit, failing with ErrVersionMismatch when it does not have expectedVersion
+func queryTaskWithVersion(ctx context.Context, taskId uint, expectedVersion uint) (models.Task, error) {
+	task, err := queryTaskWithRole(ctx, taskId, models.RoleEditor)
+	if err != nil {
+		return models.Task{}, err
+	}
+
+	if expectedVersion != AnyVersion && task.Version != expectedVersion {
+		return models.Task{}, ErrVersionMismatch
+	}
+
+	return task, nil
+}
+
+// Runs the read-modify-write change again when the task changed between the read and the write,
+// unless the client expects a version: then the change is stale and fails with ErrVersionMismatch.
+// Without expected version, it fails with ErrConcurrentChange once the attempts are exhausted.
+func retryOnConflict(expectedVersion uint, change func() error) error {
+	for attempt := 1; ; attempt++ {
+		err := change()
+		if expectedVersion != AnyVersion || !errors.Is(err, ErrVersionMismatch) {
+			return err
+		} else if attempt == conflictAttempts {
+			return ErrConcurrentChange
+		}
+	}
 }
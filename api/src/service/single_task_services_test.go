@@ -1,13 +1,13 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"testing"
 	"time"
 	"to-do-api/models"
 
-	"bou.ke/monkey"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -18,11 +18,11 @@ const testDueDate = int64(1770844785)
 func TestCreateNewTask(t *testing.T) {
 	mockTaskID := uint(1)
 
-	// Mock models.AddTask function
-	monkey.Patch(models.AddTask, func(task models.Task) (uint, error) {
+	// Mock repository.AddTask function
+	repository := setMockRepository()
+	repository.addTask = func(task models.Task) (uint, error) {
 		return mockTaskID, nil
-	})
-	defer monkey.UnpatchAll()
+	}
 
 	title := "Test Task"
 	priority := uint(1)
@@ -39,19 +39,36 @@ func TestCreateNewTask(t *testing.T) {
 	}
 
 	// Run function
-	taskID, err := CreateNewTask(taskRequest)
+	taskID, err := CreateNewTask(context.Background(), taskRequest)
 
 	// Assertions
 	assert.Nil(t, err)
 	assert.Equal(t, mockTaskID, taskID)
 }
 
+func TestCreateNewTaskOnlyTitle(t *testing.T) {
+	repository := setMockRepository()
+
+	title := "Test Task"
+	taskRequest := TaskRequestBody{Title: &title}
+
+	// Run function
+	taskID, err := CreateNewTask(context.Background(), taskRequest)
+
+	// Assertions
+	assert.Nil(t, err)
+	storedTask, _ := repository.QueryTask(context.Background(), noUser, taskID)
+	assert.Equal(t, title, storedTask.Title)
+	assert.Equal(t, "", storedTask.Description)
+	assert.True(t, storedTask.DueDate.IsZero())
+}
+
 func TestCreateNewTaskDBError(t *testing.T) {
-	// Mock models.AddTask to return an error
-	monkey.Patch(models.AddTask, func(task models.Task) (uint, error) {
+	// Mock repository.AddTask to return an error
+	repository := setMockRepository()
+	repository.addTask = func(task models.Task) (uint, error) {
 		return 0, errors.New("database error")
-	})
-	defer monkey.UnpatchAll()
+	}
 
 	title := "Test Task"
 	priority := uint(1)
@@ -68,7 +85,7 @@ func TestCreateNewTaskDBError(t *testing.T) {
 	}
 
 	// Run function
-	_, err := CreateNewTask(taskRequest)
+	_, err := CreateNewTask(context.Background(), taskRequest)
 
 	// Assertions
 	assert.Equal(t, errors.New("fail processing request on database"), err)
@@ -90,28 +107,28 @@ func TestGetTaskById(t *testing.T) {
 	}
 
 	expectedTask := TaskResponseBody{
-		Id:          1,
-		Title:       "Test Task",
-		Description: "This is a test task",
-		Status:      "done",
-		Priority:    uint16(5),
-		CreatedAt:   testCreatedAt,
-		DueDate:     testDueDate,
+		Id:           1,
+		Title:        "Test Task",
+		Description:  "This is a test task",
+		Status:       "done",
+		Priority:     uint16(5),
+		CreatedAt:    testCreatedAt,
+		DueDate:      testDueDate,
+		Dependencies: []uint{2},
+		Subtasks:     []uint{},
 	}
 
-	// Mock models.CheckExistence function
-	monkey.Patch(models.CheckExistence, func(taskId uint) (bool, error) {
-		return true, nil
-	})
-
-	// Mock models.QueryTask function
-	monkey.Patch(models.QueryTask, func(taskId uint) (models.Task, error) {
+	// Mock repository.QueryTask function
+	repository := setMockRepository()
+	repository.queryTask = func(taskId uint) (models.Task, error) {
 		return mockTask, nil
-	})
-	defer monkey.UnpatchAll()
+	}
+	repository.queryDependencies = func(taskId uint) ([]uint, error) {
+		return []uint{2}, nil
+	}
 
 	// Run function
-	task, err := GetTaskById(1)
+	task, err := GetTaskById(context.Background(), 1)
 
 	// Assertions
 	assert.Nil(t, err)
@@ -120,13 +137,13 @@ func TestGetTaskById(t *testing.T) {
 
 func TestGetTaskByIdInvalidId(t *testing.T) {
 
-	monkey.Patch(models.CheckExistence, func(taskId uint) (bool, error) {
-		return false, sql.ErrNoRows
-	})
-	defer monkey.UnpatchAll()
+	repository := setMockRepository()
+	repository.queryTask = func(taskId uint) (models.Task, error) {
+		return models.Task{}, sql.ErrNoRows
+	}
 
 	// Run function
-	_, err := GetTaskById(1)
+	_, err := GetTaskById(context.Background(), 1)
 
 	// Assertions
 	assert.Equal(t, errors.New("requested resource not found on database"), err)
@@ -136,19 +153,14 @@ func TestGetTaskByIdServerError(t *testing.T) {
 
 	var mockTask models.Task
 
-	// Mock models.CheckExistence function
-	monkey.Patch(models.CheckExistence, func(taskId uint) (bool, error) {
-		return true, nil
-	})
-
-	// Mock models.QueryTask function
-	monkey.Patch(models.QueryTask, func(taskId uint) (models.Task, error) {
+	// Mock repository.QueryTask function
+	repository := setMockRepository()
+	repository.queryTask = func(taskId uint) (models.Task, error) {
 		return mockTask, sql.ErrTxDone
-	})
-	defer monkey.UnpatchAll()
+	}
 
 	// Run function
-	_, err := GetTaskById(1)
+	_, err := GetTaskById(context.Background(), 1)
 
 	// Assertions
 	assert.Equal(t, errors.New("fail processing request on database"), err)
@@ -169,16 +181,16 @@ func TestUpdateTask(t *testing.T) {
 		DueDate:     mockDueDate,
 	}
 
-	// Mock models.QueryTask function
-	monkey.Patch(models.QueryTask, func(taskId uint) (models.Task, error) {
+	// Mock repository.QueryTask function
+	repository := setMockRepository()
+	repository.queryTask = func(taskId uint) (models.Task, error) {
 		return mockTask, nil
-	})
+	}
 
-	// Mock models.UpdateTask function
-	monkey.Patch(models.UpdateTask, func(models.Task) error {
+	// Mock repository.UpdateTask function
+	repository.updateTask = func(models.Task) error {
 		return nil
-	})
-	defer monkey.UnpatchAll()
+	}
 
 	title := "Update Test Task Title"
 	priority := uint(2)
@@ -195,7 +207,7 @@ func TestUpdateTask(t *testing.T) {
 	}
 
 	// Run function
-	err := UpdateTask(1, taskRequest)
+	err := UpdateTask(context.Background(), 1, taskRequest, AnyVersion)
 
 	// Assertions
 	assert.Nil(t, err)
@@ -204,12 +216,11 @@ func TestUpdateTask(t *testing.T) {
 func TestUpdateTaskInvalidId(t *testing.T) {
 	var mockTask models.Task
 
-	// Mock models.QueryTask function
-	monkey.Patch(models.QueryTask, func(taskId uint) (models.Task, error) {
+	// Mock repository.QueryTask function
+	repository := setMockRepository()
+	repository.queryTask = func(taskId uint) (models.Task, error) {
 		return mockTask, sql.ErrNoRows
-	})
-
-	defer monkey.UnpatchAll()
+	}
 
 	title := "Update Test Task Title"
 
@@ -218,7 +229,7 @@ func TestUpdateTaskInvalidId(t *testing.T) {
 	}
 
 	// Run function
-	err := UpdateTask(1, taskRequest)
+	err := UpdateTask(context.Background(), 1, taskRequest, AnyVersion)
 
 	// Assertions
 	assert.Equal(t, errors.New("requested resource not found on database"), err)
@@ -227,17 +238,16 @@ func TestUpdateTaskInvalidId(t *testing.T) {
 func TestUpdateTaskQueryServerError(t *testing.T) {
 	var mockTask models.Task
 
-	// Mock models.QueryTask function
-	monkey.Patch(models.QueryTask, func(taskId uint) (models.Task, error) {
+	// Mock repository.QueryTask function
+	repository := setMockRepository()
+	repository.queryTask = func(taskId uint) (models.Task, error) {
 		return mockTask, sql.ErrConnDone
-	})
-
-	defer monkey.UnpatchAll()
+	}
 
 	var taskRequest TaskRequestBody
 
 	// Run function
-	err := UpdateTask(1, taskRequest)
+	err := UpdateTask(context.Background(), 1, taskRequest, AnyVersion)
 
 	// Assertions
 	assert.Equal(t, errors.New("fail processing request on database"), err)
@@ -246,21 +256,21 @@ func TestUpdateTaskQueryServerError(t *testing.T) {
 func TestUpdateTaskExecServerError(t *testing.T) {
 	var mockTask models.Task
 
-	// Mock models.QueryTask function
-	monkey.Patch(models.QueryTask, func(taskId uint) (models.Task, error) {
+	// Mock repository.QueryTask function
+	repository := setMockRepository()
+	repository.queryTask = func(taskId uint) (models.Task, error) {
 		return mockTask, nil
-	})
+	}
 
-	// Mock models.UpdateTask function
-	monkey.Patch(models.UpdateTask, func(models.Task) error {
+	// Mock repository.UpdateTask function
+	repository.updateTask = func(models.Task) error {
 		return sql.ErrTxDone
-	})
-	defer monkey.UnpatchAll()
+	}
 
 	var taskRequest TaskRequestBody
 
 	// Run function
-	err := UpdateTask(1, taskRequest)
+	err := UpdateTask(context.Background(), 1, taskRequest, AnyVersion)
 
 	// Assertions
 	assert.Equal(t, errors.New("fail processing request on database"), err)
@@ -269,19 +279,19 @@ func TestUpdateTaskExecServerError(t *testing.T) {
 // Delete Task test /////////////////////////////////////////////////
 func TestDeleteTask(t *testing.T) {
 
-	// Mock models.CheckExistence function
-	monkey.Patch(models.CheckExistence, func(taskId uint) (bool, error) {
-		return true, nil
-	})
+	// Mock repository.QueryTask function
+	repository := setMockRepository()
+	repository.queryTask = func(taskId uint) (models.Task, error) {
+		return models.Task{Id: taskId}, nil
+	}
 
-	// Mock models.DeleteTask function
-	monkey.Patch(models.DeleteTask, func(taskId uint) error {
+	// Mock repository.DeleteTask function
+	repository.deleteTask = func(taskId uint) error {
 		return nil
-	})
-	defer monkey.UnpatchAll()
+	}
 
 	// Run function
-	err := DeleteTask(1)
+	err := DeleteTask(context.Background(), 1, AnyVersion)
 
 	// Assertions
 	assert.Nil(t, err)
@@ -289,15 +299,14 @@ func TestDeleteTask(t *testing.T) {
 
 func TestDeleteTaskInvalidId(t *testing.T) {
 
-	// Mock models.CheckExistence function
-	monkey.Patch(models.CheckExistence, func(taskId uint) (bool, error) {
-		return false, nil
-	})
-
-	defer monkey.UnpatchAll()
+	// Mock repository.QueryTask function
+	repository := setMockRepository()
+	repository.queryTask = func(taskId uint) (models.Task, error) {
+		return models.Task{}, sql.ErrNoRows
+	}
 
 	// Run function
-	err := DeleteTask(1)
+	err := DeleteTask(context.Background(), 1, AnyVersion)
 
 	// Assertions
 	assert.Equal(t, errors.New("requested resource not found on database"), err)
@@ -305,20 +314,121 @@ func TestDeleteTaskInvalidId(t *testing.T) {
 
 func TestDeleteTaskServerError(t *testing.T) {
 
-	// Mock models.CheckExistence function
-	monkey.Patch(models.CheckExistence, func(taskId uint) (bool, error) {
-		return true, nil
-	})
+	// Mock repository.QueryTask function
+	repository := setMockRepository()
+	repository.queryTask = func(taskId uint) (models.Task, error) {
+		return models.Task{Id: taskId}, nil
+	}
 
-	// Mock models.DeleteTask function
-	monkey.Patch(models.DeleteTask, func(taskId uint) error {
+	// Mock repository.DeleteTask function
+	repository.deleteTask = func(taskId uint) error {
 		return sql.ErrTxDone
-	})
-	defer monkey.UnpatchAll()
+	}
 
 	// Run function
-	err := DeleteTask(1)
+	err := DeleteTask(context.Background(), 1, AnyVersion)
 
 	// Assertions
 	assert.Equal(t, errors.New("fail processing request on database"), err)
 }
+
+func TestUpdateTaskVersionMismatch(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Test Task"})
+
+	title := "New title"
+	err := UpdateTask(context.Background(), 1, TaskRequestBody{Title: &title}, 2)
+	assert.Equal(t, ErrVersionMismatch, err, "Update based on another version should be rejected")
+
+	err = UpdateTask(context.Background(), 1, TaskRequestBody{Title: &title}, 1)
+	assert.Nil(t, err)
+
+	task, _ := GetTaskById(context.Background(), 1)
+	assert.Equal(t, title, task.Title)
+	assert.Equal(t, uint(2), task.Version, "Version should be incremented")
+
+	err = DeleteTask(context.Background(), 1, 1)
+	assert.Equal(t, ErrVersionMismatch, err, "Deletion based on a stale version should be rejected")
+}
+
+func TestUpdateTaskConcurrentChange(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Test Task"})
+
+	// Another request changes the task between the read and the write of the first attempt, the
+	// first read returning the task from before the change
+	concurrentTitle := "Concurrent title"
+	staleTask, _ := repository.MemoryTaskRepository.QueryTask(context.Background(), noUser, 1)
+	concurrentTask := staleTask
+	concurrentTask.Title = concurrentTitle
+	repository.MemoryTaskRepository.UpdateTask(context.Background(), noUser, concurrentTask)
+	attempts := 0
+	repository.queryTask = func(taskId uint) (models.Task, error) {
+		attempts++
+		if attempts == 1 {
+			return staleTask, nil
+		}
+		return repository.MemoryTaskRepository.QueryTask(context.Background(), noUser, taskId)
+	}
+
+	priority := uint(3)
+	err := PatchTask(context.Background(), 1, TaskPatch{Set: TaskRequestBody{Priority: &priority}}, AnyVersion)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempts, "Update without expected version should be retried")
+	task, _ := GetTaskById(context.Background(), 1)
+	assert.Equal(t, concurrentTitle, task.Title, "Concurrent change should not be overwritten")
+	assert.Equal(t, uint16(3), task.Priority)
+
+	attempts = 0
+	err = PatchTask(context.Background(), 1, TaskPatch{Set: TaskRequestBody{Priority: &priority}}, task.Version)
+	assert.Equal(t, ErrVersionMismatch, err, "Update with expected version should not be retried")
+}
+
+func TestUpdateTaskConcurrentChangeExhausted(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Test Task"})
+
+	// The task changes concurrently on every attempt
+	attempts := 0
+	repository.updateTask = func(updatedTask models.Task) error {
+		attempts++
+		return models.ErrVersionConflict
+	}
+
+	priority := uint(3)
+	err := PatchTask(context.Background(), 1, TaskPatch{Set: TaskRequestBody{Priority: &priority}}, AnyVersion)
+
+	assert.Equal(t, ErrConcurrentChange, err, "Exhausted retries without expected version are not a failed precondition")
+	assert.Equal(t, conflictAttempts, attempts)
+}
+
+func TestUpdateTaskReplacesMissingFields(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Test Task", Description: "Old description", Status: "backlog", Priority: 4, DueDate: time.Unix(testDueDate, 0)})
+
+	title := "New title"
+	status := "backlog"
+	err := UpdateTask(context.Background(), 1, TaskRequestBody{Title: &title, Status: &status}, AnyVersion)
+
+	assert.Nil(t, err)
+	task, _ := repository.QueryTask(context.Background(), noUser, 1)
+	assert.Equal(t, title, task.Title)
+	assert.Equal(t, "", task.Description, "Missing fields should take their default value")
+	assert.Equal(t, uint16(0), task.Priority, "Missing fields should take their default value")
+	assert.True(t, task.DueDate.IsZero(), "Missing fields should take their default value")
+}
+
+func TestPatchTaskClearsNullFields(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Test Task", Description: "Description", Status: "backlog", Priority: 4, DueDate: time.Unix(testDueDate, 0)})
+
+	priority := uint(2)
+	err := PatchTask(context.Background(), 1, TaskPatch{Set: TaskRequestBody{Priority: &priority}, Cleared: []string{"due_date"}}, AnyVersion)
+
+	assert.Nil(t, err)
+	task, _ := repository.QueryTask(context.Background(), noUser, 1)
+	assert.Equal(t, uint16(2), task.Priority)
+	assert.True(t, task.DueDate.IsZero(), "Null fields should be removed")
+	assert.Equal(t, "Description", task.Description, "Missing fields should be kept")
+}
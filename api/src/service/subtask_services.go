@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"to-do-api/models"
+)
+
+// GetSubtasks lists the direct subtasks of the task
+func GetSubtasks(ctx context.Context, taskId uint) ([]TaskInfo, error) {
+	if _, err := taskRepository.QueryTask(ctx, currentUser(ctx), taskId); err != nil {
+		return []TaskInfo{}, databaseError("Query Task", err)
+	}
+
+	tasks, err := taskRepository.QuerySubtasks(ctx, currentUser(ctx), taskId)
+	if err != nil {
+		return []TaskInfo{}, databaseError("Query Subtasks", err)
+	}
+
+	subtasks := []TaskInfo{}
+	for _, task := range tasks {
+		subtasks = append(subtasks, newTaskInfo(task))
+	}
+
+	return subtasks, nil
+}
+
+// Ids of the direct subtasks among the descendants of the task, and the percentage of the descendants done
+func subtasksProgress(taskId uint, descendants []models.Task) ([]uint, *uint) {
+	subtasks := []uint{}
+	if len(descendants) == 0 {
+		return subtasks, nil
+	}
+
+	done := 0
+	for _, task := range descendants {
+		if task.ParentId == taskId {
+			subtasks = append(subtasks, task.Id)
+		}
+		if workflow.isDone(task.Status) {
+			done++
+		}
+	}
+	completion := uint(done * 100 / len(descendants))
+
+	return subtasks, &completion
+}
+
+// Checks the user can make the task a subtask of the parent (0 meaning no parent).
+// taskId is 0 for a new task, which can not have subtasks yet.
+func checkParentAssignable(ctx context.Context, taskId uint, parentId uint) error {
+	if parentId == 0 {
+		return nil
+	}
+	if parentId == taskId {
+		return ErrParentCycle
+	}
+
+	if _, err := queryTaskWithRole(ctx, parentId, models.RoleEditor); errors.Is(err, ErrRowNotFound) {
+		return fmt.Errorf("%w: parent task %d not found", ErrInvalidInput, parentId)
+	} else if err != nil {
+		return err
+	}
+
+	if taskId == 0 {
+		return nil
+	}
+	// Held until the change is committed, so the parents can not change meanwhile. The parents
+	// in the trash count, as restoring them would bring back the cycle.
+	if err := taskRepository.LockParents(ctx); err != nil {
+		return databaseError("Lock Parents", err)
+	}
+	ancestorIds, err := taskRepository.QueryAncestorIds(ctx, parentId)
+	if err != nil {
+		return databaseError("Query Parents", err)
+	}
+	if slices.Contains(ancestorIds, taskId) {
+		return ErrParentCycle
+	}
+
+	return nil
+}
+
+// Moves the subtasks of the task to the trash at any depth, the ones the user can not edit being left as they are
+func trashSubtasks(ctx context.Context, taskId uint) error {
+	descendants, err := taskRepository.QueryDescendants(ctx, currentUser(ctx), taskId)
+	if err != nil {
+		return databaseError("Query Subtasks", err)
+	}
+
+	for _, subtask := range descendants {
+		if err = checkTaskRole(ctx, subtask, models.RoleEditor); errors.Is(err, ErrForbidden) {
+			continue
+		} else if err != nil {
+			return err
+		}
+		if err = trashTask(ctx, subtask); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Restores the subtasks deleted together with the task, at any depth: the ones deleted in the same
+// transaction, sharing its deletion time. The subtasks deleted on their own before or after the task
+// stay in the trash, with their own subtasks.
+func restoreSubtasks(ctx context.Context, task models.Task) error {
+	trash, err := trashRepository.QueryDeletedTasks(ctx, currentUser(ctx))
+	if err != nil {
+		return databaseError("Query Deleted Tasks", err)
+	}
+
+	restored := map[uint]bool{task.Id: true}
+	pending := []uint{task.Id}
+	for len(pending) > 0 {
+		parentId := pending[0]
+		pending = pending[1:]
+		for _, subtask := range trash {
+			if subtask.ParentId != parentId || restored[subtask.Id] || !subtask.DeletedAt.Equal(task.DeletedAt) {
+				continue
+			}
+			if err = restoreTrashedTask(ctx, subtask); err != nil {
+				return err
+			}
+			restored[subtask.Id] = true
+			pending = append(pending, subtask.Id)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"to-do-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Creates the task 1 with the subtasks 2 (done) and 3, and 4 subtask of 3
+func setSubtasksTestTasks() *mockTaskRepository {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Epic", Status: "open"})
+	repository.AddTask(context.Background(), models.Task{Title: "Done", Status: "done", ParentId: 1})
+	repository.AddTask(context.Background(), models.Task{Title: "Story", Status: "backlog", ParentId: 1})
+	repository.AddTask(context.Background(), models.Task{Title: "Step", Status: "backlog", ParentId: 3})
+	return repository
+}
+
+func TestGetSubtasks(t *testing.T) {
+	setSubtasksTestTasks()
+
+	subtasks, err := GetSubtasks(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint{2, 3}, []uint{subtasks[0].Id, subtasks[1].Id}, "Only the direct subtasks should be listed")
+
+	_, err = GetSubtasks(context.Background(), 10)
+	assert.Equal(t, ErrRowNotFound, err)
+}
+
+func TestGetTaskCompletion(t *testing.T) {
+	setSubtasksTestTasks()
+
+	task, err := GetTaskById(context.Background(), 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []uint{2, 3}, task.Subtasks)
+	assert.Equal(t, uint(33), *task.Completion, "One of the three subtasks is done")
+
+	task, _ = GetTaskById(context.Background(), 4)
+	assert.Equal(t, uint(3), task.ParentId)
+	assert.Nil(t, task.Completion, "Task without subtasks should have no completion")
+}
+
+func TestSetTaskParent(t *testing.T) {
+	setSubtasksTestTasks()
+
+	parentId := uint(1)
+	taskId, err := CreateNewTask(context.Background(), TaskRequestBody{Title: new(string), ParentId: &parentId})
+	assert.Nil(t, err)
+	task, _ := GetTaskById(context.Background(), taskId)
+	assert.Equal(t, parentId, task.ParentId)
+
+	parentId = 4
+	err = PatchTask(context.Background(), 1, TaskPatch{Set: TaskRequestBody{ParentId: &parentId}}, AnyVersion)
+	assert.Equal(t, ErrParentCycle, err, "Task should not become a subtask of its subtasks")
+
+	parentId = 1
+	err = PatchTask(context.Background(), 1, TaskPatch{Set: TaskRequestBody{ParentId: &parentId}}, AnyVersion)
+	assert.Equal(t, ErrParentCycle, err, "Task should not become its own subtask")
+
+	parentId = 10
+	err = PatchTask(context.Background(), 4, TaskPatch{Set: TaskRequestBody{ParentId: &parentId}}, AnyVersion)
+	assert.True(t, errors.Is(err, ErrInvalidInput), "Inexistent parent should be rejected")
+
+	err = PatchTask(context.Background(), 4, TaskPatch{Cleared: []string{"parent_id"}}, AnyVersion)
+	assert.Nil(t, err)
+	task, _ = GetTaskById(context.Background(), 4)
+	assert.Equal(t, uint(0), task.ParentId, "Cleared parent should make a top level task")
+}
+
+func TestDeleteAndRestoreSubtasks(t *testing.T) {
+	repository := setSubtasksTestTasks()
+
+	// Deleted on its own before the parent, so it stays in the trash
+	DeleteTask(context.Background(), 2, AnyVersion)
+
+	err := DeleteTask(context.Background(), 1, AnyVersion)
+	assert.Nil(t, err)
+	trash, _ := GetTrash(context.Background())
+	assert.Len(t, trash, 4, "Subtasks should be deleted with the task")
+
+	err = RestoreTask(context.Background(), 4)
+	assert.Equal(t, ErrParentDeleted, err, "Subtask should not be restored before its parent")
+
+	err = RestoreTask(context.Background(), 1)
+	assert.Nil(t, err)
+	for _, taskId := range []uint{1, 3, 4} {
+		exists, _ := repository.CheckExistence(context.Background(), noUser, taskId)
+		assert.True(t, exists, "Subtasks deleted with the task should be restored")
+	}
+	exists, _ := repository.CheckExistence(context.Background(), noUser, 2)
+	assert.False(t, exists, "Subtask deleted before the task should stay in the trash")
+
+	events, _ := repository.QueryTaskEvents(context.Background(), 4)
+	assert.Equal(t, []string{models.ActionDelete, models.ActionRestore}, []string{events[0].Action, events[1].Action})
+}
+
+// Alice shares the project with Bob as viewer: tasks 1 -> 2 are Bob's, their subtask 3 is Alice's
+func setSharedSubtasksTestTasks(t *testing.T) (context.Context, context.Context) {
+	setMockRepository()
+	SetUserRepository(models.NewMemoryUserRepository())
+	ownerId, _ := userRepository.AddUser(context.Background(), models.User{Username: "alice"})
+	memberId, _ := userRepository.AddUser(context.Background(), models.User{Username: "bob"})
+	ownerCtx := ContextWithUser(context.Background(), ownerId)
+	memberCtx := ContextWithUser(context.Background(), memberId)
+
+	name := "Backend"
+	projectId, _ := CreateProject(ownerCtx, ProjectRequestBody{Name: &name})
+	username := "bob"
+	role := models.RoleEditor
+	SetProjectMember(ownerCtx, projectId, ProjectMemberRequestBody{Username: &username, Role: &role})
+	title := "Test Task"
+	rootId, _ := CreateNewTask(memberCtx, TaskRequestBody{Title: &title, ProjectId: &projectId})
+	parentId, _ := CreateNewTask(memberCtx, TaskRequestBody{Title: &title, ProjectId: &projectId, ParentId: &rootId})
+	_, err := CreateNewTask(ownerCtx, TaskRequestBody{Title: &title, ProjectId: &projectId, ParentId: &parentId})
+	assert.Nil(t, err)
+	role = models.RoleViewer
+	SetProjectMember(ownerCtx, projectId, ProjectMemberRequestBody{Username: &username, Role: &role})
+
+	return ownerCtx, memberCtx
+}
+
+func TestDeleteSubtasksNotEditable(t *testing.T) {
+	_, memberCtx := setSharedSubtasksTestTasks(t)
+
+	err := DeleteTask(memberCtx, 2, AnyVersion)
+
+	assert.Nil(t, err, "Subtasks the user can not edit should not prevent deleting the task")
+	_, err = GetTaskById(memberCtx, 2)
+	assert.Equal(t, ErrRowNotFound, err)
+	_, err = GetTaskById(memberCtx, 3)
+	assert.Nil(t, err, "Subtasks the user can not edit should be left as they are")
+}
+
+func TestSetTaskParentThroughTrash(t *testing.T) {
+	ownerCtx, memberCtx := setSharedSubtasksTestTasks(t)
+	assert.Nil(t, DeleteTask(memberCtx, 2, AnyVersion), "Subtask 3 should be left out of the trash")
+
+	parentId := uint(3)
+	err := PatchTask(ownerCtx, 1, TaskPatch{Set: TaskRequestBody{ParentId: &parentId}}, AnyVersion)
+
+	assert.Equal(t, ErrParentCycle, err, "Restoring the deleted parent would close a cycle")
+}
+
+func TestRestoreSubtasksDeletedAfterTask(t *testing.T) {
+	ownerCtx, memberCtx := setSharedSubtasksTestTasks(t)
+	assert.Nil(t, DeleteTask(memberCtx, 2, AnyVersion))
+	assert.Nil(t, DeleteTask(ownerCtx, 3, AnyVersion))
+
+	assert.Nil(t, RestoreTask(ownerCtx, 2))
+
+	_, err := GetTaskById(ownerCtx, 3)
+	assert.Equal(t, ErrRowNotFound, err, "Subtask deleted on its own after the task should stay in the trash")
+}
+
+func TestDeleteSubtasksRollback(t *testing.T) {
+	repository := setSubtasksTestTasks()
+	repository.deleteTask = func(taskId uint) error {
+		if taskId == 4 {
+			return errors.New("connection reset")
+		}
+		return repository.MemoryTaskRepository.DeleteTask(context.Background(), noUser, taskId, 1)
+	}
+
+	err := DeleteTask(context.Background(), 1, AnyVersion)
+
+	assert.Equal(t, ErrDatabaseGeneral, err)
+	repository.deleteTask = nil
+	trash, _ := GetTrash(context.Background())
+	assert.Empty(t, trash, "Failed deletion should be rolled back")
+}
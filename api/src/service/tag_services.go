@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"to-do-api/models"
+)
+
+// Color of the tags created without one
+const defaultTagColor = "#808080"
+
+type TagRequestBody struct {
+	Name  *string `json:"name"`
+	Color *string `json:"color"` // hexadecimal RGB, as #rrggbb
+}
+
+type TagInfo struct {
+	Id    uint   `json:"id"`
+	Name  string `json:"name"`
+	Color string `json:"color"`
+}
+
+func CreateTag(ctx context.Context, tag TagRequestBody) (uint, error) {
+	newTag := models.Tag{
+		OwnerId: currentUser(ctx),
+		Name:    *tag.Name,
+		Color:   defaultTagColor,
+	}
+	if tag.Color != nil {
+		newTag.Color = strings.ToLower(*tag.Color)
+	}
+
+	tagId, err := tagRepository.AddTag(ctx, newTag)
+	if err != nil {
+		return 0, databaseError("Create Tag", err)
+	}
+
+	return tagId, nil
+}
+
+// GetTags lists the tags of the user ordered by name
+func GetTags(ctx context.Context) ([]TagInfo, error) {
+	tagsInfo := []TagInfo{}
+
+	tags, err := tagRepository.QueryTags(ctx, currentUser(ctx))
+	if err != nil {
+		return tagsInfo, databaseError("Query Tags", err)
+	}
+
+	for _, tag := range tags {
+		tagsInfo = append(tagsInfo, TagInfo{Id: tag.Id, Name: tag.Name, Color: tag.Color})
+	}
+
+	return tagsInfo, nil
+}
+
+func UpdateTag(ctx context.Context, tagId uint, tag TagRequestBody) error {
+	currentTag, err := tagRepository.QueryTag(ctx, currentUser(ctx), tagId)
+	if err != nil {
+		return databaseError("Query Tag", err)
+	}
+
+	if tag.Name != nil {
+		currentTag.Name = *tag.Name
+	}
+	if tag.Color != nil {
+		currentTag.Color = strings.ToLower(*tag.Color)
+	}
+
+	if err = tagRepository.UpdateTag(ctx, currentTag); err != nil {
+		return databaseError("Update Tag", err)
+	}
+
+	return nil
+}
+
+// DeleteTag removes the tag, together with its labels on the tasks
+func DeleteTag(ctx context.Context, tagId uint) error {
+	if err := tagRepository.DeleteTag(ctx, currentUser(ctx), tagId); err != nil {
+		return databaseError("Delete Tag", err)
+	}
+
+	return nil
+}
+
+// AddTaskTag labels the task with one of the tags of the user
+func AddTaskTag(ctx context.Context, taskId uint, tagId uint) error {
+	if _, err := queryTaskWithRole(ctx, taskId, models.RoleEditor); err != nil {
+		return err
+	}
+	if _, err := tagRepository.QueryTag(ctx, currentUser(ctx), tagId); err != nil {
+		return databaseError("Query Tag", err)
+	}
+
+	if err := tagRepository.AddTaskTag(ctx, taskId, tagId); err != nil {
+		return databaseError("Add Task Tag", err)
+	}
+
+	return nil
+}
+
+// RemoveTaskTag removes the tag from the task, whoever the tag belongs to
+func RemoveTaskTag(ctx context.Context, taskId uint, tagId uint) error {
+	if _, err := queryTaskWithRole(ctx, taskId, models.RoleEditor); err != nil {
+		return err
+	}
+
+	if err := tagRepository.DeleteTaskTag(ctx, taskId, tagId); err != nil {
+		return databaseError("Remove Task Tag", err)
+	}
+
+	return nil
+}
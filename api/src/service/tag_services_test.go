@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"to-do-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func tagRequest(name string, color string) TagRequestBody {
+	request := TagRequestBody{Name: &name}
+	if color != "" {
+		request.Color = &color
+	}
+	return request
+}
+
+func TestTags(t *testing.T) {
+	setMockRepository()
+
+	bugId, err := CreateTag(context.Background(), tagRequest("bug", "#FF0000"))
+	assert.Nil(t, err)
+	_, err = CreateTag(context.Background(), tagRequest("urgent", ""))
+	assert.Nil(t, err)
+	_, err = CreateTag(context.Background(), tagRequest("bug", ""))
+	assert.Equal(t, ErrTagExists, err, "Tag names should be unique")
+
+	err = UpdateTag(context.Background(), bugId, tagRequest("urgent", ""))
+	assert.Equal(t, ErrTagExists, err, "Tag should not take the name of another one")
+	err = UpdateTag(context.Background(), bugId, tagRequest("defect", ""))
+	assert.Nil(t, err)
+
+	tags, err := GetTags(context.Background())
+	assert.Nil(t, err)
+	assert.Equal(t, []TagInfo{{Id: 1, Name: "defect", Color: "#ff0000"}, {Id: 2, Name: "urgent", Color: defaultTagColor}}, tags)
+
+	otherUserCtx := ContextWithUser(context.Background(), 2)
+	tags, _ = GetTags(otherUserCtx)
+	assert.Empty(t, tags, "Tags of other users should not be listed")
+	assert.Equal(t, ErrRowNotFound, DeleteTag(otherUserCtx, bugId))
+}
+
+func TestTaskTags(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Task 1", Status: "backlog"})
+	repository.AddTask(context.Background(), models.Task{Title: "Task 2", Status: "backlog"})
+	bugId, _ := CreateTag(context.Background(), tagRequest("bug", ""))
+	urgentId, _ := CreateTag(context.Background(), tagRequest("urgent", ""))
+
+	assert.Nil(t, AddTaskTag(context.Background(), 1, bugId))
+	assert.Nil(t, AddTaskTag(context.Background(), 1, urgentId))
+	assert.Nil(t, AddTaskTag(context.Background(), 2, bugId))
+	assert.Equal(t, ErrRowNotFound, AddTaskTag(context.Background(), 1, 9), "Tag should exist")
+
+	task, _ := GetTaskById(context.Background(), 1)
+	assert.Equal(t, []string{"bug", "urgent"}, task.Tags)
+
+	filterConfig, _ := CreateFilterConfig("", "", "", "", "", "bug,urgent", "")
+	tasks, _ := GetTasksList(context.Background(), filterConfig, defaultPageConfig)
+	assert.Len(t, tasks, 2, "Tasks with any of the tags should be listed")
+	filterConfig, _ = CreateFilterConfig("", "", "", "", "", "bug,urgent", "all")
+	tasks, _ = GetTasksList(context.Background(), filterConfig, defaultPageConfig)
+	assert.Len(t, tasks, 1, "Only tasks with all the tags should be listed")
+
+	assert.Nil(t, RemoveTaskTag(context.Background(), 1, urgentId))
+	assert.Equal(t, ErrRowNotFound, RemoveTaskTag(context.Background(), 1, urgentId))
+}
+
+func TestValidateTagInput(t *testing.T) {
+	assert.Equal(t, errors.New("missing required field: 'name'"), ValidateNewTagInput(TagRequestBody{}))
+	assert.Equal(t, errors.New("invalid name: must be at most 50 letters, numbers, '-' or '_'"), ValidateNewTagInput(tagRequest("bug,urgent", "")))
+	assert.Equal(t, errors.New("invalid color: must be hexadecimal RGB, as '#1e90ff'"), ValidateNewTagInput(tagRequest("bug", "red")))
+	assert.Nil(t, ValidateNewTagInput(tagRequest("high-priority", "#1E90FF")))
+
+	assert.Equal(t, errors.New("at least one field must be present: 'name', 'color'"), ValidateUpdateTagInput(TagRequestBody{}))
+}
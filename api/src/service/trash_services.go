@@ -0,0 +1,140 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"time"
+	"to-do-api/models"
+)
+
+// Days the deleted tasks are kept in the trash when TRASH_RETENTION_DAYS is not defined
+const defaultTrashRetentionDays = 30
+
+// How often the tasks past the retention are purged
+const trashPurgeInterval = time.Hour
+
+type TrashedTaskInfo struct {
+	TaskInfo
+	DeletedAt int64 `json:"deleted_at"`
+}
+
+// GetTrash lists the deleted tasks the user can access, the last deleted first
+func GetTrash(ctx context.Context) ([]TrashedTaskInfo, error) {
+	tasks, err := trashRepository.QueryDeletedTasks(ctx, currentUser(ctx))
+	if err != nil {
+		return []TrashedTaskInfo{}, databaseError("Query Deleted Tasks", err)
+	}
+
+	trash := []TrashedTaskInfo{}
+	for _, task := range tasks {
+		trash = append(trash, TrashedTaskInfo{TaskInfo: newTaskInfo(task), DeletedAt: task.DeletedAt.Unix()})
+	}
+
+	return trash, nil
+}
+
+// RestoreTask moves the task back from the trash together with the subtasks deleted with it,
+// requiring the same role as deleting it. A subtask can not be restored while its parent is in the trash.
+func RestoreTask(ctx context.Context, taskId uint) error {
+	return inTransaction(ctx, "Restore Task", func(txCtx context.Context) error {
+		task, err := trashRepository.QueryDeletedTask(txCtx, currentUser(txCtx), taskId)
+		if err != nil {
+			return databaseError("Query Deleted Task", err)
+		}
+
+		if task.ParentId != 0 {
+			_, err = trashRepository.QueryDeletedTask(txCtx, currentUser(txCtx), task.ParentId)
+			if err == nil {
+				return ErrParentDeleted
+			} else if err = databaseError("Query Deleted Task", err); !errors.Is(err, ErrRowNotFound) {
+				return err
+			}
+		}
+
+		if err = restoreTrashedTask(txCtx, task); err != nil {
+			return err
+		}
+		return restoreSubtasks(txCtx, task)
+	})
+}
+
+func restoreTrashedTask(ctx context.Context, task models.Task) error {
+	if err := checkTaskRole(ctx, task, models.RoleEditor); err != nil {
+		return err
+	}
+
+	if err := trashRepository.RestoreTask(ctx, currentUser(ctx), task.Id); err != nil {
+		return databaseError("Restore Task", err)
+	}
+	task.DeletedAt = time.Time{}
+
+	return recordTaskEvent(ctx, models.ActionRestore, task.Id, nil, &task)
+}
+
+// PurgeTrash removes for good the tasks deleted more than retention ago, with the contents of their attachments
+func PurgeTrash(ctx context.Context, retention time.Duration) (uint, error) {
+	deletedBefore := time.Now().Add(-retention)
+
+	var purged uint
+	blobKeys := []string{}
+	err := inTransaction(ctx, "Purge Deleted Tasks", func(txCtx context.Context) error {
+		// The tasks with attachments stay locked until the purge, so they can not be restored without their contents
+		attachments, err := attachmentRepository.QueryPurgeableAttachments(txCtx, deletedBefore)
+		if err != nil {
+			return databaseError("Query Purgeable Attachments", err)
+		}
+		for _, attachment := range attachments {
+			blobKeys = append(blobKeys, attachment.BlobKey)
+		}
+
+		if purged, err = trashRepository.PurgeDeletedTasks(txCtx, deletedBefore); err != nil {
+			return databaseError("Purge Deleted Tasks", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	// Contents are removed once the purge is committed, never for tasks still existing
+	deleteBlobs(ctx, blobKeys)
+
+	return purged, nil
+}
+
+// LoadTrashRetention reads how long the deleted tasks are kept from the TRASH_RETENTION_DAYS
+// env variable. 0 keeps them until restored.
+func LoadTrashRetention() time.Duration {
+	value, exist := os.LookupEnv("TRASH_RETENTION_DAYS")
+	if !exist || value == "" {
+		return defaultTrashRetentionDays * 24 * time.Hour
+	}
+
+	days, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		log.Fatalf("Invalid TRASH_RETENTION_DAYS value '%s', must be a number of days >= 0", value)
+	}
+
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// StartTrashPurge periodically purges the trash in the background, unless retention is 0.
+// The returned function stops it, waiting for the purge in progress
+func StartTrashPurge(retention time.Duration) (stop func()) {
+	if retention == 0 {
+		return func() {}
+	}
+
+	return runPeriodically(trashPurgeInterval, func(ctx context.Context) {
+		purged, err := PurgeTrash(ctx, retention)
+		if err != nil && ctx.Err() == nil {
+			fmt.Printf("Error purging the trash. e: %v\n", err)
+		} else if purged > 0 {
+			fmt.Printf("Purged %d tasks from the trash\n", purged)
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+	"to-do-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeleteAndRestoreTask(t *testing.T) {
+	repository := setMockRepository()
+
+	title := "Test Task"
+	taskId, _ := CreateNewTask(context.Background(), TaskRequestBody{Title: &title})
+	DeleteTask(context.Background(), taskId, AnyVersion)
+
+	_, err := GetTaskById(context.Background(), taskId)
+	assert.Equal(t, ErrRowNotFound, err, "Deleted task should not be found")
+
+	trash, err := GetTrash(context.Background())
+	assert.Nil(t, err)
+	assert.Len(t, trash, 1)
+	assert.Equal(t, title, trash[0].Title)
+	assert.NotZero(t, trash[0].DeletedAt)
+
+	err = RestoreTask(context.Background(), taskId)
+	assert.Nil(t, err)
+
+	task, err := GetTaskById(context.Background(), taskId)
+	assert.Nil(t, err, "Restored task should be found")
+	assert.Equal(t, title, task.Title)
+
+	events, _ := repository.QueryTaskEvents(context.Background(), taskId)
+	assert.Equal(t, models.ActionRestore, events[len(events)-1].Action)
+	assert.Contains(t, events[len(events)-1].Changes, models.FieldChange{Field: "title", Before: nil, After: title})
+}
+
+func TestRestoreTaskNotInTrash(t *testing.T) {
+	setMockRepository()
+
+	title := "Test Task"
+	taskId, _ := CreateNewTask(context.Background(), TaskRequestBody{Title: &title})
+
+	err := RestoreTask(context.Background(), taskId)
+
+	assert.Equal(t, ErrRowNotFound, err)
+}
+
+func TestRestoreTaskViewer(t *testing.T) {
+	setMockRepository()
+	SetUserRepository(models.NewMemoryUserRepository())
+	ownerId, _ := userRepository.AddUser(context.Background(), models.User{Username: "alice"})
+	viewerId, _ := userRepository.AddUser(context.Background(), models.User{Username: "bob"})
+	ownerCtx := ContextWithUser(context.Background(), ownerId)
+
+	projectName := "Project"
+	projectId, _ := CreateProject(ownerCtx, ProjectRequestBody{Name: &projectName})
+	viewerRole := models.RoleViewer
+	bobName := "bob"
+	SetProjectMember(ownerCtx, projectId, ProjectMemberRequestBody{Username: &bobName, Role: &viewerRole})
+
+	title := "Test Task"
+	taskId, _ := CreateNewTask(ownerCtx, TaskRequestBody{Title: &title, ProjectId: &projectId})
+	DeleteTask(ownerCtx, taskId, AnyVersion)
+
+	viewerCtx := ContextWithUser(context.Background(), viewerId)
+	trash, _ := GetTrash(viewerCtx)
+	assert.Len(t, trash, 1, "Viewers should see the deleted tasks of the project")
+
+	err := RestoreTask(viewerCtx, taskId)
+	assert.Equal(t, ErrForbidden, err, "Viewers should not restore tasks")
+}
+
+func TestPurgeTrash(t *testing.T) {
+	setMockRepository()
+
+	title := "Test Task"
+	taskId, _ := CreateNewTask(context.Background(), TaskRequestBody{Title: &title})
+	DeleteTask(context.Background(), taskId, AnyVersion)
+
+	purged, err := PurgeTrash(context.Background(), time.Hour)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(0), purged, "Tasks within the retention should be kept")
+
+	purged, _ = PurgeTrash(context.Background(), -time.Minute)
+	assert.Equal(t, uint(1), purged)
+
+	trash, _ := GetTrash(context.Background())
+	assert.Empty(t, trash)
+}
+
+func TestLoadTrashRetention(t *testing.T) {
+	t.Setenv("TRASH_RETENTION_DAYS", "")
+	assert.Equal(t, 30*24*time.Hour, LoadTrashRetention())
+
+	t.Setenv("TRASH_RETENTION_DAYS", "7")
+	assert.Equal(t, 7*24*time.Hour, LoadTrashRetention())
+}
@@ -0,0 +1,136 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+	"to-do-api/models"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UserRequestBody struct {
+	Username *string `json:"username"`
+	Password *string `json:"password"`
+}
+
+type AuthTokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"` // seconds until the access token expires
+}
+
+type userContextKey struct{}
+
+// Hash compared on logins of unknown usernames, so they take as long as the wrong
+// passwords and the response time does not reveal which usernames are registered
+var dummyPasswordHash = []byte("$2a$10$rUwCPZOU1wdqjm1rr1o4zerJvqIFvE6yBavofsDbrFWRi/0jcn442")
+
+func RegisterUser(ctx context.Context, user UserRequestBody) (uint, error) {
+	// bcrypt generates a random salt for each password and stores it in the hash
+	passwordHash, err := bcrypt.GenerateFromPassword([]byte(*user.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return 0, err
+	}
+
+	userId, err := userRepository.AddUser(ctx, models.User{
+		Username:     *user.Username,
+		PasswordHash: string(passwordHash),
+		CreatedAt:    time.Now(),
+	})
+	if err != nil {
+		return 0, databaseError("Create User", err)
+	}
+
+	return userId, nil
+}
+
+func Login(ctx context.Context, credentials UserRequestBody) (AuthTokens, error) {
+	user, err := userRepository.QueryUserByUsername(ctx, *credentials.Username)
+	if err != nil {
+		err = databaseError("Query User", err)
+		if errors.Is(err, ErrRowNotFound) {
+			bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(*credentials.Password))
+			return AuthTokens{}, ErrInvalidCredentials
+		}
+		return AuthTokens{}, err
+	}
+
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(*credentials.Password)) != nil {
+		return AuthTokens{}, ErrInvalidCredentials
+	}
+
+	return newAuthTokens(user.Id), nil
+}
+
+// RefreshTokens issues a new pair of tokens from a valid refresh token
+func RefreshTokens(ctx context.Context, token string) (AuthTokens, error) {
+	userId, err := parseToken(token, refreshToken)
+	if err != nil {
+		return AuthTokens{}, err
+	}
+
+	// The user may have been removed since the token was issued
+	if _, err = userRepository.QueryUser(ctx, userId); err != nil {
+		err = databaseError("Query User", err)
+		if errors.Is(err, ErrRowNotFound) {
+			return AuthTokens{}, ErrInvalidToken
+		}
+		return AuthTokens{}, err
+	}
+
+	return newAuthTokens(userId), nil
+}
+
+// AuthenticateToken returns the user of a valid access token
+func AuthenticateToken(token string) (uint, error) {
+	return parseToken(token, accessToken)
+}
+
+// ContextWithUser stores the authenticated user in the request context
+func ContextWithUser(ctx context.Context, userId uint) context.Context {
+	return context.WithValue(ctx, userContextKey{}, userId)
+}
+
+// UserFromContext returns the authenticated user of the request, if any
+func UserFromContext(ctx context.Context) (uint, bool) {
+	userId, found := ctx.Value(userContextKey{}).(uint)
+	return userId, found
+}
+
+// User the request acts on behalf of, 0 (no user) when not authenticated
+func currentUser(ctx context.Context) uint {
+	userId, _ := UserFromContext(ctx)
+	return userId
+}
+
+// Usernames of the users, each one queried once
+type usernameCache map[uint]string
+
+// Username of the user, empty for unknown users (id 0)
+func (usernames usernameCache) username(ctx context.Context, userId uint) (string, error) {
+	if userId == 0 {
+		return "", nil
+	}
+	if username, known := usernames[userId]; known {
+		return username, nil
+	}
+
+	user, err := userRepository.QueryUser(ctx, userId)
+	if err != nil {
+		return "", databaseError("Query User", err)
+	}
+	usernames[userId] = user.Username
+
+	return user.Username, nil
+}
+
+func newAuthTokens(userId uint) AuthTokens {
+	return AuthTokens{
+		AccessToken:  issueToken(userId, accessToken, authConfig.AccessTokenTTL),
+		RefreshToken: issueToken(userId, refreshToken, authConfig.RefreshTokenTTL),
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(authConfig.AccessTokenTTL.Seconds()),
+	}
+}
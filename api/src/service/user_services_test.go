@@ -0,0 +1,122 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+	"to-do-api/models"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func setMockUserRepository() *models.MemoryUserRepository {
+	repository := models.NewMemoryUserRepository()
+	SetUserRepository(repository)
+	SetAuthConfig(AuthConfig{Secret: []byte("test secret"), AccessTokenTTL: time.Minute, RefreshTokenTTL: time.Hour})
+	return repository
+}
+
+func newUserRequest(username string, password string) UserRequestBody {
+	return UserRequestBody{Username: &username, Password: &password}
+}
+
+func TestRegisterAndLogin(t *testing.T) {
+	repository := setMockUserRepository()
+
+	userId, err := RegisterUser(context.Background(), newUserRequest("alice", "password123"))
+	assert.Nil(t, err)
+
+	// Password is never stored in plain text
+	storedUser, _ := repository.QueryUser(context.Background(), userId)
+	assert.NotEqual(t, "password123", storedUser.PasswordHash)
+
+	tokens, err := Login(context.Background(), newUserRequest("alice", "password123"))
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer", tokens.TokenType)
+	assert.Equal(t, int64(60), tokens.ExpiresIn)
+
+	authenticatedId, err := AuthenticateToken(tokens.AccessToken)
+	assert.Nil(t, err)
+	assert.Equal(t, userId, authenticatedId)
+}
+
+func TestRegisterUserTaken(t *testing.T) {
+	setMockUserRepository()
+	RegisterUser(context.Background(), newUserRequest("alice", "password123"))
+
+	_, err := RegisterUser(context.Background(), newUserRequest("alice", "other password"))
+
+	assert.Equal(t, ErrUserExists, err)
+}
+
+func TestLoginInvalidCredentials(t *testing.T) {
+	setMockUserRepository()
+	RegisterUser(context.Background(), newUserRequest("alice", "password123"))
+
+	_, err := Login(context.Background(), newUserRequest("alice", "wrong password"))
+	assert.Equal(t, ErrInvalidCredentials, err)
+
+	_, err = Login(context.Background(), newUserRequest("bob", "password123"))
+	assert.Equal(t, ErrInvalidCredentials, err)
+}
+
+func TestDummyPasswordHashCost(t *testing.T) {
+	cost, err := bcrypt.Cost(dummyPasswordHash)
+
+	assert.Nil(t, err)
+	assert.Equal(t, bcrypt.DefaultCost, cost, "Unknown usernames should cost the same as the registered ones")
+}
+
+func TestRefreshTokens(t *testing.T) {
+	setMockUserRepository()
+	userId, _ := RegisterUser(context.Background(), newUserRequest("alice", "password123"))
+	tokens, _ := Login(context.Background(), newUserRequest("alice", "password123"))
+
+	newTokens, err := RefreshTokens(context.Background(), tokens.RefreshToken)
+	assert.Nil(t, err)
+	authenticatedId, _ := AuthenticateToken(newTokens.AccessToken)
+	assert.Equal(t, userId, authenticatedId)
+
+	// Access tokens can not be used to refresh
+	_, err = RefreshTokens(context.Background(), tokens.AccessToken)
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func TestRefreshTokensUnknownUser(t *testing.T) {
+	setMockUserRepository()
+
+	_, err := RefreshTokens(context.Background(), issueToken(10, refreshToken, time.Hour))
+
+	assert.Equal(t, ErrInvalidToken, err)
+}
+
+func TestAuthenticateInvalidTokens(t *testing.T) {
+	setMockUserRepository()
+	validToken := issueToken(1, accessToken, time.Minute)
+
+	_, err := AuthenticateToken(issueToken(1, accessToken, -time.Minute))
+	assert.Equal(t, ErrInvalidToken, err, "Expired token should be rejected")
+
+	_, err = AuthenticateToken(issueToken(1, refreshToken, time.Hour))
+	assert.Equal(t, ErrInvalidToken, err, "Refresh token should be rejected")
+
+	_, err = AuthenticateToken(validToken[:len(validToken)-2] + "xx")
+	assert.Equal(t, ErrInvalidToken, err, "Tampered token should be rejected")
+
+	SetAuthConfig(AuthConfig{Secret: []byte("other secret"), AccessTokenTTL: time.Minute})
+	_, err = AuthenticateToken(validToken)
+	assert.Equal(t, ErrInvalidToken, err, "Token signed by other secret should be rejected")
+
+	_, err = AuthenticateToken("not a token")
+	assert.Equal(t, ErrInvalidToken, err, "Malformed token should be rejected")
+}
+
+func TestUserFromContext(t *testing.T) {
+	_, found := UserFromContext(context.Background())
+	assert.False(t, found)
+
+	userId, found := UserFromContext(ContextWithUser(context.Background(), 3))
+	assert.True(t, found)
+	assert.Equal(t, uint(3), userId)
+}
@@ -1,12 +1,12 @@
 package service
 
 import (
+	"context"
 	"database/sql"
 	"errors"
 	"testing"
-	"to-do-api/models"
+	"time"
 
-	"bou.ke/monkey"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -45,28 +45,41 @@ func TestValidateUpdateTaskInput(t *testing.T) {
 	status := "done"
 	dueDate := int64(1770843800)
 
-	err = ValidateUpdateTaskInput(TaskRequestBody{Title: &title})
-	assert.Nil(t, err, "Should return no error for input containing Title")
+	err = ValidateUpdateTaskInput(TaskRequestBody{Title: &title, Status: &status})
+	assert.Nil(t, err, "Should return no error for input containing Title and Status")
 
-	err = ValidateUpdateTaskInput(TaskRequestBody{Description: &desc})
-	assert.Nil(t, err, "Should return no error for input containing Description")
-
-	err = ValidateUpdateTaskInput(TaskRequestBody{Priority: &prio})
-	assert.Nil(t, err, "Should return no error for input containing Priority")
+	err = ValidateUpdateTaskInput(TaskRequestBody{Title: &title, Description: &desc, Priority: &prio, Status: &status, DueDate: &dueDate})
+	assert.Nil(t, err, "Should return no error for input containing all info")
 
+	// Check invalid Info
 	err = ValidateUpdateTaskInput(TaskRequestBody{Status: &status})
-	assert.Nil(t, err, "Should return no error for input containing Status")
+	assert.Equal(t, errors.New("missing required field: 'title'"), err, "Should return Error for missing title")
 
-	err = ValidateUpdateTaskInput(TaskRequestBody{DueDate: &dueDate})
-	assert.Nil(t, err, "Should return no error for input containing DueDate")
+	err = ValidateUpdateTaskInput(TaskRequestBody{Title: &title, Description: &desc})
+	assert.Equal(t, errors.New("missing required field: 'status'"), err, "Should return Error for missing status")
+}
 
-	err = ValidateUpdateTaskInput(TaskRequestBody{Title: &title, Description: &desc, Priority: &prio, Status: &status, DueDate: &dueDate})
-	assert.Nil(t, err, "Should return no error for input containing all info")
+func TestValidateTaskPatchInput(t *testing.T) {
+	patch, err := ValidateTaskPatchInput([]byte(`{"title": "New Task", "due_date": null, "project_id": null}`))
+	assert.Nil(t, err)
+	assert.Equal(t, "New Task", *patch.Set.Title)
+	assert.Nil(t, patch.Set.DueDate)
+	assert.Equal(t, []string{"due_date", "project_id"}, patch.Cleared)
 
-	// Check invalid Info
-	err = ValidateUpdateTaskInput(TaskRequestBody{})
-	assert.Equal(t, errors.New("at least one field must be present: 'title', 'description', 'priority', 'status, 'due_date'"), err, "Should return Error for invalid update input")
+	_, err = ValidateTaskPatchInput([]byte(`{}`))
+	assert.Equal(t, errors.New("at least one field must be present: 'title', 'description', 'priority', 'status', 'due_date', 'estimate_hours', 'project_id', 'parent_id', 'recurrence'"), err)
+
+	_, err = ValidateTaskPatchInput([]byte(`{"title": null}`))
+	assert.Equal(t, errors.New("field 'title' can not be removed"), err)
 
+	_, err = ValidateTaskPatchInput([]byte(`{"owner": 3}`))
+	assert.Equal(t, errors.New("unknown field 'owner'"), err)
+
+	_, err = ValidateTaskPatchInput([]byte(`[1]`))
+	assert.Equal(t, errors.New("patch must be a JSON object"), err)
+
+	_, err = ValidateTaskPatchInput([]byte(`{"priority": "high"}`))
+	assert.NotNil(t, err, "Should return Error for invalid field type")
 }
 
 func TestValidateTaskIdInput(t *testing.T) {
@@ -92,35 +105,35 @@ func TestValidateTaskIdInput(t *testing.T) {
 }
 
 func TestCheckIdExistValidId(t *testing.T) {
-	// Mock models.AddTask to return an error
-	monkey.Patch(models.CheckExistence, func(taskId uint) (bool, error) {
+	// Mock repository.AddTask to return an error
+	repository := setMockRepository()
+	repository.checkExistence = func(taskId uint) (bool, error) {
 		return true, nil
-	})
-	defer monkey.UnpatchAll()
+	}
 
-	idExist, _ := checkIdExist(uint(2))
+	idExist, _ := checkIdExist(context.Background(), uint(2))
 	assert.True(t, idExist, "Should return True for valid Id")
 }
 
 func TestCheckIdExistInvalidId(t *testing.T) {
-	// Mock models.AddTask to return an error
-	monkey.Patch(models.CheckExistence, func(taskId uint) (bool, error) {
+	// Mock repository.AddTask to return an error
+	repository := setMockRepository()
+	repository.checkExistence = func(taskId uint) (bool, error) {
 		return false, nil
-	})
-	defer monkey.UnpatchAll()
+	}
 
-	idExist, _ := checkIdExist(uint(2))
+	idExist, _ := checkIdExist(context.Background(), uint(2))
 	assert.False(t, idExist, "Should return False for invalid Id")
 }
 
 func TestCheckIdExistDBError(t *testing.T) {
-	// Mock models.AddTask to return an error
-	monkey.Patch(models.CheckExistence, func(taskId uint) (bool, error) {
+	// Mock repository.AddTask to return an error
+	repository := setMockRepository()
+	repository.checkExistence = func(taskId uint) (bool, error) {
 		return false, sql.ErrConnDone
-	})
-	defer monkey.UnpatchAll()
+	}
 
-	_, err := checkIdExist(uint(2))
+	_, err := checkIdExist(context.Background(), uint(2))
 	assert.Equal(t, ErrDatabaseGeneral, err, "Should return Error for problems in DB")
 }
 
@@ -261,3 +274,68 @@ func TestIsValidPriorityFilter(t *testing.T) {
 	assert.False(t, isValid, "Should return False for negative input")
 
 }
+
+func TestValidateScheduleStartInput(t *testing.T) {
+	start, err := ValidateScheduleStartInput("1738569600")
+	assert.Nil(t, err, "Should not return Error for valid timestamp")
+	assert.Equal(t, int64(1738569600), start.Unix(), "Should return the informed start")
+
+	_, err = ValidateScheduleStartInput("tomorrow")
+	assert.Equal(t, errors.New("invalid 'start' value, must be a Unix timestamp"), err, "Should return Error for invalid timestamp")
+}
+
+func TestValidateUserInput(t *testing.T) {
+	username := "alice"
+	password := "password123"
+	shortPassword := "short"
+	invalidUsername := "a!"
+
+	assert.Nil(t, ValidateUserInput(UserRequestBody{Username: &username, Password: &password}), "Should not return Error for valid user")
+	assert.Equal(t, errors.New("missing required fields: 'username' and 'password'"), ValidateUserInput(UserRequestBody{Username: &username}), "Should return Error for missing password")
+	assert.Equal(t, errors.New("username must have 3 to 32 letters, numbers or '_.-'"), ValidateUserInput(UserRequestBody{Username: &invalidUsername, Password: &password}), "Should return Error for invalid username")
+	assert.Equal(t, errors.New("password must have between 8 and 72 characters"), ValidateUserInput(UserRequestBody{Username: &username, Password: &shortPassword}), "Should return Error for short password")
+}
+
+func TestValidateIfMatchInput(t *testing.T) {
+	version, err := ValidateIfMatchInput("")
+	assert.Nil(t, err)
+	assert.Equal(t, AnyVersion, version)
+
+	version, _ = ValidateIfMatchInput("*")
+	assert.Equal(t, AnyVersion, version)
+
+	version, err = ValidateIfMatchInput(`"12"`)
+	assert.Nil(t, err)
+	assert.Equal(t, uint(12), version)
+
+	_, err = ValidateIfMatchInput(`W/"12"`)
+	assert.Equal(t, ErrVersionMismatch, err, "Weak tags should never match")
+
+	_, err = ValidateIfMatchInput("12")
+	assert.ErrorIs(t, err, ErrInvalidInput, "Unquoted tags should be rejected")
+}
+
+func TestValidateReminderInput(t *testing.T) {
+	remindAt := time.Now().Add(time.Hour).Unix()
+	pastRemindAt := time.Now().Add(-time.Hour).Unix()
+	beforeDue := uint(30)
+	webhook := ChannelWebhook
+	email := ChannelEmail
+	sms := "sms"
+	recipient := "ada@example.com"
+	namedRecipient := "Ada <ada@example.com>"
+	injectedRecipient := "ada@example.com\r\nBcc: eve@example.com"
+
+	assert.Nil(t, ValidateReminderInput(ReminderRequestBody{RemindAt: &remindAt, Channel: &webhook}), "Should not return Error for valid reminder")
+	assert.Nil(t, ValidateReminderInput(ReminderRequestBody{BeforeDue: &beforeDue, Channel: &email, Recipient: &recipient}), "Should not return Error for valid reminder")
+	assert.Equal(t, errors.New("exactly one of 'remind_at' and 'before_due' must be present"), ValidateReminderInput(ReminderRequestBody{RemindAt: &remindAt, BeforeDue: &beforeDue, Channel: &webhook}))
+	assert.Equal(t, errors.New("exactly one of 'remind_at' and 'before_due' must be present"), ValidateReminderInput(ReminderRequestBody{Channel: &webhook}))
+	assert.Equal(t, errors.New("remind_at must be in the future"), ValidateReminderInput(ReminderRequestBody{RemindAt: &pastRemindAt, Channel: &webhook}))
+	assert.Equal(t, errors.New("missing required field: 'channel'"), ValidateReminderInput(ReminderRequestBody{RemindAt: &remindAt}))
+	assert.Equal(t, errors.New("invalid channel 'sms'. Valid values: ['webhook', 'email']"), ValidateReminderInput(ReminderRequestBody{RemindAt: &remindAt, Channel: &sms}))
+	assert.Equal(t, errors.New("missing required field for the email channel: 'recipient'"), ValidateReminderInput(ReminderRequestBody{RemindAt: &remindAt, Channel: &email}))
+	assert.Equal(t, errors.New("the webhook channel has no 'recipient'"), ValidateReminderInput(ReminderRequestBody{RemindAt: &remindAt, Channel: &webhook, Recipient: &recipient}))
+	for _, invalidRecipient := range []string{"ada", namedRecipient, injectedRecipient} {
+		assert.Equal(t, errors.New("recipient must be an email address"), ValidateReminderInput(ReminderRequestBody{RemindAt: &remindAt, Channel: &email, Recipient: &invalidRecipient}), "Should reject recipient '%s'", invalidRecipient)
+	}
+}
@@ -1,13 +1,16 @@
 package service
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/mail"
 	"regexp"
 	"slices"
 	"strconv"
 	"strings"
-	"to-do-api/models"
+	"time"
 )
 
 var validSortCriteria []string = []string{"id", "title", "status", "priority", "created_at", "due_date"}
@@ -19,17 +22,404 @@ func ValidateNewTaskInput(requestInput TaskRequestBody) error {
 		return errors.New("title must not be empty")
 	}
 
-	return nil
+	if err := validateStatus(requestInput.Status); err != nil {
+		return err
+	}
+	return validateRecurrence(requestInput.Recurrence)
 }
 
+// Fields of a task a merge patch can set to null, the others being required
+var clearableTaskFields = []string{"description", "priority", "due_date", "estimate_hours", "project_id", "parent_id", "recurrence"}
+
+// ValidateUpdateTaskInput validates a full replace of the task: the title and the status are required
 func ValidateUpdateTaskInput(requestInput TaskRequestBody) error {
+	if err := ValidateNewTaskInput(requestInput); err != nil {
+		return err
+	}
+	if requestInput.Status == nil {
+		return errors.New("missing required field: 'status'")
+	}
+
+	return nil
+}
+
+// ValidateTaskPatchInput reads a JSON Merge Patch of a task, where null removes the field
+func ValidateTaskPatchInput(body []byte) (TaskPatch, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(body, &members); err != nil || members == nil {
+		return TaskPatch{}, errors.New("patch must be a JSON object")
+	}
+	if len(members) == 0 {
+		return TaskPatch{}, errors.New("at least one field must be present: 'title', 'description', 'priority', 'status', 'due_date', 'estimate_hours', 'project_id', 'parent_id', 'recurrence'")
+	}
+
+	patch := TaskPatch{}
+	values := map[string]json.RawMessage{}
+	for field, value := range members {
+		clearable := slices.Contains(clearableTaskFields, field)
+		if !clearable && field != "title" && field != "status" {
+			return TaskPatch{}, fmt.Errorf("unknown field '%s'", field)
+		}
+
+		if string(value) != "null" {
+			values[field] = value
+		} else if clearable {
+			patch.Cleared = append(patch.Cleared, field)
+		} else {
+			return TaskPatch{}, fmt.Errorf("field '%s' can not be removed", field)
+		}
+	}
+	slices.Sort(patch.Cleared)
+
+	setFields, _ := json.Marshal(values)
+	if err := json.Unmarshal(setFields, &patch.Set); err != nil {
+		return TaskPatch{}, fmt.Errorf("invalid patch: %v", err)
+	}
+	if patch.Set.Title != nil && *patch.Set.Title == "" {
+		return TaskPatch{}, errors.New("title must not be empty")
+	}
+	if err := validateStatus(patch.Set.Status); err != nil {
+		return TaskPatch{}, err
+	}
+	if err := validateRecurrence(patch.Set.Recurrence); err != nil {
+		return TaskPatch{}, err
+	}
+
+	return patch, nil
+}
+
+// Only the statuses of the workflow are accepted
+func validateStatus(status *string) error {
+	if status == nil {
+		return nil
+	}
+	if _, found := workflow.status(*status); !found {
+		return fmt.Errorf("invalid status '%s'. Valid values: %v", *status, workflow.statusNames())
+	}
+
+	return nil
+}
+
+// Recurrences must be RRULEs of the supported subset, or empty to stop recurring
+func validateRecurrence(recurrence *string) error {
+	if recurrence == nil || *recurrence == "" {
+		return nil
+	}
+	return ValidateRecurrenceInput(*recurrence)
+}
+
+func ValidateRecurrenceInput(recurrence string) error {
+	if len(recurrence) > maxRecurrenceLength {
+		return fmt.Errorf("recurrence must be at most %d characters", maxRecurrenceLength)
+	}
+	if _, err := parseRecurrenceRule(recurrence); err != nil {
+		return fmt.Errorf("invalid recurrence: %v", err)
+	}
+
+	return nil
+}
+
+// ValidateEditScopeInput reads which occurrences of a recurring task a change applies to, only the task by default
+func ValidateEditScopeInput(scope string) (string, error) {
+	if scope == "" {
+		return ScopeThis, nil
+	}
+	if scope != ScopeThis && scope != ScopeFuture {
+		return "", fmt.Errorf("invalid scope '%s'. Valid values: ['%s', '%s']", scope, ScopeThis, ScopeFuture)
+	}
+
+	return scope, nil
+}
+
+func ValidateOccurrenceCountInput(count string) (int, error) {
+	if count == "" {
+		return defaultOccurrenceCount, nil
+	}
+	number, err := strconv.Atoi(count)
+	if err != nil || number < 1 || number > maxOccurrenceCount {
+		return 0, fmt.Errorf("invalid 'count' value, must be between 1 and %d", maxOccurrenceCount)
+	}
+
+	return number, nil
+}
+
+func ValidateBatchInput(requestInput BatchRequestBody) ([]TaskOperation, error) {
+	if requestInput.Mode != "" && requestInput.Mode != BatchAtomic && requestInput.Mode != BatchPerItem {
+		return []TaskOperation{}, fmt.Errorf("invalid mode '%s'. Valid values: ['%s', '%s']", requestInput.Mode, BatchAtomic, BatchPerItem)
+	}
+	if len(requestInput.Operations) == 0 || len(requestInput.Operations) > maxBatchOperations {
+		return []TaskOperation{}, fmt.Errorf("a batch must have between 1 and %d operations", maxBatchOperations)
+	}
+
+	operations := []TaskOperation{}
+	for index, input := range requestInput.Operations {
+		operation, err := validateBatchOperation(input)
+		if err != nil {
+			return []TaskOperation{}, fmt.Errorf("operation %d: %v", index, err)
+		}
+		operations = append(operations, operation)
+	}
+
+	return operations, nil
+}
+
+func validateBatchOperation(input BatchOperationInput) (TaskOperation, error) {
+	operation := TaskOperation{Op: input.Op, TaskId: input.TaskId, Version: input.Version}
+
+	if !slices.Contains([]string{OpCreate, OpUpdate, OpPatch, OpDelete}, input.Op) {
+		return operation, fmt.Errorf("invalid op '%s'. Valid values: ['%s', '%s', '%s', '%s']", input.Op, OpCreate, OpUpdate, OpPatch, OpDelete)
+	}
+	if input.Op != OpCreate && input.TaskId == 0 {
+		return operation, errors.New("missing required field: 'task_id'")
+	}
+	if input.Op != OpDelete && len(input.Task) == 0 {
+		return operation, errors.New("missing required field: 'task'")
+	}
+
+	switch input.Op {
+	case OpCreate, OpUpdate:
+		if err := json.Unmarshal(input.Task, &operation.Task); err != nil {
+			return operation, fmt.Errorf("invalid task: %v", err)
+		}
+		if input.Op == OpCreate {
+			return operation, ValidateNewTaskInput(operation.Task)
+		}
+		return operation, ValidateUpdateTaskInput(operation.Task)
+	case OpPatch:
+		patch, err := ValidateTaskPatchInput(input.Task)
+		operation.Patch = patch
+		return operation, err
+	}
+
+	return operation, nil
+}
+
+func ValidateMassUpdateInput(requestInput MassUpdateRequestBody) (MassUpdate, error) {
+	update := MassUpdate{ShiftDueDateDays: requestInput.ShiftDueDateDays, OverdueOnly: requestInput.OverdueOnly}
+	if len(requestInput.Changes) == 0 && update.ShiftDueDateDays == 0 {
+		return update, errors.New("at least one change must be present: 'changes', 'shift_due_date_days'")
+	}
+
+	if len(requestInput.Changes) > 0 {
+		patch, err := ValidateTaskPatchInput(requestInput.Changes)
+		if err != nil {
+			return update, fmt.Errorf("invalid changes: %v", err)
+		}
+		if update.ShiftDueDateDays != 0 && (patch.Set.DueDate != nil || slices.Contains(patch.Cleared, "due_date")) {
+			return update, errors.New("'changes' can not set the due date together with 'shift_due_date_days'")
+		}
+		update.Patch = patch
+	}
+
+	return update, nil
+}
+
+func ValidateChecklistItemInput(requestInput ChecklistItemRequestBody) error {
+	if requestInput.Text == nil {
+		return errors.New("missing required field: 'text'")
+	}
+
+	return validateChecklistText(requestInput.Text)
+}
+
+// ValidateChecklistItemUpdateInput validates a partial update of the item, at least one field being present
+func ValidateChecklistItemUpdateInput(requestInput ChecklistItemRequestBody) error {
+	if requestInput.Text == nil && requestInput.Checked == nil {
+		return errors.New("at least one field must be present: 'text', 'checked'")
+	}
+
+	return validateChecklistText(requestInput.Text)
+}
+
+func validateChecklistText(text *string) error {
+	if text == nil {
+		return nil
+	}
+	if *text == "" {
+		return errors.New("text must not be empty")
+	} else if len([]rune(*text)) > maxChecklistTextLength {
+		return fmt.Errorf("text must be at most %d characters", maxChecklistTextLength)
+	}
+
+	return nil
+}
+
+func ValidateChecklistOrderInput(requestInput ChecklistOrderRequestBody) error {
+	if len(requestInput.ItemIds) == 0 {
+		return errors.New("missing required field: 'item_ids'")
+	}
+	return nil
+}
+
+func ValidateNewTagInput(requestInput TagRequestBody) error {
+	if requestInput.Name == nil {
+		return errors.New("missing required field: 'name'")
+	}
+
+	return validateTagFields(requestInput)
+}
+
+func ValidateUpdateTagInput(requestInput TagRequestBody) error {
+	if (TagRequestBody{}) == requestInput {
+		return errors.New("at least one field must be present: 'name', 'color'")
+	}
+
+	return validateTagFields(requestInput)
+}
+
+func validateTagFields(requestInput TagRequestBody) error {
+	if requestInput.Name != nil && !isValidTagName(*requestInput.Name) {
+		return fmt.Errorf("invalid name: must be at most %d letters, numbers, '-' or '_'", maxTagNameLength)
+	}
+	if requestInput.Color != nil && !tagColorPattern.MatchString(*requestInput.Color) {
+		return errors.New("invalid color: must be hexadecimal RGB, as '#1e90ff'")
+	}
+
+	return nil
+}
+
+func ValidateTagIdInput(tagIdString string) (uint, error) {
+	tagId, err := strconv.Atoi(tagIdString)
+	if err != nil || tagId < 0 {
+		return 0, errors.New("invalid tag id")
+	}
+
+	return uint(tagId), nil
+}
+
+func ValidateCommentInput(requestInput CommentRequestBody) error {
+	if requestInput.Body == nil {
+		return errors.New("missing required field: 'body'")
+	} else if strings.TrimSpace(*requestInput.Body) == "" {
+		return errors.New("body must not be empty")
+	} else if len([]rune(*requestInput.Body)) > maxCommentLength {
+		return fmt.Errorf("body must be at most %d characters", maxCommentLength)
+	}
+
+	return nil
+}
+
+func ValidateCommentIdInput(commentIdString string) (uint, error) {
+	commentId, err := strconv.Atoi(commentIdString)
+	if err != nil || commentId < 0 {
+		return 0, errors.New("invalid comment id")
+	}
+
+	return uint(commentId), nil
+}
+
+func ValidateAttachmentIdInput(attachmentIdString string) (uint, error) {
+	attachmentId, err := strconv.Atoi(attachmentIdString)
+	if err != nil || attachmentId < 0 {
+		return 0, errors.New("invalid attachment id")
+	}
+
+	return uint(attachmentId), nil
+}
+
+func ValidateReminderInput(requestInput ReminderRequestBody) error {
+	if (requestInput.RemindAt == nil) == (requestInput.BeforeDue == nil) {
+		return errors.New("exactly one of 'remind_at' and 'before_due' must be present")
+	} else if requestInput.RemindAt != nil && time.Unix(*requestInput.RemindAt, 0).Before(time.Now()) {
+		return errors.New("remind_at must be in the future")
+	} else if requestInput.BeforeDue != nil && *requestInput.BeforeDue > maxReminderBeforeDue {
+		return fmt.Errorf("before_due must be at most %d minutes", maxReminderBeforeDue)
+	}
+
+	if requestInput.Channel == nil {
+		return errors.New("missing required field: 'channel'")
+	} else if *requestInput.Channel != ChannelWebhook && *requestInput.Channel != ChannelEmail {
+		return fmt.Errorf("invalid channel '%s'. Valid values: ['%s', '%s']", *requestInput.Channel, ChannelWebhook, ChannelEmail)
+	}
 
-	if (TaskRequestBody{}) == requestInput {
-		return errors.New("at least one field must be present: 'title', 'description', 'priority', 'status, 'due_date'")
+	if *requestInput.Channel == ChannelEmail && requestInput.Recipient == nil {
+		return errors.New("missing required field for the email channel: 'recipient'")
 	}
+	if requestInput.Recipient != nil {
+		if *requestInput.Channel != ChannelEmail {
+			return fmt.Errorf("the %s channel has no 'recipient'", *requestInput.Channel)
+		}
+		// A bare address, so the recipient can not add headers to the mail
+		address, err := mail.ParseAddress(*requestInput.Recipient)
+		if err != nil || address.Name != "" || address.Address != *requestInput.Recipient {
+			return errors.New("recipient must be an email address")
+		}
+	}
+
 	return nil
 }
 
+func ValidateReminderIdInput(reminderIdString string) (uint, error) {
+	reminderId, err := strconv.Atoi(reminderIdString)
+	if err != nil || reminderId < 0 {
+		return 0, errors.New("invalid reminder id")
+	}
+
+	return uint(reminderId), nil
+}
+
+func ValidateNewProjectInput(requestInput ProjectRequestBody) error {
+	if requestInput.Name == nil {
+		return errors.New("missing required field: 'name'")
+	}
+
+	return validateProjectName(requestInput.Name)
+}
+
+func ValidateUpdateProjectInput(requestInput ProjectRequestBody) error {
+	if (ProjectRequestBody{}) == requestInput {
+		return errors.New("at least one field must be present: 'name', 'description', 'archived'")
+	}
+
+	return validateProjectName(requestInput.Name)
+}
+
+func validateProjectName(name *string) error {
+	if name != nil && strings.TrimSpace(*name) == "" {
+		return errors.New("name must not be empty")
+	}
+
+	return nil
+}
+
+func ValidateProjectMemberInput(requestInput ProjectMemberRequestBody) error {
+	if requestInput.Username == nil || requestInput.Role == nil {
+		return errors.New("missing required fields: 'username' and 'role'")
+	}
+	if _, valid := roleRank[*requestInput.Role]; !valid {
+		return errors.New("invalid role. Valid values: ['viewer', 'editor', 'owner']")
+	}
+
+	return nil
+}
+
+func ValidateUserIdInput(userIdString string) (uint, error) {
+	userId, err := strconv.Atoi(userIdString)
+	if err != nil || userId < 0 {
+		return 0, errors.New("invalid user id")
+	}
+
+	return uint(userId), nil
+}
+
+func ValidateProjectIdInput(projectIdString string) (uint, error) {
+	projectId, err := strconv.Atoi(projectIdString)
+	if err != nil || projectId < 0 {
+		return 0, errors.New("invalid project id")
+	}
+
+	return uint(projectId), nil
+}
+
+func ValidateChecklistItemIdInput(itemIdString string) (uint, error) {
+	itemId, err := strconv.Atoi(itemIdString)
+	if err != nil || itemId < 0 {
+		return 0, errors.New("invalid checklist item id")
+	}
+
+	return uint(itemId), nil
+}
+
 func ValidateTaskIdInput(taskIdString string) (uint, error) {
 	taskId, err := strconv.Atoi(taskIdString)
 	if err != nil || taskId < 0 {
@@ -41,10 +431,78 @@ func ValidateTaskIdInput(taskIdString string) (uint, error) {
 
 }
 
-func checkIdExist(taskId uint) (bool, error) {
-	validId, err := models.CheckExistence(taskId)
+// ValidateIfMatchInput reads the task version expected by the If-Match header, AnyVersion when
+// the header is missing or "*"
+func ValidateIfMatchInput(ifMatch string) (uint, error) {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" || ifMatch == "*" {
+		return AnyVersion, nil
+	}
+
+	// Weak tags never match, the If-Match comparison being strong
+	if strings.HasPrefix(ifMatch, "W/") {
+		return 0, ErrVersionMismatch
+	}
+
+	version, err := strconv.ParseUint(strings.Trim(ifMatch, `"`), 10, 32)
+	if err != nil || version == 0 || !strings.HasPrefix(ifMatch, `"`) || !strings.HasSuffix(ifMatch, `"`) {
+		return 0, fmt.Errorf("%w: If-Match must be the ETag of the task, like \"3\"", ErrInvalidInput)
+	}
+
+	return uint(version), nil
+}
+
+var validUsername = regexp.MustCompile(`^[a-zA-Z0-9_.-]{3,32}$`)
+
+func ValidateLoginInput(requestInput UserRequestBody) error {
+	if requestInput.Username == nil || requestInput.Password == nil {
+		return errors.New("missing required fields: 'username' and 'password'")
+	}
+
+	return nil
+}
+
+func ValidateUserInput(requestInput UserRequestBody) error {
+	if err := ValidateLoginInput(requestInput); err != nil {
+		return err
+	}
+	if !validUsername.MatchString(*requestInput.Username) {
+		return errors.New("username must have 3 to 32 letters, numbers or '_.-'")
+	}
+	// bcrypt ignores anything after 72 bytes
+	if len(*requestInput.Password) < 8 || len(*requestInput.Password) > 72 {
+		return errors.New("password must have between 8 and 72 characters")
+	}
+
+	return nil
+}
+
+func ValidateDependencyInput(taskId uint, dependsOnId uint) error {
+	if taskId == dependsOnId {
+		return errors.New("a task can not depend on itself")
+	}
+
+	return nil
+}
+
+// Parses the schedule start (Unix timestamp), now when not informed
+func ValidateScheduleStartInput(startString string) (time.Time, error) {
+	if startString == "" {
+		return time.Now(), nil
+	}
+
+	start, err := strconv.ParseInt(startString, 10, 64)
 	if err != nil {
-		return false, ErrDatabaseGeneral
+		return time.Time{}, errors.New("invalid 'start' value, must be a Unix timestamp")
+	}
+
+	return time.Unix(start, 0), nil
+}
+
+func checkIdExist(ctx context.Context, taskId uint) (bool, error) {
+	validId, err := taskRepository.CheckExistence(ctx, currentUser(ctx), taskId)
+	if err != nil {
+		return false, databaseError("Check Task existence", err)
 	}
 
 	return validId, err
@@ -74,6 +532,38 @@ func isValidTextFilter(input string) bool {
 	return re.MatchString(input)
 }
 
+// Longest tag name accepted by the tags table (VARCHAR(50))
+const maxTagNameLength = 50
+
+var tagNamePattern = regexp.MustCompile(`^[\p{L}\p{N}_-]+$`)
+var tagColorPattern = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+
+// Tag names hold no comma, so they can be listed in the tags filter
+func isValidTagName(name string) bool {
+	return tagNamePattern.MatchString(name) && len([]rune(name)) <= maxTagNameLength
+}
+
+// Parses the comma separated tag names of the tags filter, without duplicates
+func parseTagsFilter(input string) (string, bool) {
+	tagNames := []string{}
+	for _, name := range strings.Split(input, ",") {
+		name = strings.TrimSpace(name)
+		if !isValidTagName(name) {
+			return "", false
+		}
+		if !slices.Contains(tagNames, name) {
+			tagNames = append(tagNames, name)
+		}
+	}
+
+	return strings.Join(tagNames, ","), true
+}
+
+func isValidIdFilter(input string) bool {
+	value, err := strconv.Atoi(input)
+	return err == nil && value > 0
+}
+
 func isValidPriorityFilter(input string) bool {
 	value, err := strconv.Atoi(input)
 	if err != nil {
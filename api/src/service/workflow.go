@@ -0,0 +1,128 @@
+package service
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+)
+
+// Longest status accepted by the tasks table (VARCHAR(20))
+const maxStatusLength = 20
+
+// Workflow defines the statuses of the tasks and how they move between them
+type Workflow struct {
+	Statuses []WorkflowStatus `json:"statuses"` // in display order, new tasks start on the first one
+}
+
+type WorkflowStatus struct {
+	Name        string   `json:"name"`
+	Done        bool     `json:"done"`        // tasks on this status no longer block the tasks depending on them
+	Transitions []string `json:"transitions"` // statuses a task on this status can move to
+}
+
+// defaultWorkflow matches the board columns of the web interface, which moves cards between any of them
+var defaultWorkflow = Workflow{Statuses: []WorkflowStatus{
+	{Name: "backlog", Transitions: []string{"open", "done"}},
+	{Name: "open", Transitions: []string{"backlog", "done"}},
+	{Name: "done", Done: true, Transitions: []string{"backlog", "open"}},
+}}
+
+var workflow = defaultWorkflow
+
+func SetWorkflow(newWorkflow Workflow) {
+	workflow = newWorkflow
+}
+
+func GetWorkflow() Workflow {
+	return workflow
+}
+
+// LoadWorkflow reads the workflow from the JSON file at WORKFLOW_FILE env variable,
+// the default backlog -> open -> done workflow when it is not defined.
+func LoadWorkflow() Workflow {
+	path := os.Getenv("WORKFLOW_FILE")
+	if path == "" {
+		return defaultWorkflow
+	}
+
+	content, err := os.ReadFile(path)
+	if err != nil {
+		log.Fatalf("Failed reading WORKFLOW_FILE '%s': %v", path, err)
+	}
+
+	var loadedWorkflow Workflow
+	if err = json.Unmarshal(content, &loadedWorkflow); err != nil {
+		log.Fatalf("Invalid WORKFLOW_FILE '%s': %v", path, err)
+	}
+	if err = loadedWorkflow.Validate(); err != nil {
+		log.Fatalf("Invalid WORKFLOW_FILE '%s': %v", path, err)
+	}
+
+	return loadedWorkflow
+}
+
+// Validate checks the statuses are unique, fit the tasks table and only transition to known statuses
+func (w Workflow) Validate() error {
+	if len(w.Statuses) == 0 {
+		return errors.New("workflow must have at least one status")
+	}
+
+	for i, status := range w.Statuses {
+		if status.Name == "" || len(status.Name) > maxStatusLength {
+			return fmt.Errorf("status names must have between 1 and %d characters", maxStatusLength)
+		}
+		if !isValidTextFilter(status.Name) {
+			return fmt.Errorf("status '%s' must only have letters, numbers, spaces and basic punctuation", status.Name)
+		}
+		if slices.ContainsFunc(w.Statuses[:i], func(other WorkflowStatus) bool { return other.Name == status.Name }) {
+			return fmt.Errorf("status '%s' is defined twice", status.Name)
+		}
+		for _, next := range status.Transitions {
+			if _, found := w.status(next); !found || next == status.Name {
+				return fmt.Errorf("invalid transition from '%s' to '%s'", status.Name, next)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (w Workflow) status(name string) (WorkflowStatus, bool) {
+	index := slices.IndexFunc(w.Statuses, func(status WorkflowStatus) bool { return status.Name == name })
+	if index < 0 {
+		return WorkflowStatus{}, false
+	}
+
+	return w.Statuses[index], true
+}
+
+func (w Workflow) statusNames() []string {
+	names := []string{}
+	for _, status := range w.Statuses {
+		names = append(names, status.Name)
+	}
+	return names
+}
+
+func (w Workflow) initialStatus() string {
+	return w.Statuses[0].Name
+}
+
+func (w Workflow) isDone(name string) bool {
+	status, found := w.status(name)
+	return found && status.Done
+}
+
+// Checks the workflow allows moving a task between the statuses. Tasks with a status
+// no longer in the workflow (e.g. created before it changed) can move to any status.
+func (w Workflow) checkTransition(from string, to string) error {
+	status, found := w.status(from)
+	if !found || from == to || slices.Contains(status.Transitions, to) {
+		return nil
+	}
+
+	return fmt.Errorf("%w: from '%s' to '%s'", ErrStatusTransition, from, to)
+}
@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"to-do-api/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Workflow where backlog tasks must be opened before being done
+var strictWorkflow = Workflow{Statuses: []WorkflowStatus{
+	{Name: "backlog", Transitions: []string{"open"}},
+	{Name: "open", Transitions: []string{"backlog", "done"}},
+	{Name: "done", Done: true, Transitions: []string{"open"}},
+}}
+
+func setTestWorkflow(t *testing.T, testWorkflow Workflow) {
+	SetWorkflow(testWorkflow)
+	t.Cleanup(func() { SetWorkflow(defaultWorkflow) })
+}
+
+func TestDefaultWorkflowIsValid(t *testing.T) {
+	assert.Nil(t, defaultWorkflow.Validate())
+}
+
+func TestWorkflowValidate(t *testing.T) {
+	duplicated := Workflow{Statuses: []WorkflowStatus{{Name: "todo"}, {Name: "todo"}}}
+	assert.Equal(t, errors.New("status 'todo' is defined twice"), duplicated.Validate())
+
+	unknownTransition := Workflow{Statuses: []WorkflowStatus{{Name: "todo", Transitions: []string{"doing"}}}}
+	assert.Equal(t, errors.New("invalid transition from 'todo' to 'doing'"), unknownTransition.Validate())
+
+	tooLong := Workflow{Statuses: []WorkflowStatus{{Name: "waiting for the customer"}}}
+	assert.Equal(t, errors.New("status names must have between 1 and 20 characters"), tooLong.Validate())
+
+	assert.Equal(t, errors.New("workflow must have at least one status"), Workflow{}.Validate())
+}
+
+func TestValidateNewTaskInputStatus(t *testing.T) {
+	title := "Test Task"
+	status := "pending"
+
+	err := ValidateNewTaskInput(TaskRequestBody{Title: &title, Status: &status})
+
+	assert.Equal(t, errors.New("invalid status 'pending'. Valid values: [backlog open done]"), err)
+}
+
+func TestCreateNewTaskInitialStatus(t *testing.T) {
+	repository := setMockRepository()
+
+	title := "Test Task"
+	taskId, _ := CreateNewTask(context.Background(), TaskRequestBody{Title: &title})
+
+	task, _ := repository.QueryTask(context.Background(), noUser, taskId)
+	assert.Equal(t, "backlog", task.Status)
+}
+
+func TestUpdateTaskStatusTransition(t *testing.T) {
+	setTestWorkflow(t, strictWorkflow)
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Test Task", Status: "backlog"})
+
+	done := "done"
+	err := PatchTask(context.Background(), 1, TaskPatch{Set: TaskRequestBody{Status: &done}}, AnyVersion)
+	assert.ErrorIs(t, err, ErrStatusTransition)
+	assert.EqualError(t, err, "status transition not allowed by the workflow: from 'backlog' to 'done'")
+
+	open := "open"
+	assert.Nil(t, PatchTask(context.Background(), 1, TaskPatch{Set: TaskRequestBody{Status: &open}}, AnyVersion))
+	assert.Nil(t, PatchTask(context.Background(), 1, TaskPatch{Set: TaskRequestBody{Status: &done}}, AnyVersion))
+}
+
+func TestUpdateTaskStatusOutsideWorkflow(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Test Task", Status: "pending"})
+
+	done := "done"
+	err := PatchTask(context.Background(), 1, TaskPatch{Set: TaskRequestBody{Status: &done}}, AnyVersion)
+
+	assert.Nil(t, err, "Tasks with a status outside the workflow should move to any status")
+}
+
+func TestUpdateExistingOpenTask(t *testing.T) {
+	repository := setMockRepository()
+	repository.AddTask(context.Background(), models.Task{Title: "Test Task", Status: "open", Version: 1})
+
+	title := "Renamed Task"
+	status := "open"
+	assert.Nil(t, UpdateTask(context.Background(), 1, TaskRequestBody{Title: &title, Status: &status}, AnyVersion), "Tasks created before the workflow should keep their status")
+
+	done := "done"
+	assert.Nil(t, UpdateTask(context.Background(), 1, TaskRequestBody{Title: &title, Status: &done}, AnyVersion))
+	backlog := "backlog"
+	assert.Nil(t, UpdateTask(context.Background(), 1, TaskRequestBody{Title: &title, Status: &backlog}, AnyVersion), "The board should move cards between any columns")
+
+	task, _ := repository.QueryTask(context.Background(), noUser, 1)
+	assert.Equal(t, "backlog", task.Status)
+}
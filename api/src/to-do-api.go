@@ -1,8 +1,11 @@
 package main
 
 import (
+	"fmt"
+	"os"
 	"to-do-api/controllers"
 	"to-do-api/models"
+	"to-do-api/service"
 )
 
 // @title			To-Do API
@@ -10,7 +13,71 @@ import (
 // @description	To-Do List API for managing tasks to be done. It provides the basic functions of Creating, Updating, Deleting and Querying single or multiple tasks.
 // @termsOfService	http://swagger.io/terms/
 func main() {
-	models.InitDatabase()
+	// Database migrations: to-do-api migrate up | down [steps] | status
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		pool := models.ConnectDatabase()
+		err := runMigrateCommand(pool, os.Args[2:])
+		pool.Close()
+
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Migration failed: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Select the storage backend from STORAGE_BACKEND env variable ("postgres" by default)
+	if os.Getenv("STORAGE_BACKEND") == "memory" {
+		repository := models.NewMemoryTaskRepository()
+		service.SetTaskRepository(repository)
+		service.SetDependencyRepository(repository)
+		service.SetProjectRepository(repository)
+		service.SetTaskEventRepository(repository)
+		service.SetTrashRepository(repository)
+		service.SetChecklistRepository(repository)
+		service.SetTagRepository(repository)
+		service.SetCommentRepository(repository)
+		service.SetAttachmentRepository(repository)
+		service.SetSeriesRepository(repository)
+		service.SetReminderRepository(repository)
+		service.SetTransactor(repository)
+		service.SetUserRepository(models.NewMemoryUserRepository())
+		service.SetBlobStore(models.NewMemoryBlobStore())
+	} else {
+		pool := models.ConnectDatabase()
+		defer pool.Close()
+
+		if _, err := models.MigrateUp(pool); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to migrate database: %v\n", err)
+			pool.Close()
+			os.Exit(1)
+		}
+		repository := models.NewPostgresTaskRepository(pool, models.GetQueryTimeout())
+		service.SetTaskRepository(repository)
+		service.SetDependencyRepository(repository)
+		service.SetProjectRepository(repository)
+		service.SetTaskEventRepository(repository)
+		service.SetTrashRepository(repository)
+		service.SetChecklistRepository(repository)
+		service.SetTagRepository(repository)
+		service.SetCommentRepository(repository)
+		service.SetAttachmentRepository(repository)
+		service.SetSeriesRepository(repository)
+		service.SetReminderRepository(repository)
+		service.SetTransactor(repository)
+		service.SetBlobStore(models.ConnectBlobStore())
+		service.SetUserRepository(models.NewPostgresUserRepository(pool, models.GetQueryTimeout()))
+	}
+	service.SetAuthConfig(service.LoadAuthConfig())
+	service.SetWorkflow(service.LoadWorkflow())
+	service.SetMaxAttachmentSize(service.LoadMaxAttachmentSize())
+	service.SetNotifiers(service.LoadNotifiers())
+	stopTrashPurge := service.StartTrashPurge(service.LoadTrashRetention())
+	stopReminderScheduler := service.StartReminderScheduler(service.LoadReminderCheckInterval())
 
 	controllers.StartAPI()
+
+	// The background jobs end their run in progress before the pool is closed
+	stopReminderScheduler()
+	stopTrashPurge()
 }